@@ -30,6 +30,8 @@ type Config struct {
 	OTPSendPerMin               int
 	OTPSendIPPerMin             int
 	OTPSendBotPerMin            int
+	NotifyPerMin                int
+	NotifyBotPerMin             int
 	LinkTokenTTL                time.Duration
 	LinkTokenRateLimitPerMin    int
 	LinkTokenRateLimitIPPerMin  int
@@ -57,6 +59,8 @@ func Load() (Config, error) {
 		OTPSendPerMin:               otpPerMin,
 		OTPSendIPPerMin:             intOr("OTP_RATE_LIMIT_IP_PER_MIN", otpPerMin),
 		OTPSendBotPerMin:            otpBotPerMin,
+		NotifyPerMin:                intOr("NOTIFY_RATE_LIMIT_PER_MIN", 20),
+		NotifyBotPerMin:             intOr("NOTIFY_RATE_LIMIT_BOT_PER_MIN", 600),
 		LinkTokenTTL:                durationOr("TELEGRAM_LINK_TTL", 10*time.Minute),
 		LinkTokenRateLimitPerMin:    linkTokenPerMin,
 		LinkTokenRateLimitIPPerMin:  intOr("LINK_TOKEN_RATE_LIMIT_IP_PER_MIN", linkTokenPerMin),
@@ -107,6 +111,12 @@ func Load() (Config, error) {
 	if cfg.OTPSendBotPerMin <= 0 {
 		invalidLimits = append(invalidLimits, "OTP_RATE_LIMIT_BOT_PER_MIN")
 	}
+	if cfg.NotifyPerMin <= 0 {
+		invalidLimits = append(invalidLimits, "NOTIFY_RATE_LIMIT_PER_MIN")
+	}
+	if cfg.NotifyBotPerMin <= 0 {
+		invalidLimits = append(invalidLimits, "NOTIFY_RATE_LIMIT_BOT_PER_MIN")
+	}
 	if cfg.LinkTokenRateLimitPerMin <= 0 {
 		invalidLimits = append(invalidLimits, "LINK_TOKEN_RATE_LIMIT_PER_MIN")
 	}
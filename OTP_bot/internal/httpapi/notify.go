@@ -0,0 +1,130 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"otp_bot/internal/linking"
+	"otp_bot/internal/observability"
+	"otp_bot/internal/ratelimit"
+	"otp_bot/internal/telegram"
+)
+
+// Telegram ограничивает длину текста сообщения 4096 символами.
+const notifyMaxTextLength = 4096
+
+// NotifyHandler доставляет уведомления основного бэкенда в привязанный чат пользователя.
+type NotifyHandler struct {
+	sender         telegram.Service
+	linkStore      linking.TelegramLinkStore
+	preferences    linking.NotificationPreferenceStore
+	internalKey    string
+	maxBodyBytes   int64
+	perChatLimiter ratelimit.Limiter
+	botLimiter     ratelimit.Limiter
+	logger         *slog.Logger
+}
+
+// NewNotifyHandler создает обработчик доставки уведомлений.
+func NewNotifyHandler(sender telegram.Service, internalKey string, linkStore linking.TelegramLinkStore, preferences linking.NotificationPreferenceStore, perChatLimiter ratelimit.Limiter, botLimiter ratelimit.Limiter, logger *slog.Logger) *NotifyHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if perChatLimiter == nil {
+		perChatLimiter = ratelimit.NoopLimiter{}
+	}
+	if botLimiter == nil {
+		botLimiter = ratelimit.NoopLimiter{}
+	}
+	return &NotifyHandler{
+		sender:         sender,
+		linkStore:      linkStore,
+		preferences:    preferences,
+		internalKey:    strings.TrimSpace(internalKey),
+		maxBodyBytes:   1 << 20,
+		perChatLimiter: perChatLimiter,
+		botLimiter:     botLimiter,
+		logger:         logger,
+	}
+}
+
+// ServeHTTP обрабатывает запросы на отправку уведомлений.
+// Отсутствие привязки и отказ от уведомлений не считаются ошибкой: ответ 200 с sent=false и причиной.
+func (h *NotifyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !requireInternalAuth(w, r, h.internalKey, h.logger) {
+		return
+	}
+
+	body := http.MaxBytesReader(w, r.Body, h.maxBodyBytes)
+	defer body.Close()
+
+	var payload struct {
+		UserID string `json:"user_id"`
+		Text   string `json:"text"`
+	}
+	requestID := observability.RequestIDFromContext(r.Context())
+	if err := json.NewDecoder(body).Decode(&payload); err != nil {
+		h.logger.Warn("invalid notify payload", slog.String("request_id", requestID))
+		writeError(w, http.StatusBadRequest, "invalid_payload")
+		return
+	}
+	userID := strings.TrimSpace(payload.UserID)
+	text := strings.TrimSpace(payload.Text)
+	if userID == "" || text == "" || utf8.RuneCountInString(text) > notifyMaxTextLength {
+		writeError(w, http.StatusBadRequest, "invalid_payload")
+		return
+	}
+
+	if h.linkStore == nil {
+		h.logger.Error("notify link store missing", slog.String("request_id", requestID))
+		writeError(w, http.StatusInternalServerError, "internal_error")
+		return
+	}
+	link, err := h.linkStore.GetByUserID(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, linking.ErrTelegramLinkNotFound) {
+			writeJSON(w, http.StatusOK, map[string]any{"sent": false, "reason": "not_linked"})
+			return
+		}
+		h.logger.Error("notify link lookup failed", slog.String("request_id", requestID), slog.String("user_id", userID), slog.String("error", err.Error()))
+		writeError(w, http.StatusInternalServerError, "internal_error")
+		return
+	}
+	if h.preferences != nil {
+		enabled, err := h.preferences.NotificationsEnabled(r.Context(), userID)
+		if err != nil {
+			h.logger.Error("notify preferences lookup failed", slog.String("request_id", requestID), slog.String("user_id", userID), slog.String("error", err.Error()))
+			writeError(w, http.StatusInternalServerError, "internal_error")
+			return
+		}
+		if !enabled {
+			writeJSON(w, http.StatusOK, map[string]any{"sent": false, "reason": "opted_out"})
+			return
+		}
+	}
+
+	if !h.perChatLimiter.Allow(fmt.Sprintf("chat:%d", link.TelegramChatID)) || !h.botLimiter.Allow("bot") {
+		h.logger.Warn("notify rate limit exceeded", slog.String("request_id", requestID), slog.Int64("chat_id", link.TelegramChatID))
+		writeError(w, http.StatusTooManyRequests, "rate_limited")
+		return
+	}
+
+	if err := h.sender.SendMessage(r.Context(), link.TelegramChatID, text); err != nil {
+		h.logger.Error("failed to send notification", slog.String("request_id", requestID), slog.Int64("chat_id", link.TelegramChatID), slog.String("error", err.Error()))
+		writeError(w, http.StatusInternalServerError, "telegram_failed")
+		return
+	}
+
+	h.logger.Info("notification sent", slog.String("request_id", requestID), slog.Int64("chat_id", link.TelegramChatID))
+	writeJSON(w, http.StatusOK, map[string]any{"sent": true})
+}
@@ -0,0 +1,109 @@
+package httpapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"otp_bot/internal/linking"
+	"otp_bot/internal/ratelimit"
+)
+
+func newNotifyTestHandler(sender *otpSender, preferences linking.NotificationPreferenceStore) *NotifyHandler {
+	linkStore := linking.NewMemoryTelegramLinkStore()
+	_ = linkStore.LinkChat(context.Background(), linking.TelegramLink{
+		UserID:         "user-1",
+		TelegramChatID: 7,
+		VerifiedAt:     time.Now(),
+	})
+	return NewNotifyHandler(sender, "secret", linkStore, preferences, ratelimit.NoopLimiter{}, ratelimit.NoopLimiter{}, nil)
+}
+
+func serveNotify(handler *NotifyHandler, body string, authorized bool) (*httptest.ResponseRecorder, map[string]any) {
+	req := httptest.NewRequest(http.MethodPost, "/notify", bytes.NewBufferString(body))
+	if authorized {
+		req.Header.Set("X-Internal-Key", "secret")
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	var response map[string]any
+	_ = json.NewDecoder(rec.Body).Decode(&response)
+	return rec, response
+}
+
+func TestNotifyUnauthorized(t *testing.T) {
+	sender := &otpSender{}
+	handler := newNotifyTestHandler(sender, nil)
+
+	rec, _ := serveNotify(handler, `{"user_id":"user-1","text":"hello"}`, false)
+	if rec.Result().StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", rec.Result().StatusCode)
+	}
+	if sender.called {
+		t.Fatalf("expected notification not sent")
+	}
+}
+
+func TestNotifySuccess(t *testing.T) {
+	sender := &otpSender{}
+	handler := newNotifyTestHandler(sender, linking.NewMemoryNotificationPreferenceStore())
+
+	rec, response := serveNotify(handler, `{"user_id":"user-1","text":"Application update"}`, true)
+	if rec.Result().StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Result().StatusCode)
+	}
+	if !sender.called || sender.text != "Application update" {
+		t.Fatalf("expected notification sent, got %q", sender.text)
+	}
+	if sent, ok := response["sent"].(bool); !ok || !sent {
+		t.Fatalf("expected sent=true, got %v", response["sent"])
+	}
+}
+
+func TestNotifyNotLinked(t *testing.T) {
+	sender := &otpSender{}
+	handler := newNotifyTestHandler(sender, nil)
+
+	rec, response := serveNotify(handler, `{"user_id":"user-2","text":"hello"}`, true)
+	if rec.Result().StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Result().StatusCode)
+	}
+	if sender.called {
+		t.Fatalf("expected notification not sent")
+	}
+	if response["reason"] != "not_linked" {
+		t.Fatalf("expected reason not_linked, got %v", response["reason"])
+	}
+}
+
+func TestNotifyOptedOut(t *testing.T) {
+	sender := &otpSender{}
+	preferences := linking.NewMemoryNotificationPreferenceStore()
+	_ = preferences.SetNotificationsEnabled(context.Background(), "user-1", false)
+	handler := newNotifyTestHandler(sender, preferences)
+
+	rec, response := serveNotify(handler, `{"user_id":"user-1","text":"hello"}`, true)
+	if rec.Result().StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Result().StatusCode)
+	}
+	if sender.called {
+		t.Fatalf("expected notification not sent")
+	}
+	if response["reason"] != "opted_out" {
+		t.Fatalf("expected reason opted_out, got %v", response["reason"])
+	}
+}
+
+func TestNotifyInvalidPayload(t *testing.T) {
+	sender := &otpSender{}
+	handler := newNotifyTestHandler(sender, nil)
+
+	rec, _ := serveNotify(handler, `{"user_id":"user-1","text":"  "}`, true)
+	if rec.Result().StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rec.Result().StatusCode)
+	}
+}
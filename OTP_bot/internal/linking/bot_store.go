@@ -9,7 +9,8 @@ import (
 
 // BotLinkStore адаптирует TelegramLinkStore для использования ботом.
 type BotLinkStore struct {
-	store TelegramLinkStore
+	store       TelegramLinkStore
+	preferences NotificationPreferenceStore
 }
 
 // NewBotLinkStore создает адаптер хранилища связей для бота.
@@ -19,6 +20,13 @@ func NewBotLinkStore(store TelegramLinkStore) *BotLinkStore {
 	}
 }
 
+// NewBotLinkStoreWithPreferences создает адаптер, который также управляет настройками уведомлений.
+func NewBotLinkStoreWithPreferences(store TelegramLinkStore, preferences NotificationPreferenceStore) *BotLinkStore {
+	adapter := NewBotLinkStore(store)
+	adapter.preferences = preferences
+	return adapter
+}
+
 // GetByChatID возвращает связь по ID чата.
 func (s *BotLinkStore) GetByChatID(ctx context.Context, chatID int64) (telegram.LinkInfo, error) {
 	link, err := s.store.GetByChatID(ctx, chatID)
@@ -30,3 +38,15 @@ func (s *BotLinkStore) GetByChatID(ctx context.Context, chatID int64) (telegram.
 	}
 	return telegram.LinkInfo{UserID: link.UserID, Phone: link.Phone, ChatID: link.TelegramChatID}, nil
 }
+
+// SetNotificationsEnabled включает или отключает уведомления для пользователя, привязанного к чату.
+func (s *BotLinkStore) SetNotificationsEnabled(ctx context.Context, chatID int64, enabled bool) error {
+	if s.preferences == nil {
+		return telegram.ErrNotificationsUnavailable
+	}
+	link, err := s.GetByChatID(ctx, chatID)
+	if err != nil {
+		return err
+	}
+	return s.preferences.SetNotificationsEnabled(ctx, link.UserID, enabled)
+}
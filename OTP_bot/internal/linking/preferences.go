@@ -0,0 +1,41 @@
+package linking
+
+import (
+	"context"
+	"sync"
+)
+
+// NotificationPreferenceStore хранит согласие пользователя на уведомления.
+// Настройка живет отдельно от telegram_links, чтобы переживать повторную привязку чата.
+type NotificationPreferenceStore interface {
+	NotificationsEnabled(ctx context.Context, userID string) (bool, error)
+	SetNotificationsEnabled(ctx context.Context, userID string, enabled bool) error
+}
+
+// MemoryNotificationPreferenceStore хранит настройки уведомлений в памяти.
+type MemoryNotificationPreferenceStore struct {
+	mu       sync.RWMutex
+	disabled map[string]bool
+}
+
+// NewMemoryNotificationPreferenceStore создает хранилище настроек в памяти.
+func NewMemoryNotificationPreferenceStore() *MemoryNotificationPreferenceStore {
+	return &MemoryNotificationPreferenceStore{disabled: make(map[string]bool)}
+}
+
+func (s *MemoryNotificationPreferenceStore) NotificationsEnabled(_ context.Context, userID string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return !s.disabled[userID], nil
+}
+
+func (s *MemoryNotificationPreferenceStore) SetNotificationsEnabled(_ context.Context, userID string, enabled bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if enabled {
+		delete(s.disabled, userID)
+		return nil
+	}
+	s.disabled[userID] = true
+	return nil
+}
@@ -45,11 +45,13 @@ func Run() error {
 	var db *sql.DB
 	var linkStore linking.TelegramLinkStore
 	var linkTokenStore linking.LinkTokenStore
+	var preferenceStore linking.NotificationPreferenceStore
 
 	if cfg.DatabaseURL == "" {
 		logger.Warn("database url missing, using in-memory stores")
 		linkStore = linking.NewMemoryTelegramLinkStore()
 		linkTokenStore = linking.NewMemoryLinkTokenStore()
+		preferenceStore = linking.NewMemoryNotificationPreferenceStore()
 	} else {
 		db, err = sql.Open(cfg.DBDriver, cfg.DatabaseURL)
 		if err != nil {
@@ -58,12 +60,13 @@ func Run() error {
 		defer db.Close()
 		linkStore = postgres.NewTelegramLinkStore(db)
 		linkTokenStore = postgres.NewTelegramLinkTokenStore(db)
+		preferenceStore = postgres.NewNotificationPreferenceStore(db)
 	}
 	hashSecret := []byte(cfg.InternalAuthKey)
 
 	linker := linking.NewTelegramLinker(linkTokenStore, linkStore, hashSecret)
 	linkRegistrar := linking.NewLinkTokenRegistrar(linkTokenStore, cfg.LinkTokenTTL, hashSecret)
-	botLinkStore := linking.NewBotLinkStore(linkStore)
+	botLinkStore := linking.NewBotLinkStoreWithPreferences(linkStore, preferenceStore)
 	bot := telegram.NewBot(telegramClient, linker, botLinkStore, apiClient, logger)
 	webhookHandler := telegram.NewWebhookHandler(bot, cfg.WebhookSecret, logger)
 	var poller *telegram.Poller
@@ -76,6 +79,10 @@ func Run() error {
 	otpBotLimiter := ratelimit.NewMemoryLimiter(cfg.OTPSendBotPerMin, time.Minute)
 	otpHandler := httpapi.NewOTPHandler(telegramClient, cfg.InternalAuthKey, linkStore, otpPerChatLimiter, otpPerIPLimiter, otpBotLimiter, logger)
 
+	notifyPerChatLimiter := ratelimit.NewMemoryLimiter(cfg.NotifyPerMin, time.Minute)
+	notifyBotLimiter := ratelimit.NewMemoryLimiter(cfg.NotifyBotPerMin, time.Minute)
+	notifyHandler := httpapi.NewNotifyHandler(telegramClient, cfg.InternalAuthKey, linkStore, preferenceStore, notifyPerChatLimiter, notifyBotLimiter, logger)
+
 	linkTokenIPLimiter := ratelimit.NewMemoryLimiter(cfg.LinkTokenRateLimitIPPerMin, time.Minute)
 	linkTokenBotLimiter := ratelimit.NewMemoryLimiter(cfg.LinkTokenRateLimitBotPerMin, time.Minute)
 	api := httpapi.NewAPI(linkRegistrar, linkStore, cfg.InternalAuthKey, linkTokenIPLimiter, linkTokenBotLimiter, logger)
@@ -85,6 +92,7 @@ func Run() error {
 	mux.HandleFunc("/telegram/link-token", api.HandleLinkToken)
 	mux.HandleFunc("/telegram/status", api.HandleStatus)
 	mux.Handle("/otp/send", otpHandler)
+	mux.Handle("/notify", notifyHandler)
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
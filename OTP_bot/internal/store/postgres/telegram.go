@@ -168,3 +168,41 @@ func (s *TelegramLinkTokenStore) Consume(ctx context.Context, tokenHash []byte)
 	link.ExpiresAt = expiresAt
 	return link, nil
 }
+
+// NotificationPreferenceStore хранит настройки уведомлений в Postgres.
+type NotificationPreferenceStore struct {
+	db *sql.DB
+}
+
+// NewNotificationPreferenceStore создает новое хранилище настроек уведомлений.
+func NewNotificationPreferenceStore(db *sql.DB) *NotificationPreferenceStore {
+	return &NotificationPreferenceStore{db: db}
+}
+
+func (s *NotificationPreferenceStore) NotificationsEnabled(ctx context.Context, userID string) (bool, error) {
+	const query = `
+		SELECT enabled
+		FROM telegram_notification_preferences
+		WHERE user_id = $1
+	`
+	var enabled bool
+	if err := s.db.QueryRowContext(ctx, query, userID).Scan(&enabled); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return true, nil
+		}
+		return false, err
+	}
+	return enabled, nil
+}
+
+func (s *NotificationPreferenceStore) SetNotificationsEnabled(ctx context.Context, userID string, enabled bool) error {
+	const query = `
+		INSERT INTO telegram_notification_preferences (user_id, enabled, updated_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (user_id)
+		DO UPDATE SET enabled = EXCLUDED.enabled,
+			updated_at = EXCLUDED.updated_at
+	`
+	_, err := s.db.ExecContext(ctx, query, userID, enabled)
+	return err
+}
@@ -126,3 +126,33 @@ func TestBotHandleCodeVerify(t *testing.T) {
 		t.Fatalf("expected code 654321, got %q", otpClient.lastVerifyCode)
 	}
 }
+
+type fakeSettingsStore struct {
+	enabled map[int64]bool
+}
+
+func (f *fakeSettingsStore) GetByChatID(ctx context.Context, chatID int64) (LinkInfo, error) {
+	return LinkInfo{UserID: "user-1", ChatID: chatID}, nil
+}
+
+func (f *fakeSettingsStore) SetNotificationsEnabled(ctx context.Context, chatID int64, enabled bool) error {
+	f.enabled[chatID] = enabled
+	return nil
+}
+
+func TestBotHandleNotificationsOff(t *testing.T) {
+	sender := &fakeSender{}
+	store := &fakeSettingsStore{enabled: map[int64]bool{}}
+	bot := NewBot(sender, nil, store, nil, slog.Default())
+
+	update := Update{Message: &Message{Chat: Chat{ID: 31, Type: "private"}, Text: "/notifications off"}}
+	if err := bot.HandleUpdate(context.Background(), update); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if enabled, ok := store.enabled[31]; !ok || enabled {
+		t.Fatalf("expected notifications disabled, got %v", store.enabled)
+	}
+	if !strings.Contains(sender.lastText, "Notifications are off") {
+		t.Fatalf("unexpected response %q", sender.lastText)
+	}
+}
@@ -80,6 +80,14 @@ type LinkStore interface {
 	GetByChatID(ctx context.Context, chatID int64) (LinkInfo, error)
 }
 
+// NotificationSettings позволяет пользователю включать и отключать уведомления из чата.
+type NotificationSettings interface {
+	SetNotificationsEnabled(ctx context.Context, chatID int64, enabled bool) error
+}
+
+// ErrNotificationsUnavailable сообщает, что хранилище настроек уведомлений не подключено.
+var ErrNotificationsUnavailable = errors.New("notification settings unavailable")
+
 // Bot обрабатывает входящие обновления Telegram.
 type Bot struct {
 	sender    Service
@@ -136,6 +144,8 @@ func (b *Bot) HandleUpdate(ctx context.Context, update Update) error {
 			return b.handleStatus(ctx, msg.Chat.ID)
 		case "/code":
 			return b.handleCodeCommand(ctx, msg.Chat.ID, arg)
+		case "/notifications":
+			return b.handleNotificationsCommand(ctx, msg.Chat.ID, arg)
 		default:
 			return b.sendMessage(ctx, msg.Chat.ID, "I did not understand. Use /help.", nil)
 		}
@@ -242,6 +252,36 @@ func (b *Bot) handleStatus(ctx context.Context, chatID int64) error {
 	return b.sendMessage(ctx, chatID, "Your Telegram is linked.", nil)
 }
 
+func (b *Bot) handleNotificationsCommand(ctx context.Context, chatID int64, arg string) error {
+	settings, ok := b.linkStore.(NotificationSettings)
+	if !ok {
+		return b.sendMessage(ctx, chatID, "Notification settings are unavailable right now.", nil)
+	}
+	var enabled bool
+	switch strings.ToLower(strings.TrimSpace(arg)) {
+	case "on":
+		enabled = true
+	case "off":
+		enabled = false
+	default:
+		return b.sendMessage(ctx, chatID, "Use /notifications on or /notifications off.", nil)
+	}
+	if err := settings.SetNotificationsEnabled(ctx, chatID, enabled); err != nil {
+		switch {
+		case errors.Is(err, ErrLinkNotFound):
+			return b.sendMessage(ctx, chatID, "Your Telegram is not linked yet. Send the link code from the app.", nil)
+		case errors.Is(err, ErrNotificationsUnavailable):
+			return b.sendMessage(ctx, chatID, "Notification settings are unavailable right now.", nil)
+		default:
+			return err
+		}
+	}
+	if enabled {
+		return b.sendMessage(ctx, chatID, "Notifications are on. I will tell you about application updates and new messages.", nil)
+	}
+	return b.sendMessage(ctx, chatID, "Notifications are off. Login codes will still be delivered. Use /notifications on to enable them again.", nil)
+}
+
 func (b *Bot) handleCodeCommand(ctx context.Context, chatID int64, arg string) error {
 	if b.otpClient == nil {
 		return b.sendMessage(ctx, chatID, "OTP requests are unavailable right now.", nil)
@@ -311,7 +351,8 @@ func (b *Bot) sendStart(ctx context.Context, chatID int64) error {
 }
 
 func (b *Bot) sendHelp(ctx context.Context, chatID int64) error {
-	text := "I send ProfZoom login codes. Send the link code from the app, then use /code to receive a login code."
+	text := "I send ProfZoom login codes and application notifications. Send the link code from the app, then use /code to receive a login code.\n" +
+		"Use /notifications off or /notifications on to manage notifications."
 	return b.sendMessage(ctx, chatID, text, nil)
 }
 
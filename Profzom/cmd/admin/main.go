@@ -0,0 +1,61 @@
+// Команда admin выдаёт и снимает роль администратора. Через публичный API роль admin не назначается.
+//
+//	admin grant <user_id | phone>
+//	admin revoke <user_id | phone>
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"profzom/internal/app"
+	"profzom/internal/common"
+	"profzom/internal/database"
+	"profzom/internal/domain/user"
+	"profzom/internal/repository/postgres"
+)
+
+func main() {
+	if len(os.Args) != 3 || (os.Args[1] != "grant" && os.Args[1] != "revoke") {
+		fmt.Fprintln(os.Stderr, "usage: admin grant|revoke <user_id | phone>")
+		os.Exit(2)
+	}
+	dsn := os.Getenv("DATABASE_URL")
+	if dsn == "" {
+		log.Fatal("DATABASE_URL is required")
+	}
+	db := database.NewPostgres(database.PostgresConfig{DSN: dsn, MaxOpenConns: 2, MaxIdleConns: 1, ConnMaxIdle: time.Minute, ConnMaxLifetime: time.Minute})
+	defer db.Close()
+
+	userRepo := postgres.NewUserRepository(db)
+	adminService := app.NewAdminService(userRepo, postgres.NewRefreshTokenRepository(db), nil, nil, nil, postgres.NewAuditRepository(db), postgres.NewUnitOfWork(db))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	account, err := findUser(ctx, userRepo, os.Args[2])
+	if err != nil {
+		log.Fatal(err)
+	}
+	result := "granted"
+	if os.Args[1] == "grant" {
+		err = adminService.GrantAdmin(ctx, account.ID)
+	} else {
+		err = adminService.RevokeAdmin(ctx, account.ID)
+		result = "revoked"
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("role admin %s for user %s\n", result, account.ID)
+}
+
+// findUser принимает id пользователя или телефон, под которым он зарегистрирован.
+func findUser(ctx context.Context, users *postgres.UserRepository, ref string) (*user.User, error) {
+	if id, err := common.ParseUUID(ref); err == nil {
+		return users.GetByID(ctx, id)
+	}
+	return users.FindByPhone(ctx, ref)
+}
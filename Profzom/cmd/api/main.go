@@ -8,19 +8,25 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata" // часовые пояса собеседований валидируются через time.LoadLocation, образ может быть без tzdata
 
 	"profzom/internal/app"
 	"profzom/internal/config"
 	"profzom/internal/database"
+	"profzom/internal/domain/outbox"
 	apphttp "profzom/internal/http"
 	"profzom/internal/http/handlers"
 	"profzom/internal/http/metrics"
 	httpmw "profzom/internal/http/middleware"
 	"profzom/internal/http/response"
 	"profzom/internal/integration/otpbot"
+	"profzom/internal/integration/webhook"
+	"profzom/internal/moderation"
 	"profzom/internal/observability"
+	"profzom/internal/realtime"
 	"profzom/internal/repository/postgres"
 	"profzom/internal/security"
+	"profzom/internal/storage"
 )
 
 func main() {
@@ -42,43 +48,120 @@ func main() {
 	telegramLinkRepo := postgres.NewTelegramLinkRepository(db)
 	studentRepo := postgres.NewStudentProfileRepository(db)
 	companyRepo := postgres.NewCompanyProfileRepository(db)
+	resumeRepo := postgres.NewResumeRepository(db)
 	vacancyRepo := postgres.NewVacancyRepository(db)
+	bookmarkRepo := postgres.NewBookmarkRepository(db)
+	savedSearchRepo := postgres.NewSavedSearchRepository(db)
+	notificationRepo := postgres.NewNotificationRepository(db)
 	applicationRepo := postgres.NewApplicationRepository(db)
 	messageRepo := postgres.NewMessageRepository(db)
+	attachmentRepo := postgres.NewAttachmentRepository(db)
+	interviewRepo := postgres.NewInterviewRepository(db)
+	invitationRepo := postgres.NewInvitationRepository(db)
+	verificationRepo := postgres.NewVerificationRepository(db)
+	outboxRepo := postgres.NewOutboxRepository(db)
+	auditRepo := postgres.NewAuditRepository(db)
+	statsRepo := postgres.NewStatsRepository(db)
+	moderationRepo := postgres.NewModerationRepository(db)
+	reportRepo := postgres.NewReportRepository(db)
+	blockListRepo := postgres.NewBlockListRepository(db)
+	uow := postgres.NewUnitOfWork(db)
+	// события и уведомления пишутся в outbox в транзакции сервиса, доставляет их диспетчер
+	events := app.NewOutboxPublisher(outboxRepo)
+	// realtime-события уходят через pg_notify после коммита и раздаются подписчикам SSE на каждой реплике
+	realtimeHub := realtime.NewHub()
+	realtimePublisher := postgres.NewRealtimePublisher(db)
 
 	jwtProvider := security.NewJWTProvider(cfg.JWTSecret)
+	cursorSigner := security.NewCursorSigner(cfg.CursorSecret)
+	fileStorage := newFileStorage(cfg)
+	moderationChecker := newModerationChecker(cfg)
 	otpBotClient := otpbot.NewClient(cfg.OTPBotBaseURL, cfg.OTPBotInternalKey, &http.Client{Timeout: 5 * time.Second})
 
-	authService := app.NewAuthServiceWithTelegramLinks(userRepo, otpRepo, refreshRepo, analyticsRepo, jwtProvider, otpBotClient, telegramLinkRepo, logger, cfg.AccessTokenTTL, cfg.RefreshTokenTTL, cfg.OTPTTL)
-	userService := app.NewUserService(userRepo, analyticsRepo)
-	profileService := app.NewProfileService(studentRepo, companyRepo, analyticsRepo)
-	vacancyService := app.NewVacancyService(vacancyRepo, companyRepo, analyticsRepo)
-	applicationService := app.NewApplicationService(applicationRepo, vacancyRepo, studentRepo, analyticsRepo)
-	messageService := app.NewMessageService(messageRepo, applicationRepo, vacancyRepo, analyticsRepo)
+	authService := app.NewAuthServiceWithTelegramLinks(userRepo, otpRepo, refreshRepo, events, uow, jwtProvider, otpBotClient, telegramLinkRepo, logger, cfg.AccessTokenTTL, cfg.RefreshTokenTTL, cfg.OTPTTL)
+	userService := app.NewUserService(userRepo, events, uow)
+	profileService := app.NewProfileService(studentRepo, companyRepo, resumeRepo, events, uow)
+	resumeService := app.NewResumeService(studentRepo, resumeRepo, applicationRepo, vacancyRepo)
+	vacancyService := app.NewVacancyServiceWithNotifier(vacancyRepo, companyRepo, events, uow, applicationRepo, events).WithModeration(moderationChecker, moderationRepo)
+	if cfg.RequireCompanyVerification {
+		vacancyService.RequireVerification(verificationRepo)
+	}
+	verificationService := app.NewCompanyVerificationService(verificationRepo, companyRepo, events, uow, events)
+	bookmarkService := app.NewBookmarkService(bookmarkRepo, vacancyRepo)
+	savedSearchService := app.NewSavedSearchService(savedSearchRepo, uow, events)
+	notificationService := app.NewNotificationService(notificationRepo)
+	applicationService := app.NewApplicationServiceWithNotifier(applicationRepo, vacancyRepo, studentRepo, messageRepo, events, uow, events).WithRealtime(realtimePublisher).WithBlockList(blockListRepo)
+	messageService := app.NewMessageServiceWithNotifier(messageRepo, applicationRepo, vacancyRepo, events, uow, events).WithRealtime(realtimePublisher).WithAttachments(attachmentRepo, fileStorage).WithModeration(moderationChecker, moderationRepo).WithBlockList(blockListRepo)
+	recommendationService := app.NewRecommendationService(studentRepo, vacancyRepo)
+	invitationService := app.NewInvitationService(invitationRepo, applicationRepo, vacancyRepo, studentRepo, messageRepo, events, uow, events).WithModeration(moderationChecker).WithBlockList(blockListRepo)
+	interviewService := app.NewInterviewService(interviewRepo, applicationRepo, vacancyRepo, events, uow).WithApplicationService(applicationService)
+	adminService := app.NewAdminService(userRepo, refreshRepo, vacancyService, applicationRepo, statsRepo, auditRepo, uow)
+	moderationService := app.NewModerationService(moderationRepo, reportRepo, vacancyService, messageService, auditRepo, uow)
+	reportService := app.NewReportService(reportRepo, vacancyRepo, companyRepo, messageRepo, applicationRepo)
+	blockListService := app.NewBlockListService(blockListRepo)
+
+	dispatcher := app.NewOutboxDispatcher(outboxRepo, cfg.OutboxInterval, logger)
+	dispatcher.Register(outbox.TopicAnalytics, "analytics", app.NewAnalyticsSink(analyticsRepo))
+	dispatcher.Register(outbox.TopicAnalytics, "saved_search", app.NewSavedSearchSink(savedSearchService))
+	dispatcher.Register(outbox.TopicNotification, "notification_feed", app.NewNotificationFeedSink(notificationRepo))
+	dispatcher.Register(outbox.TopicNotification, "telegram", app.NewNotificationSink(app.NewTelegramNotifier(otpBotClient, logger)))
+	if cfg.OutboxWebhookURL != "" {
+		sink := webhook.NewSink(cfg.OutboxWebhookURL, cfg.OutboxWebhookKey, &http.Client{Timeout: 5 * time.Second})
+		dispatcher.Register(outbox.TopicAnalytics, "webhook", sink)
+		dispatcher.Register(outbox.TopicNotification, "webhook", sink)
+	}
 
 	rateLimiter := httpmw.NewRateLimiter()
 	authHandler := handlers.NewAuthHandler(authService, rateLimiter, cfg.OTPBotInternalKey)
 	userHandler := handlers.NewUserHandler(userService)
 	profileHandler := handlers.NewProfileHandler(profileService)
-	vacancyHandler := handlers.NewVacancyHandler(vacancyService)
-	applicationHandler := handlers.NewApplicationHandler(applicationService, rateLimiter)
-	messageHandler := handlers.NewMessageHandler(messageService, rateLimiter)
-	middleware := httpmw.NewAuthMiddleware(jwtProvider)
+	resumeHandler := handlers.NewResumeHandler(resumeService)
+	vacancyHandler := handlers.NewVacancyHandler(vacancyService, bookmarkService, cursorSigner)
+	bookmarkHandler := handlers.NewBookmarkHandler(bookmarkService, cursorSigner)
+	savedSearchHandler := handlers.NewSavedSearchHandler(savedSearchService)
+	notificationHandler := handlers.NewNotificationHandler(notificationService, cursorSigner)
+	streamHandler := handlers.NewStreamHandler(realtimeHub)
+	applicationHandler := handlers.NewApplicationHandler(applicationService, interviewService, rateLimiter, cursorSigner)
+	messageHandler := handlers.NewMessageHandler(messageService, rateLimiter, cursorSigner)
+	interviewHandler := handlers.NewInterviewHandler(interviewService)
+	recommendationHandler := handlers.NewRecommendationHandler(recommendationService)
+	candidateHandler := handlers.NewCandidateHandler(profileService, cursorSigner)
+	invitationHandler := handlers.NewInvitationHandler(invitationService, rateLimiter, cursorSigner)
+	verificationHandler := handlers.NewVerificationHandler(verificationService, cursorSigner)
+	adminHandler := handlers.NewAdminHandler(adminService, cursorSigner)
+	moderationHandler := handlers.NewModerationHandler(moderationService, cursorSigner)
+	reportHandler := handlers.NewReportHandler(reportService, rateLimiter)
+	blockListHandler := handlers.NewBlockListHandler(blockListService, cursorSigner)
+	middleware := httpmw.NewAuthMiddleware(jwtProvider).WithAccountCheck(userService.ActiveRoles)
 
 	collector := metrics.NewCollector()
 	response.SetErrorCollector(collector)
 
 	router := apphttp.NewRouter(apphttp.RouterDependencies{
-		AuthHandler:        authHandler,
-		UserHandler:        userHandler,
-		ProfileHandler:     profileHandler,
-		VacancyHandler:     vacancyHandler,
-		ApplicationHandler: applicationHandler,
-		MessageHandler:     messageHandler,
-		AuthMiddleware:     middleware,
-		MetricsHandler:     handlers.NewMetricsHandler(collector),
-		Metrics:            collector,
-		RequestTimeout:     cfg.RequestTimeout,
+		AuthHandler:           authHandler,
+		UserHandler:           userHandler,
+		ProfileHandler:        profileHandler,
+		ResumeHandler:         resumeHandler,
+		VacancyHandler:        vacancyHandler,
+		BookmarkHandler:       bookmarkHandler,
+		SavedSearchHandler:    savedSearchHandler,
+		ApplicationHandler:    applicationHandler,
+		MessageHandler:        messageHandler,
+		InterviewHandler:      interviewHandler,
+		RecommendationHandler: recommendationHandler,
+		CandidateHandler:      candidateHandler,
+		InvitationHandler:     invitationHandler,
+		VerificationHandler:   verificationHandler,
+		AdminHandler:          adminHandler,
+		ModerationHandler:     moderationHandler,
+		ReportHandler:         reportHandler,
+		BlockListHandler:      blockListHandler,
+		NotificationHandler:   notificationHandler,
+		StreamHandler:         streamHandler,
+		AuthMiddleware:        middleware,
+		MetricsHandler:        handlers.NewMetricsHandler(collector),
+		Metrics:               collector,
+		RequestTimeout:        cfg.RequestTimeout,
 	})
 	server := &http.Server{
 		Addr:         ":" + cfg.HTTPPort,
@@ -87,6 +170,15 @@ func main() {
 		WriteTimeout: 10 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
+	// Shutdown не прерывает активные запросы, поэтому SSE-потоки закрываются явно
+	server.RegisterOnShutdown(realtimeHub.Close)
+
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	go app.NewVacancyExpirySweeper(vacancyService, cfg.VacancySweepEvery, logger).Run(workerCtx)
+	go dispatcher.Run(workerCtx)
+	go postgres.NewRealtimeListener(cfg.PostgresDSN, realtimeHub, logger).Run(workerCtx)
+	go app.NewSavedSearchDigestWorker(savedSearchService, cfg.SearchDigestEvery, logger).Run(workerCtx)
 
 	go func() {
 		logger.Info("API started on :" + cfg.HTTPPort)
@@ -98,9 +190,44 @@ func main() {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 	<-quit
+	stopWorkers()
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 	if err := server.Shutdown(ctx); err != nil {
 		log.Fatal(err)
 	}
 }
+
+func newFileStorage(cfg *config.Config) storage.Storage {
+	if cfg.StorageDriver == "s3" {
+		return storage.NewS3(storage.S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Bucket:    cfg.S3Bucket,
+			Region:    cfg.S3Region,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+		}, &http.Client{Timeout: 2 * time.Minute})
+	}
+	local, err := storage.NewLocal(cfg.StorageLocalDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return local
+}
+
+func newModerationChecker(cfg *config.Config) *moderation.RuleChecker {
+	var patterns []string
+	if cfg.ModerationPattern != "" {
+		patterns = append(patterns, cfg.ModerationPattern)
+	}
+	checker, err := moderation.NewRuleChecker(moderation.RuleConfig{
+		StopWords:      cfg.ModerationStopWords,
+		Patterns:       patterns,
+		MaxLinks:       cfg.ModerationMaxLinks,
+		BlockedDomains: cfg.ModerationBlockedDomains,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	return checker
+}
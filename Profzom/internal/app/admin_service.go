@@ -0,0 +1,203 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/admin"
+	"profzom/internal/domain/application"
+	"profzom/internal/domain/auth"
+	"profzom/internal/domain/user"
+	"profzom/internal/domain/vacancy"
+)
+
+const (
+	adminReasonMaxLength = 500
+	defaultStatsPeriod   = 30 * 24 * time.Hour
+)
+
+// AdminService — back-office модератора: пользователи, блокировки, снятие вакансий, отклики и сводка.
+// Каждое изменяющее действие пишется в журнал аудита в той же транзакции, что и само изменение.
+type AdminService struct {
+	users        user.Repository
+	refresh      auth.RefreshTokenRepository
+	vacancies    *VacancyService
+	applications application.Repository
+	stats        admin.StatsRepository
+	audit        admin.AuditRepository
+	tx           UnitOfWork
+}
+
+func NewAdminService(users user.Repository, refresh auth.RefreshTokenRepository, vacancies *VacancyService, applications application.Repository, stats admin.StatsRepository, audit admin.AuditRepository, tx UnitOfWork) *AdminService {
+	return &AdminService{users: users, refresh: refresh, vacancies: vacancies, applications: applications, stats: stats, audit: audit, tx: tx}
+}
+
+func (s *AdminService) SearchUsers(ctx context.Context, query user.SearchQuery) ([]user.Account, *common.Cursor, error) {
+	query.Text = strings.TrimSpace(query.Text)
+	query.Role = user.Role(strings.ToLower(strings.TrimSpace(string(query.Role))))
+	switch query.Role {
+	case "", user.RoleStudent, user.RoleCompany, user.RoleAdmin:
+	default:
+		return nil, nil, common.NewValidationError("invalid user query", map[string]string{"role": "role must be student, company or admin"})
+	}
+	page := normalizePage(common.PageRequest{Limit: query.Limit, After: query.After})
+	query.Limit = page.Limit
+	return s.users.Search(ctx, query)
+}
+
+// BlockUser закрывает аккаунту вход и API и отзывает его refresh-токены; себя заблокировать нельзя.
+func (s *AdminService) BlockUser(ctx context.Context, adminID, userID common.UUID, reason string) (*user.User, error) {
+	reason, err := normalizeAdminReason(reason, false)
+	if err != nil {
+		return nil, err
+	}
+	if adminID == userID {
+		return nil, common.NewError(common.CodeValidation, "cannot block own account", nil)
+	}
+	account, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if account.BlockedAt != nil {
+		return nil, common.NewError(common.CodeConflict, "user is already blocked", nil)
+	}
+	now := time.Now().UTC()
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		if err := s.users.SetBlocked(ctx, userID, &now, reason); err != nil {
+			return err
+		}
+		if err := s.refresh.RevokeAll(ctx, userID, now.Unix()); err != nil {
+			return err
+		}
+		return s.record(ctx, &adminID, "user.blocked", "user", userID.String(), map[string]string{"reason": reason})
+	})
+	if err != nil {
+		return nil, err
+	}
+	account.BlockedAt = &now
+	account.BlockReason = reason
+	return account, nil
+}
+
+func (s *AdminService) UnblockUser(ctx context.Context, adminID, userID common.UUID) (*user.User, error) {
+	account, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if account.BlockedAt == nil {
+		return nil, common.NewError(common.CodeConflict, "user is not blocked", nil)
+	}
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		if err := s.users.SetBlocked(ctx, userID, nil, ""); err != nil {
+			return err
+		}
+		return s.record(ctx, &adminID, "user.unblocked", "user", userID.String(), nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	account.BlockedAt = nil
+	account.BlockReason = ""
+	return account, nil
+}
+
+// UnpublishVacancy возвращает опубликованную вакансию в черновик; причина обязательна и уходит компании.
+func (s *AdminService) UnpublishVacancy(ctx context.Context, adminID, vacancyID common.UUID, reason string) (*vacancy.Vacancy, error) {
+	reason, err := normalizeAdminReason(reason, true)
+	if err != nil {
+		return nil, err
+	}
+	var updated *vacancy.Vacancy
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		updated, err = s.vacancies.Unpublish(ctx, adminID, vacancyID, reason)
+		if err != nil {
+			return err
+		}
+		return s.record(ctx, &adminID, "vacancy.unpublished", "vacancy", vacancyID.String(), map[string]string{"reason": reason, "company_id": updated.CompanyID.String()})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// ListApplications отдаёт отклики по всей платформе с теми же фильтрами, что и воронка компании.
+func (s *AdminService) ListApplications(ctx context.Context, query application.PipelineQuery) ([]application.PipelineItem, *common.Cursor, error) {
+	query, err := normalizePipelineQuery(query)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.applications.ListPipeline(ctx, query)
+}
+
+// Stats считает сводку за период: нулевой since означает последние 30 дней.
+func (s *AdminService) Stats(ctx context.Context, since time.Time) (*admin.Stats, error) {
+	if since.IsZero() {
+		since = time.Now().UTC().Add(-defaultStatsPeriod)
+	}
+	return s.stats.Stats(ctx, since.UTC())
+}
+
+func (s *AdminService) AuditLog(ctx context.Context, query admin.AuditQuery) ([]admin.AuditEntry, *common.Cursor, error) {
+	query.Action = strings.TrimSpace(query.Action)
+	query.TargetID = strings.TrimSpace(query.TargetID)
+	page := normalizePage(common.PageRequest{Limit: query.Limit, After: query.After})
+	query.Limit = page.Limit
+	return s.audit.List(ctx, query)
+}
+
+// GrantAdmin выдаёт роль admin. Через API роль не выдаётся: метод вызывается консольной утилитой,
+// поэтому запись аудита идёт без admin_id. Роли читаются из базы на каждом запросе, так что роль действует сразу.
+func (s *AdminService) GrantAdmin(ctx context.Context, userID common.UUID) error {
+	if _, err := s.users.GetByID(ctx, userID); err != nil {
+		return err
+	}
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		if err := s.users.AddRole(ctx, userID, user.RoleAdmin); err != nil {
+			return err
+		}
+		return s.record(ctx, nil, "admin.granted", "user", userID.String(), nil)
+	})
+}
+
+// RevokeAdmin снимает роль admin и отзывает refresh-токены, чтобы роль не продлилась через обновление токена.
+func (s *AdminService) RevokeAdmin(ctx context.Context, userID common.UUID) error {
+	if _, err := s.users.GetByID(ctx, userID); err != nil {
+		return err
+	}
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		if err := s.users.RemoveRole(ctx, userID, user.RoleAdmin); err != nil {
+			return err
+		}
+		if err := s.refresh.RevokeAll(ctx, userID, time.Now().UTC().Unix()); err != nil {
+			return err
+		}
+		return s.record(ctx, nil, "admin.revoked", "user", userID.String(), nil)
+	})
+}
+
+func (s *AdminService) record(ctx context.Context, adminID *common.UUID, action, targetType, targetID string, details map[string]string) error {
+	return s.audit.Create(ctx, admin.AuditEntry{
+		ID:         common.NewUUID(),
+		AdminID:    adminID,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Details:    details,
+		CreatedAt:  time.Now().UTC(),
+	})
+}
+
+func normalizeAdminReason(reason string, required bool) (string, error) {
+	reason = strings.TrimSpace(reason)
+	switch {
+	case reason == "" && required:
+		return "", common.NewValidationError("invalid request", map[string]string{"reason": "reason is required"})
+	case len([]rune(reason)) > adminReasonMaxLength:
+		return "", common.NewValidationError("invalid request", map[string]string{"reason": "reason is too long"})
+	}
+	return reason, nil
+}
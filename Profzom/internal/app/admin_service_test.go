@@ -0,0 +1,132 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/application"
+	"profzom/internal/domain/auth"
+	"profzom/internal/domain/notification"
+	"profzom/internal/domain/user"
+	"profzom/internal/domain/vacancy"
+)
+
+func TestAdminServiceBlockUser_RevokesTokensAndAudits(t *testing.T) {
+	f := newTestEnv(t)
+	target, _ := f.users.Create(context.Background(), "+70000000001")
+	_ = f.refresh.Store(context.Background(), auth.RefreshToken{Token: "refresh", UserID: target.ID, ExpiresAt: time.Now().Add(time.Hour)})
+
+	if _, err := f.adminSvc.BlockUser(context.Background(), f.adminID, f.adminID, "spam"); !common.Is(err, common.CodeValidation) {
+		t.Fatalf("expected validation error when blocking own account, got %v", err)
+	}
+	blocked, err := f.adminSvc.BlockUser(context.Background(), f.adminID, target.ID, "  spam  ")
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if blocked.BlockedAt == nil || blocked.BlockReason != "spam" {
+		t.Fatalf("expected blocked account with reason, got %+v", blocked)
+	}
+	if token, _ := f.refresh.GetByToken(context.Background(), "refresh"); token.RevokedAt == nil {
+		t.Fatal("expected refresh token to be revoked")
+	}
+	if _, err := f.adminSvc.BlockUser(context.Background(), f.adminID, target.ID, ""); !common.Is(err, common.CodeConflict) {
+		t.Fatalf("expected conflict for already blocked user, got %v", err)
+	}
+	if len(f.audit.entries) != 1 || f.audit.entries[0].Action != "user.blocked" || *f.audit.entries[0].AdminID != f.adminID || f.audit.entries[0].TargetID != target.ID.String() {
+		t.Fatalf("expected one user.blocked audit entry, got %+v", f.audit.entries)
+	}
+
+	if _, err := f.adminSvc.UnblockUser(context.Background(), f.adminID, target.ID); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if blocked, _ := f.users.IsBlocked(context.Background(), target.ID); blocked {
+		t.Fatal("expected user to be unblocked")
+	}
+}
+
+func TestAdminServiceUnpublishVacancy(t *testing.T) {
+	f := newTestEnv(t)
+	companyID := common.NewUUID()
+	published, _ := f.vacancies.Create(context.Background(), vacancy.Vacancy{CompanyID: companyID, Title: "Go intern", Status: vacancy.StatusPublished})
+
+	if _, err := f.adminSvc.UnpublishVacancy(context.Background(), f.adminID, published.ID, " "); !common.Is(err, common.CodeValidation) {
+		t.Fatalf("expected validation error without reason, got %v", err)
+	}
+	updated, err := f.adminSvc.UnpublishVacancy(context.Background(), f.adminID, published.ID, "misleading salary")
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if updated.Status != vacancy.StatusDraft {
+		t.Fatalf("expected status draft, got %q", updated.Status)
+	}
+	if len(f.notifier.sent) != 1 || f.notifier.sent[0].UserID != companyID || f.notifier.sent[0].Type != notification.TypeVacancyUnpublished {
+		t.Fatalf("expected vacancy.unpublished notification for company, got %+v", f.notifier.sent)
+	}
+	if len(f.audit.entries) != 1 || f.audit.entries[0].Details["reason"] != "misleading salary" {
+		t.Fatalf("expected audit entry with reason, got %+v", f.audit.entries)
+	}
+	if _, err := f.adminSvc.UnpublishVacancy(context.Background(), f.adminID, published.ID, "again"); !common.Is(err, common.CodeValidation) {
+		t.Fatalf("expected validation error for draft vacancy, got %v", err)
+	}
+}
+
+func TestAdminServiceListApplications_ValidatesQuery(t *testing.T) {
+	f := newTestEnv(t)
+	_, _, err := f.adminSvc.ListApplications(context.Background(), application.PipelineQuery{Statuses: []application.Status{"unknown"}})
+	if !common.Is(err, common.CodeValidation) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestAdminServiceGrantAdmin(t *testing.T) {
+	f := newTestEnv(t)
+	target, _ := f.users.Create(context.Background(), "+70000000002")
+
+	if err := f.adminSvc.GrantAdmin(context.Background(), target.ID); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	roles, _ := f.users.ListRoles(context.Background(), target.ID)
+	if len(roles) != 1 || roles[0] != user.RoleAdmin {
+		t.Fatalf("expected admin role, got %v", roles)
+	}
+	if len(f.audit.entries) != 1 || f.audit.entries[0].AdminID != nil || f.audit.entries[0].Action != "admin.granted" {
+		t.Fatalf("expected admin.granted audit entry without admin_id, got %+v", f.audit.entries)
+	}
+	if err := f.adminSvc.RevokeAdmin(context.Background(), target.ID); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if roles, _ := f.users.ListRoles(context.Background(), target.ID); len(roles) != 0 {
+		t.Fatalf("expected no roles after revoke, got %v", roles)
+	}
+}
+
+func TestUserServiceActiveRoles_ReflectsRevokeAndBlock(t *testing.T) {
+	f := newTestEnv(t)
+	users := NewUserService(f.users, noopAnalyticsRepo{}, directUnitOfWork{})
+	target, _ := f.users.Create(context.Background(), "+70000000003")
+	if err := f.adminSvc.GrantAdmin(context.Background(), target.ID); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if roles, err := users.ActiveRoles(context.Background(), target.ID); err != nil || len(roles) != 1 || roles[0] != user.RoleAdmin {
+		t.Fatalf("expected admin role, got %v, %v", roles, err)
+	}
+
+	// токен с claim admin ещё жив, но роль уже отозвана
+	if err := f.adminSvc.RevokeAdmin(context.Background(), target.ID); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if roles, err := users.ActiveRoles(context.Background(), target.ID); err != nil || len(roles) != 0 {
+		t.Fatalf("expected no roles after revoke, got %v, %v", roles, err)
+	}
+	if _, err := f.adminSvc.BlockUser(context.Background(), f.adminID, target.ID, "spam"); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if _, err := users.ActiveRoles(context.Background(), target.ID); !common.Is(err, common.CodeForbidden) {
+		t.Fatalf("expected forbidden for blocked account, got %v", err)
+	}
+	if _, err := users.ActiveRoles(context.Background(), common.NewUUID()); !common.Is(err, common.CodeUnauthorized) {
+		t.Fatalf("expected unauthorized for unknown account, got %v", err)
+	}
+}
@@ -7,19 +7,48 @@ import (
 	"profzom/internal/common"
 	"profzom/internal/domain/analytics"
 	"profzom/internal/domain/application"
+	"profzom/internal/domain/message"
+	"profzom/internal/domain/notification"
 	"profzom/internal/domain/profile"
+	"profzom/internal/domain/user"
 	"profzom/internal/domain/vacancy"
+	"profzom/internal/realtime"
 )
 
 type ApplicationService struct {
 	repo      application.Repository
 	vacancies vacancy.Repository
 	students  profile.StudentRepository
+	messages  message.Repository
 	analytics analytics.Repository
+	tx        UnitOfWork
+	notifier  Notifier
+	realtime  RealtimePublisher
+	blocks    user.BlockListRepository
 }
 
-func NewApplicationService(repo application.Repository, vacancies vacancy.Repository, students profile.StudentRepository, analytics analytics.Repository) *ApplicationService {
-	return &ApplicationService{repo: repo, vacancies: vacancies, students: students, analytics: analytics}
+const withdrawalMessage = "The student has withdrawn the application."
+
+func NewApplicationService(repo application.Repository, vacancies vacancy.Repository, students profile.StudentRepository, messages message.Repository, analytics analytics.Repository, tx UnitOfWork) *ApplicationService {
+	return &ApplicationService{repo: repo, vacancies: vacancies, students: students, messages: messages, analytics: analytics, tx: tx, notifier: noopNotifier{}, realtime: noopRealtimePublisher{}}
+}
+
+func NewApplicationServiceWithNotifier(repo application.Repository, vacancies vacancy.Repository, students profile.StudentRepository, messages message.Repository, analytics analytics.Repository, tx UnitOfWork, notifier Notifier) *ApplicationService {
+	service := NewApplicationService(repo, vacancies, students, messages, analytics, tx)
+	service.notifier = notifier
+	return service
+}
+
+// WithRealtime включает отправку смены статусов и системных сообщений в поток событий студента и компании.
+func (s *ApplicationService) WithRealtime(publisher RealtimePublisher) *ApplicationService {
+	s.realtime = publisher
+	return s
+}
+
+// WithBlockList запрещает отклик, если студент или компания внесли другого в чёрный список.
+func (s *ApplicationService) WithBlockList(blocks user.BlockListRepository) *ApplicationService {
+	s.blocks = blocks
+	return s
 }
 
 func (s *ApplicationService) Apply(ctx context.Context, vacancyID, studentID common.UUID) (*application.Application, error) {
@@ -40,6 +69,9 @@ func (s *ApplicationService) Apply(ctx context.Context, vacancyID, studentID com
 	if vac.Status != vacancy.StatusPublished {
 		return nil, common.NewError(common.CodeValidation, "vacancy is not published", nil)
 	}
+	if err := ensureNotBlocked(ctx, s.blocks, studentID, vac.CompanyID); err != nil {
+		return nil, err
+	}
 	if _, err := s.repo.FindByVacancyAndStudent(ctx, vacancyID, studentID); err == nil {
 		return nil, common.NewError(common.CodeConflict, "already applied", nil)
 	} else if !common.Is(err, common.CodeNotFound) {
@@ -50,11 +82,29 @@ func (s *ApplicationService) Apply(ctx context.Context, vacancyID, studentID com
 		StudentID: studentID,
 		Status:    application.StatusApplied,
 	}
-	created, err := s.repo.Create(ctx, app)
+	var created *application.Application
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		created, err = s.repo.Create(ctx, app)
+		if err != nil {
+			return err
+		}
+		if err := s.analytics.Create(ctx, analytics.Event{Name: "application.created", UserID: &studentID, Payload: analyticsPayload(ctx, map[string]string{"application_id": created.ID.String(), "vacancy_id": vacancyID.String()})}); err != nil {
+			return err
+		}
+		if err := publishRealtime(ctx, s.realtime, realtime.TypeApplicationStatusChanged, created, studentID, vac.CompanyID); err != nil {
+			return err
+		}
+		return s.notifier.Notify(ctx, notification.Notification{
+			UserID:  vac.CompanyID,
+			Type:    notification.TypeApplicationReceived,
+			Text:    applicationReceivedNotificationText(vac.Title),
+			Payload: map[string]string{"application_id": created.ID.String(), "vacancy_id": vacancyID.String()},
+		})
+	})
 	if err != nil {
 		return nil, err
 	}
-	_ = s.analytics.Create(ctx, analytics.Event{Name: "application.created", UserID: &studentID, Payload: analyticsPayload(ctx, map[string]string{"application_id": created.ID.String(), "vacancy_id": vacancyID.String()})})
 	return created, nil
 }
 
@@ -75,33 +125,121 @@ func (s *ApplicationService) UpdateStatus(ctx context.Context, applicationID com
 	if isFinalStatus(currentStatus) {
 		return nil, common.NewError(common.CodeValidation, "application status is final", nil)
 	}
+	if nextStatus == application.StatusWithdrawn {
+		return nil, common.NewError(common.CodeForbidden, "only student can withdraw application", nil)
+	}
 	if !isAllowedTransition(currentStatus, nextStatus) {
 		return nil, common.NewError(common.CodeValidation, "invalid status transition", nil)
 	}
 	if nextStatus == application.StatusRejected && feedback == "" {
 		return nil, common.NewError(common.CodeValidation, "feedback is required for rejection", nil)
 	}
-	updated, err := s.repo.UpdateStatus(ctx, applicationID, nextStatus, feedback)
+	var updated *application.Application
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		updated, err = s.repo.UpdateStatus(ctx, application.StatusEvent{
+			ApplicationID: applicationID,
+			FromStatus:    currentStatus,
+			ToStatus:      nextStatus,
+			ChangedBy:     companyID,
+			Feedback:      feedback,
+		})
+		if err != nil {
+			return err
+		}
+		if err := s.analytics.Create(ctx, analytics.Event{Name: "application.status_changed", UserID: &companyID, Payload: analyticsPayload(ctx, map[string]string{"application_id": updated.ID.String(), "status": string(status)})}); err != nil {
+			return err
+		}
+		if err := publishRealtime(ctx, s.realtime, realtime.TypeApplicationStatusChanged, updated, updated.StudentID, companyID); err != nil {
+			return err
+		}
+		if text, ok := statusNotificationText(nextStatus, vac.Title, feedback); ok {
+			return s.notifier.Notify(ctx, notification.Notification{
+				UserID:  updated.StudentID,
+				Type:    notification.TypeApplicationStatus,
+				Text:    text,
+				Payload: map[string]string{"application_id": updated.ID.String(), "vacancy_id": updated.VacancyID.String(), "status": string(nextStatus)},
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// Withdraw отзывает отклик по инициативе студента; компания узнаёт об этом из системного сообщения в переписке.
+func (s *ApplicationService) Withdraw(ctx context.Context, applicationID, studentID common.UUID) (*application.Application, error) {
+	app, err := s.repo.GetByID(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	if app.StudentID != studentID {
+		return nil, common.NewError(common.CodeForbidden, "application belongs to another student", nil)
+	}
+	currentStatus := normalizeApplicationStatus(app.Status)
+	if isFinalStatus(currentStatus) {
+		return nil, common.NewError(common.CodeValidation, "application status is final", nil)
+	}
+	if !isAllowedTransition(currentStatus, application.StatusWithdrawn) {
+		return nil, common.NewError(common.CodeValidation, "invalid status transition", nil)
+	}
+	vac, err := s.vacancies.GetByID(ctx, app.VacancyID)
+	if err != nil {
+		return nil, err
+	}
+	var updated *application.Application
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		updated, err = s.repo.UpdateStatus(ctx, application.StatusEvent{
+			ApplicationID: applicationID,
+			FromStatus:    currentStatus,
+			ToStatus:      application.StatusWithdrawn,
+			ChangedBy:     studentID,
+		})
+		if err != nil {
+			return err
+		}
+		if err := s.analytics.Create(ctx, analytics.Event{Name: "application.withdrawn", UserID: &studentID, Payload: analyticsPayload(ctx, map[string]string{"application_id": updated.ID.String(), "vacancy_id": updated.VacancyID.String(), "from_status": string(currentStatus)})}); err != nil {
+			return err
+		}
+		systemMessage, err := s.messages.Create(ctx, message.Message{ApplicationID: applicationID, Kind: message.KindSystem, Body: withdrawalMessage})
+		if err != nil {
+			return err
+		}
+		if err := publishRealtime(ctx, s.realtime, realtime.TypeApplicationStatusChanged, updated, studentID, vac.CompanyID); err != nil {
+			return err
+		}
+		if err := publishRealtime(ctx, s.realtime, realtime.TypeMessageCreated, systemMessage, studentID, vac.CompanyID); err != nil {
+			return err
+		}
+		return s.notifier.Notify(ctx, notification.Notification{
+			UserID:  vac.CompanyID,
+			Type:    notification.TypeApplicationWithdrawn,
+			Text:    withdrawalNotificationText(vac.Title),
+			Payload: map[string]string{"application_id": updated.ID.String(), "vacancy_id": updated.VacancyID.String()},
+		})
+	})
 	if err != nil {
 		return nil, err
 	}
-	_ = s.analytics.Create(ctx, analytics.Event{Name: "application.status_changed", UserID: &companyID, Payload: analyticsPayload(ctx, map[string]string{"application_id": updated.ID.String(), "status": string(status)})})
 	return updated, nil
 }
 
 func isAllowedTransition(from application.Status, to application.Status) bool {
 	switch from {
 	case application.StatusApplied:
-		return to == application.StatusInvited || to == application.StatusRejected
+		return to == application.StatusInvited || to == application.StatusRejected || to == application.StatusWithdrawn
 	case application.StatusInvited:
-		return to == application.StatusAccepted || to == application.StatusRejected
+		return to == application.StatusAccepted || to == application.StatusRejected || to == application.StatusWithdrawn
 	default:
 		return false
 	}
 }
 
 func isFinalStatus(status application.Status) bool {
-	return status == application.StatusRejected || status == application.StatusAccepted
+	return status == application.StatusRejected || status == application.StatusAccepted || status == application.StatusWithdrawn
 }
 
 func normalizeApplicationStatus(status application.Status) application.Status {
@@ -112,14 +250,160 @@ func normalizeApplicationStatus(status application.Status) application.Status {
 	return normalized
 }
 
-func (s *ApplicationService) ListByStudent(ctx context.Context, studentID common.UUID) ([]application.Application, error) {
-	return s.repo.ListByStudent(ctx, studentID)
+// StudentApplication — отклик в списке студента с числом непрочитанных им сообщений.
+type StudentApplication struct {
+	application.Application
+	UnreadMessages int `json:"unread_messages"`
+}
+
+func (s *ApplicationService) ListByStudent(ctx context.Context, studentID common.UUID, page common.PageRequest) ([]StudentApplication, *common.Cursor, error) {
+	items, next, err := s.repo.ListByStudent(ctx, studentID, normalizePage(page))
+	if err != nil {
+		return nil, nil, err
+	}
+	ids := make([]common.UUID, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+	unread, err := s.messages.CountUnread(ctx, studentID, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	result := make([]StudentApplication, 0, len(items))
+	for _, item := range items {
+		result = append(result, StudentApplication{Application: item, UnreadMessages: unread[item.ID]})
+	}
+	return result, next, nil
+}
+
+func (s *ApplicationService) ListByCompany(ctx context.Context, companyID common.UUID, page common.PageRequest) ([]application.Application, *common.Cursor, error) {
+	return s.repo.ListByCompany(ctx, companyID, normalizePage(page))
+}
+
+// PipelineResult — страница воронки откликов и счётчики по всем статусам (с учётом фильтра по вакансии, но не по статусу).
+type PipelineResult struct {
+	Items  []application.PipelineItem
+	Next   *common.Cursor
+	Counts map[application.Status]int
+}
+
+var pipelineStatuses = []application.Status{
+	application.StatusApplied,
+	application.StatusInvited,
+	application.StatusAccepted,
+	application.StatusRejected,
+	application.StatusWithdrawn,
+}
+
+func (s *ApplicationService) Pipeline(ctx context.Context, query application.PipelineQuery) (*PipelineResult, error) {
+	query, err := normalizePipelineQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	if query.VacancyID != nil {
+		vac, err := s.vacancies.GetByID(ctx, *query.VacancyID)
+		if err != nil {
+			return nil, err
+		}
+		if vac.CompanyID != query.CompanyID {
+			return nil, common.NewError(common.CodeForbidden, "vacancy belongs to another company", nil)
+		}
+	}
+	items, next, err := s.repo.ListPipeline(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]common.UUID, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+	unread, err := s.messages.CountUnread(ctx, query.CompanyID, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		items[i].UnreadMessages = unread[items[i].ID]
+	}
+	counts, err := s.repo.CountByStatus(ctx, query.CompanyID, query.VacancyID)
+	if err != nil {
+		return nil, err
+	}
+	for _, status := range pipelineStatuses {
+		if _, ok := counts[status]; !ok {
+			counts[status] = 0
+		}
+	}
+	return &PipelineResult{Items: items, Next: next, Counts: counts}, nil
+}
+
+// normalizePipelineQuery приводит статусы и сортировку к каноническому виду и ограничивает размер страницы.
+func normalizePipelineQuery(query application.PipelineQuery) (application.PipelineQuery, error) {
+	fields := map[string]string{}
+	for i, status := range query.Statuses {
+		query.Statuses[i] = normalizeApplicationStatus(status)
+		if !isPipelineStatus(query.Statuses[i]) {
+			fields["status"] = "unknown status " + string(status)
+		}
+	}
+	switch query.Sort {
+	case "":
+		query.Sort = application.PipelineSortNewest
+	case application.PipelineSortNewest, application.PipelineSortOldest:
+	default:
+		fields["sort"] = "sort must be newest or oldest"
+	}
+	if len(fields) > 0 {
+		return query, common.NewValidationError("invalid pipeline query", fields)
+	}
+	page := normalizePage(common.PageRequest{Limit: query.Limit, After: query.After})
+	query.Limit = page.Limit
+	return query, nil
 }
 
-func (s *ApplicationService) ListByCompany(ctx context.Context, companyID common.UUID) ([]application.Application, error) {
-	return s.repo.ListByCompany(ctx, companyID)
+func isPipelineStatus(status application.Status) bool {
+	for _, known := range pipelineStatuses {
+		if status == known {
+			return true
+		}
+	}
+	return false
 }
 
 func (s *ApplicationService) Get(ctx context.Context, id common.UUID) (*application.Application, error) {
 	return s.repo.GetByID(ctx, id)
 }
+
+// GetForParticipant возвращает отклик вместе с историей статусов; доступен только студенту и компании-владельцу вакансии.
+func (s *ApplicationService) GetForParticipant(ctx context.Context, applicationID, userID common.UUID) (*application.Application, []application.StatusEvent, error) {
+	app, err := s.participantApplication(ctx, applicationID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	history, err := s.repo.ListStatusEvents(ctx, applicationID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return app, history, nil
+}
+
+func (s *ApplicationService) History(ctx context.Context, applicationID, userID common.UUID) ([]application.StatusEvent, error) {
+	if _, err := s.participantApplication(ctx, applicationID, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListStatusEvents(ctx, applicationID)
+}
+
+func (s *ApplicationService) participantApplication(ctx context.Context, applicationID, userID common.UUID) (*application.Application, error) {
+	app, err := s.repo.GetByID(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	vac, err := s.vacancies.GetByID(ctx, app.VacancyID)
+	if err != nil {
+		return nil, err
+	}
+	if userID != app.StudentID && userID != vac.CompanyID {
+		return nil, common.NewError(common.CodeForbidden, "user is not allowed to view application", nil)
+	}
+	return app, nil
+}
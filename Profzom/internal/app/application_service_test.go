@@ -0,0 +1,334 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/application"
+	"profzom/internal/domain/message"
+	"profzom/internal/realtime"
+)
+
+func TestApplicationServiceUpdateStatus_RecordsHistory(t *testing.T) {
+	f := newTestEnv(t)
+
+	if _, err := f.applicationSvc.UpdateStatus(context.Background(), f.application.ID, application.StatusInvited, "", f.companyID); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	history, err := f.applicationSvc.History(context.Background(), f.application.ID, f.studentID)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("expected 2 history events, got %d", len(history))
+	}
+	last := history[1]
+	if last.FromStatus != application.StatusApplied || last.ToStatus != application.StatusInvited || last.ChangedBy != f.companyID {
+		t.Fatalf("unexpected history event %+v", last)
+	}
+}
+
+// staleApplicationRepo отдаёт отклик в том виде, в каком он был до параллельного изменения.
+type staleApplicationRepo struct {
+	*fakeApplicationRepo
+	snapshot application.Application
+}
+
+func (r staleApplicationRepo) GetByID(ctx context.Context, id common.UUID) (*application.Application, error) {
+	app := r.snapshot
+	return &app, nil
+}
+
+func TestApplicationServiceUpdateStatus_ConflictOnConcurrentWithdraw(t *testing.T) {
+	f := newTestEnv(t)
+	stale := staleApplicationRepo{fakeApplicationRepo: f.applications, snapshot: *f.application}
+	company := NewApplicationServiceWithNotifier(stale, f.vacancies, nil, f.messages, noopAnalyticsRepo{}, directUnitOfWork{}, f.notifier)
+
+	if _, err := f.applicationSvc.Withdraw(context.Background(), f.application.ID, f.studentID); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if _, err := company.UpdateStatus(context.Background(), f.application.ID, application.StatusInvited, "", f.companyID); !common.Is(err, common.CodeConflict) {
+		t.Fatalf("expected conflict for status changed concurrently, got %v", err)
+	}
+	current, _ := f.applications.GetByID(context.Background(), f.application.ID)
+	history, _ := f.applications.ListStatusEvents(context.Background(), f.application.ID)
+	if current.Status != application.StatusWithdrawn || history[len(history)-1].ToStatus != application.StatusWithdrawn {
+		t.Fatalf("expected withdrawn application to stay withdrawn, got %q, history %+v", current.Status, history)
+	}
+}
+
+func TestApplicationServiceHistory_ForbiddenForStranger(t *testing.T) {
+	f := newTestEnv(t)
+
+	_, err := f.applicationSvc.History(context.Background(), f.application.ID, common.NewUUID())
+	if !common.Is(err, common.CodeForbidden) {
+		t.Fatalf("expected forbidden error, got %v", err)
+	}
+}
+
+func TestApplicationServiceWithdraw_PostsSystemMessage(t *testing.T) {
+	f := newTestEnv(t)
+
+	updated, err := f.applicationSvc.Withdraw(context.Background(), f.application.ID, f.studentID)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if updated.Status != application.StatusWithdrawn {
+		t.Fatalf("expected status %q, got %q", application.StatusWithdrawn, updated.Status)
+	}
+	messages, _, _ := f.messages.ListByApplication(context.Background(), f.application.ID, "", common.PageRequest{})
+	if len(messages) != 1 || messages[0].Kind != message.KindSystem || messages[0].SenderID != "" {
+		t.Fatalf("expected one system message, got %+v", messages)
+	}
+}
+
+func TestApplicationServiceWithdraw_ForbiddenForAnotherStudent(t *testing.T) {
+	f := newTestEnv(t)
+
+	_, err := f.applicationSvc.Withdraw(context.Background(), f.application.ID, common.NewUUID())
+	if !common.Is(err, common.CodeForbidden) {
+		t.Fatalf("expected forbidden error, got %v", err)
+	}
+}
+
+func TestApplicationServiceWithdraw_IsFinal(t *testing.T) {
+	f := newTestEnv(t)
+
+	if _, err := f.applicationSvc.Withdraw(context.Background(), f.application.ID, f.studentID); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	_, err := f.applicationSvc.UpdateStatus(context.Background(), f.application.ID, application.StatusInvited, "", f.companyID)
+	if !common.Is(err, common.CodeValidation) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestApplicationServiceUpdateStatus_CompanyCannotWithdraw(t *testing.T) {
+	f := newTestEnv(t)
+
+	_, err := f.applicationSvc.UpdateStatus(context.Background(), f.application.ID, application.StatusWithdrawn, "", f.companyID)
+	if !common.Is(err, common.CodeForbidden) {
+		t.Fatalf("expected forbidden error, got %v", err)
+	}
+}
+
+func TestApplicationServiceUpdateStatus_NotifiesStudent(t *testing.T) {
+	f := newTestEnv(t)
+
+	if _, err := f.applicationSvc.UpdateStatus(context.Background(), f.application.ID, application.StatusInvited, "", f.companyID); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(f.notifier.sent) != 1 || f.notifier.sent[0].UserID != f.studentID {
+		t.Fatalf("expected one notification for student, got %+v", f.notifier.sent)
+	}
+	if !strings.Contains(f.notifier.sent[0].Text, "Go intern") {
+		t.Fatalf("expected vacancy title in notification, got %q", f.notifier.sent[0].Text)
+	}
+}
+
+func TestApplicationServiceWithdraw_NotifiesCompany(t *testing.T) {
+	f := newTestEnv(t)
+
+	if _, err := f.applicationSvc.Withdraw(context.Background(), f.application.ID, f.studentID); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(f.notifier.sent) != 1 || f.notifier.sent[0].UserID != f.companyID {
+		t.Fatalf("expected one notification for company, got %+v", f.notifier.sent)
+	}
+}
+
+func TestMessageServiceSend_NotifiesOtherParticipant(t *testing.T) {
+	f := newTestEnv(t)
+
+	if _, err := f.messageSvc.Send(context.Background(), f.application.ID, f.studentID, "Hello!"); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(f.notifier.sent) != 1 || f.notifier.sent[0].UserID != f.companyID {
+		t.Fatalf("expected one notification for company, got %+v", f.notifier.sent)
+	}
+}
+
+type recordingRealtime struct {
+	events []realtime.Event
+}
+
+func (r *recordingRealtime) Publish(ctx context.Context, event realtime.Event) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+func TestMessageServiceSend_PublishesRealtimeEvent(t *testing.T) {
+	f := newTestEnv(t)
+	stream := &recordingRealtime{}
+	f.messageSvc.WithRealtime(stream)
+
+	created, err := f.messageSvc.Send(context.Background(), f.application.ID, f.studentID, "Hello!")
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(stream.events) != 1 || stream.events[0].Type != realtime.TypeMessageCreated {
+		t.Fatalf("expected one message.created event, got %+v", stream.events)
+	}
+	event := stream.events[0]
+	if len(event.UserIDs) != 2 || event.UserIDs[0] != f.studentID || event.UserIDs[1] != f.companyID {
+		t.Fatalf("expected event for both participants, got %v", event.UserIDs)
+	}
+	if !strings.Contains(string(event.Data), created.ID.String()) {
+		t.Fatalf("expected message in event data, got %s", event.Data)
+	}
+}
+
+func TestApplicationServiceUpdateStatus_PublishesRealtimeEvent(t *testing.T) {
+	f := newTestEnv(t)
+	stream := &recordingRealtime{}
+	f.applicationSvc.WithRealtime(stream)
+
+	if _, err := f.applicationSvc.UpdateStatus(context.Background(), f.application.ID, application.StatusInvited, "", f.companyID); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(stream.events) != 1 || stream.events[0].Type != realtime.TypeApplicationStatusChanged || !strings.Contains(string(stream.events[0].Data), `"status":"invited"`) {
+		t.Fatalf("expected application.status_changed event, got %+v", stream.events)
+	}
+}
+
+func TestApplicationServicePipeline_FiltersAndCounts(t *testing.T) {
+	f := newTestEnv(t)
+	other, _ := f.applications.Create(context.Background(), application.Application{VacancyID: f.application.VacancyID, StudentID: common.NewUUID(), Status: application.StatusApplied})
+	if _, err := f.applicationSvc.UpdateStatus(context.Background(), other.ID, application.StatusInvited, "", f.companyID); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	result, err := f.applicationSvc.Pipeline(context.Background(), application.PipelineQuery{CompanyID: f.companyID, VacancyID: &f.application.VacancyID, Statuses: []application.Status{"Invited"}})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(result.Items) != 1 || result.Items[0].ID != other.ID {
+		t.Fatalf("expected only invited application, got %+v", result.Items)
+	}
+	if result.Counts[application.StatusApplied] != 1 || result.Counts[application.StatusInvited] != 1 {
+		t.Fatalf("unexpected counts %v", result.Counts)
+	}
+	if count, ok := result.Counts[application.StatusWithdrawn]; !ok || count != 0 {
+		t.Fatalf("expected zero count for withdrawn, got %v", result.Counts)
+	}
+}
+
+func TestApplicationServicePipeline_LegacyInterviewIsInvited(t *testing.T) {
+	f := newTestEnv(t)
+	legacy, _ := f.applications.Create(context.Background(), application.Application{VacancyID: f.application.VacancyID, StudentID: common.NewUUID(), Status: " Interview"})
+
+	result, err := f.applicationSvc.Pipeline(context.Background(), application.PipelineQuery{CompanyID: f.companyID, Statuses: []application.Status{application.StatusInvited}})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(result.Items) != 1 || result.Items[0].ID != legacy.ID {
+		t.Fatalf("expected legacy interview row in invited filter, got %+v", result.Items)
+	}
+	if result.Counts[application.StatusInvited] != len(result.Items) {
+		t.Fatalf("expected invited count to match filtered list, got %v", result.Counts)
+	}
+}
+
+func TestApplicationServicePipeline_RejectsUnknownStatus(t *testing.T) {
+	f := newTestEnv(t)
+
+	_, err := f.applicationSvc.Pipeline(context.Background(), application.PipelineQuery{CompanyID: f.companyID, Statuses: []application.Status{"hired"}})
+	if !common.Is(err, common.CodeValidation) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestApplicationServicePipeline_ForbiddenForForeignVacancy(t *testing.T) {
+	f := newTestEnv(t)
+
+	_, err := f.applicationSvc.Pipeline(context.Background(), application.PipelineQuery{CompanyID: common.NewUUID(), VacancyID: &f.application.VacancyID})
+	if !common.Is(err, common.CodeForbidden) {
+		t.Fatalf("expected forbidden error, got %v", err)
+	}
+}
+
+func TestMessageServiceMarkRead_UpdatesUnreadCountsAndFlags(t *testing.T) {
+	f := newTestEnv(t)
+	stream := &recordingRealtime{}
+	f.messageSvc.WithRealtime(stream)
+	ctx := context.Background()
+
+	if _, err := f.messageSvc.Send(ctx, f.application.ID, f.studentID, "Hello!"); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	reply, err := f.messages.Create(ctx, message.Message{ApplicationID: f.application.ID, SenderID: f.companyID, Body: "Hi"})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if _, err := f.messages.Create(ctx, message.Message{ApplicationID: f.application.ID, SenderID: f.companyID, Body: "When can you start?"}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	items, _, err := f.applicationSvc.ListByStudent(ctx, f.studentID, common.PageRequest{})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(items) != 1 || items[0].UnreadMessages != 2 {
+		t.Fatalf("expected 2 unread messages for student, got %+v", items)
+	}
+
+	if _, err := f.messageSvc.MarkRead(ctx, f.application.ID, f.studentID, reply.ID); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	items, _, err = f.applicationSvc.ListByStudent(ctx, f.studentID, common.PageRequest{})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if items[0].UnreadMessages != 1 {
+		t.Fatalf("expected 1 unread message after marking reply read, got %d", items[0].UnreadMessages)
+	}
+	last := stream.events[len(stream.events)-1]
+	if last.Type != realtime.TypeMessageRead || len(last.UserIDs) != 2 {
+		t.Fatalf("expected message.read event for both participants, got %+v", last)
+	}
+
+	thread, _, err := f.messageSvc.List(ctx, f.application.ID, f.companyID, common.PageRequest{})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(thread) != 3 {
+		t.Fatalf("expected 3 messages, got %d", len(thread))
+	}
+	// для компании: сообщение студента не прочитано ею, её первое сообщение студент прочитал, второе — нет
+	if thread[0].IsRead || !thread[1].IsRead || thread[2].IsRead {
+		t.Fatalf("expected read flags [false true false], got [%v %v %v]", thread[0].IsRead, thread[1].IsRead, thread[2].IsRead)
+	}
+}
+
+func TestMessageServiceMarkRead_DoesNotMoveBackwards(t *testing.T) {
+	f := newTestEnv(t)
+	ctx := context.Background()
+	first, _ := f.messages.Create(ctx, message.Message{ApplicationID: f.application.ID, SenderID: f.companyID, Body: "One"})
+	second, _ := f.messages.Create(ctx, message.Message{ApplicationID: f.application.ID, SenderID: f.companyID, Body: "Two"})
+
+	if _, err := f.messageSvc.MarkRead(ctx, f.application.ID, f.studentID, ""); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	state, err := f.messageSvc.MarkRead(ctx, f.application.ID, f.studentID, first.ID)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if state.LastReadMessageID != second.ID {
+		t.Fatalf("expected read state to stay at %s, got %s", second.ID, state.LastReadMessageID)
+	}
+}
+
+func TestMessageServiceMarkRead_RejectsOutsider(t *testing.T) {
+	f := newTestEnv(t)
+	if _, err := f.messages.Create(context.Background(), message.Message{ApplicationID: f.application.ID, SenderID: f.companyID, Body: "One"}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	_, err := f.messageSvc.MarkRead(context.Background(), f.application.ID, common.NewUUID(), "")
+	if !common.Is(err, common.CodeForbidden) {
+		t.Fatalf("expected forbidden error, got %v", err)
+	}
+}
@@ -24,6 +24,7 @@ type AuthService struct {
 	otp           auth.OTPRepository
 	refreshTokens auth.RefreshTokenRepository
 	analytics     analytics.Repository
+	tx            UnitOfWork
 	jwtProvider   *security.JWTProvider
 	otpBot        otpbot.Client
 	telegramLinks telegram.LinkRepository
@@ -46,12 +47,13 @@ type Logger interface {
 	Error(msg string)
 }
 
-func NewAuthService(users user.Repository, otp auth.OTPRepository, refreshTokens auth.RefreshTokenRepository, analytics analytics.Repository, jwtProvider *security.JWTProvider, otpBot otpbot.Client, logger Logger, accessTTL, refreshTTL, otpTTL time.Duration) *AuthService {
+func NewAuthService(users user.Repository, otp auth.OTPRepository, refreshTokens auth.RefreshTokenRepository, analytics analytics.Repository, tx UnitOfWork, jwtProvider *security.JWTProvider, otpBot otpbot.Client, logger Logger, accessTTL, refreshTTL, otpTTL time.Duration) *AuthService {
 	return &AuthService{
 		users:         users,
 		otp:           otp,
 		refreshTokens: refreshTokens,
 		analytics:     analytics,
+		tx:            tx,
 		jwtProvider:   jwtProvider,
 		otpBot:        otpBot,
 		telegramLinks: nil,
@@ -62,8 +64,8 @@ func NewAuthService(users user.Repository, otp auth.OTPRepository, refreshTokens
 	}
 }
 
-func NewAuthServiceWithTelegramLinks(users user.Repository, otp auth.OTPRepository, refreshTokens auth.RefreshTokenRepository, analytics analytics.Repository, jwtProvider *security.JWTProvider, otpBot otpbot.Client, telegramLinks telegram.LinkRepository, logger Logger, accessTTL, refreshTTL, otpTTL time.Duration) *AuthService {
-	service := NewAuthService(users, otp, refreshTokens, analytics, jwtProvider, otpBot, logger, accessTTL, refreshTTL, otpTTL)
+func NewAuthServiceWithTelegramLinks(users user.Repository, otp auth.OTPRepository, refreshTokens auth.RefreshTokenRepository, analytics analytics.Repository, tx UnitOfWork, jwtProvider *security.JWTProvider, otpBot otpbot.Client, telegramLinks telegram.LinkRepository, logger Logger, accessTTL, refreshTTL, otpTTL time.Duration) *AuthService {
+	service := NewAuthService(users, otp, refreshTokens, analytics, tx, jwtProvider, otpBot, logger, accessTTL, refreshTTL, otpTTL)
 	service.telegramLinks = telegramLinks
 	return service
 }
@@ -88,7 +90,10 @@ func (s *AuthService) Register(ctx context.Context) (*RegistrationResult, error)
 	if err := s.otpBot.RegisterLinkToken(ctx, account.ID.String(), code); err != nil {
 		return nil, s.handleOTPBotError(err, account.ID, "link")
 	}
-	_ = s.analytics.Create(ctx, analytics.Event{Name: "auth.telegram_link_requested", UserID: &account.ID, Payload: analyticsPayload(ctx, map[string]string{"user_id": account.ID.String()})})
+	// код уже передан боту, поэтому сбой записи события не должен ломать регистрацию
+	if err := s.analytics.Create(ctx, analytics.Event{Name: "auth.telegram_link_requested", UserID: &account.ID, Payload: analyticsPayload(ctx, map[string]string{"user_id": account.ID.String()})}); err != nil {
+		s.logError(fmt.Sprintf("analytics event failed event=auth.telegram_link_requested user_id=%s: %v", account.ID, err))
+	}
 	return &RegistrationResult{UserID: account.ID, LinkCode: code}, nil
 }
 
@@ -139,10 +144,15 @@ func (s *AuthService) RequestOTPByTelegram(ctx context.Context, chatID int64) (*
 		return nil, common.NewError(common.CodeInternal, "failed to generate otp", err)
 	}
 	expiresAt := time.Now().UTC().Add(s.otpTTL)
-	if err := s.otp.UpsertCode(ctx, userID, code, expiresAt.Unix(), otpMaxAttempts); err != nil {
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		if err := s.otp.UpsertCode(ctx, userID, code, expiresAt.Unix(), otpMaxAttempts); err != nil {
+			return err
+		}
+		return s.analytics.Create(ctx, analytics.Event{Name: "auth.otp_requested", UserID: &account.ID, Payload: analyticsPayload(ctx, map[string]string{"user_id": account.ID.String()})})
+	})
+	if err != nil {
 		return nil, err
 	}
-	_ = s.analytics.Create(ctx, analytics.Event{Name: "auth.otp_requested", UserID: &account.ID, Payload: analyticsPayload(ctx, map[string]string{"user_id": account.ID.String()})})
 	return &OTPRequestPayload{Code: code, ExpiresAt: expiresAt}, nil
 }
 
@@ -153,26 +163,37 @@ func (s *AuthService) VerifyOTP(ctx context.Context, userID, code string) (*auth
 	}
 	if !ok {
 		s.logInfo(fmt.Sprintf("otp verification failed user_id=%s", userID))
-		_ = s.analytics.Create(ctx, analytics.Event{Name: "auth.otp_failed", Payload: analyticsPayload(ctx, map[string]string{"user_id": userID})})
+		// списанная попытка уже сохранена и не должна откатываться из-за сбоя аналитики
+		if err := s.analytics.Create(ctx, analytics.Event{Name: "auth.otp_failed", Payload: analyticsPayload(ctx, map[string]string{"user_id": userID})}); err != nil {
+			s.logError(fmt.Sprintf("analytics event failed event=auth.otp_failed user_id=%s: %v", userID, err))
+		}
 		return nil, nil, false, common.NewError(common.CodeUnauthorized, "invalid otp code", nil)
 	}
-	if err := s.otp.InvalidateCode(ctx, userID); err != nil {
-		return nil, nil, false, err
-	}
 	parsedID, err := common.ParseUUID(userID)
 	if err != nil {
 		return nil, nil, false, common.NewError(common.CodeValidation, "invalid user_id", err)
 	}
-	account, err := s.users.GetByID(ctx, parsedID)
+	var pair *auth.TokenPair
+	var account *user.User
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		if err := s.otp.InvalidateCode(ctx, userID); err != nil {
+			return err
+		}
+		var err error
+		account, err = s.users.GetByID(ctx, parsedID)
+		if err != nil {
+			return err
+		}
+		pair, err = s.issueTokens(ctx, account)
+		if err != nil {
+			return err
+		}
+		return s.analytics.Create(ctx, analytics.Event{Name: "auth.logged_in", UserID: &account.ID, Payload: analyticsPayload(ctx, map[string]string{"user_id": account.ID.String()})})
+	})
 	if err != nil {
 		return nil, nil, false, err
 	}
 	isNewUser := len(account.Roles) == 0
-	pair, err := s.issueTokens(ctx, account)
-	if err != nil {
-		return nil, nil, false, err
-	}
-	_ = s.analytics.Create(ctx, analytics.Event{Name: "auth.logged_in", UserID: &account.ID, Payload: analyticsPayload(ctx, map[string]string{"user_id": account.ID.String()})})
 	s.logInfo(fmt.Sprintf("user logged in user_id=%s", account.ID))
 	return pair, account, isNewUser, nil
 }
@@ -225,6 +246,9 @@ func (s *AuthService) Logout(ctx context.Context, token string) error {
 }
 
 func (s *AuthService) issueTokens(ctx context.Context, account *user.User) (*auth.TokenPair, error) {
+	if account.BlockedAt != nil {
+		return nil, common.NewError(common.CodeForbidden, "account is blocked", nil)
+	}
 	roles := make([]string, len(account.Roles))
 	for i, role := range account.Roles {
 		roles[i] = string(role)
@@ -14,7 +14,6 @@ import (
 	"profzom/internal/domain/analytics"
 	"profzom/internal/domain/auth"
 	"profzom/internal/domain/telegram"
-	"profzom/internal/domain/user"
 	"profzom/internal/integration/otpbot"
 	"profzom/internal/security"
 )
@@ -105,138 +104,6 @@ func (r *fakeOTPRepo) DeleteExpired(ctx context.Context, beforeUnix int64) error
 	return nil
 }
 
-type fakeUserRepo struct {
-	mu      sync.Mutex
-	byPhone map[string]*user.User
-	byID    map[common.UUID]*user.User
-}
-
-func newFakeUserRepo() *fakeUserRepo {
-	return &fakeUserRepo{
-		byPhone: make(map[string]*user.User),
-		byID:    make(map[common.UUID]*user.User),
-	}
-}
-
-func (r *fakeUserRepo) FindByPhone(ctx context.Context, phone string) (*user.User, error) {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-	account := r.byPhone[phone]
-	if account == nil {
-		return nil, common.NewError(common.CodeNotFound, "user not found", nil)
-	}
-	return cloneUser(account), nil
-}
-
-func (r *fakeUserRepo) GetByID(ctx context.Context, id common.UUID) (*user.User, error) {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-	account := r.byID[id]
-	if account == nil {
-		return nil, common.NewError(common.CodeNotFound, "user not found", nil)
-	}
-	return cloneUser(account), nil
-}
-
-func (r *fakeUserRepo) Create(ctx context.Context, phone string) (*user.User, error) {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-	id := common.NewUUID()
-	now := time.Now().UTC()
-	account := &user.User{ID: id, Phone: phone, CreatedAt: now, UpdatedAt: now}
-	if phone != "" {
-		r.byPhone[phone] = account
-	}
-	r.byID[id] = account
-	return cloneUser(account), nil
-}
-
-func (r *fakeUserRepo) SetRoles(ctx context.Context, userID common.UUID, roles []user.Role) error {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-	account := r.byID[userID]
-	if account == nil {
-		return common.NewError(common.CodeNotFound, "user not found", nil)
-	}
-	account.Roles = append([]user.Role(nil), roles...)
-	return nil
-}
-
-func (r *fakeUserRepo) ListRoles(ctx context.Context, userID common.UUID) ([]user.Role, error) {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-	account := r.byID[userID]
-	if account == nil {
-		return nil, common.NewError(common.CodeNotFound, "user not found", nil)
-	}
-	return append([]user.Role(nil), account.Roles...), nil
-}
-
-func cloneUser(account *user.User) *user.User {
-	copy := *account
-	copy.Roles = append([]user.Role(nil), account.Roles...)
-	return &copy
-}
-
-type fakeRefreshTokenRepo struct {
-	mu     sync.Mutex
-	tokens map[string]auth.RefreshToken
-}
-
-func newFakeRefreshTokenRepo() *fakeRefreshTokenRepo {
-	return &fakeRefreshTokenRepo{tokens: make(map[string]auth.RefreshToken)}
-}
-
-func (r *fakeRefreshTokenRepo) Store(ctx context.Context, token auth.RefreshToken) error {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-	r.tokens[token.Token] = token
-	return nil
-}
-
-func (r *fakeRefreshTokenRepo) GetByToken(ctx context.Context, token string) (*auth.RefreshToken, error) {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-	value, ok := r.tokens[token]
-	if !ok {
-		return nil, common.NewError(common.CodeNotFound, "refresh token not found", nil)
-	}
-	copy := value
-	return &copy, nil
-}
-
-func (r *fakeRefreshTokenRepo) Revoke(ctx context.Context, token string, revokedAtUnix int64) error {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-	value, ok := r.tokens[token]
-	if !ok {
-		return common.NewError(common.CodeNotFound, "refresh token not found", nil)
-	}
-	revokedAt := time.Unix(revokedAtUnix, 0).UTC()
-	value.RevokedAt = &revokedAt
-	r.tokens[token] = value
-	return nil
-}
-
-func (r *fakeRefreshTokenRepo) RevokeAll(ctx context.Context, userID common.UUID, revokedAtUnix int64) error {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-	revokedAt := time.Unix(revokedAtUnix, 0).UTC()
-	for key, value := range r.tokens {
-		if value.UserID == userID {
-			value.RevokedAt = &revokedAt
-			r.tokens[key] = value
-		}
-	}
-	return nil
-}
-
-type noopAnalyticsRepo struct{}
-
-func (noopAnalyticsRepo) Create(ctx context.Context, event analytics.Event) error {
-	return nil
-}
-
 type fakeTelegramLinkRepo struct {
 	mu    sync.Mutex
 	links map[int64]*telegram.Link
@@ -307,13 +174,17 @@ func (b *fakeOTPBot) SendOTP(ctx context.Context, phone, code string) error {
 	return nil
 }
 
+func (b *fakeOTPBot) Notify(ctx context.Context, userID, text string) error {
+	return nil
+}
+
 func TestAuthServiceRegister_IssuesLinkCode(t *testing.T) {
 	otpRepo := newFakeOTPRepo()
 	userRepo := newFakeUserRepo()
 	refreshRepo := newFakeRefreshTokenRepo()
 	otpBot := &fakeOTPBot{}
 	jwtProvider := security.NewJWTProvider("secret")
-	service := NewAuthService(userRepo, otpRepo, refreshRepo, noopAnalyticsRepo{}, jwtProvider, otpBot, nil, time.Minute, time.Hour, 5*time.Minute)
+	service := NewAuthService(userRepo, otpRepo, refreshRepo, noopAnalyticsRepo{}, directUnitOfWork{}, jwtProvider, otpBot, nil, time.Minute, time.Hour, 5*time.Minute)
 
 	result, err := service.Register(context.Background())
 	if err != nil {
@@ -345,7 +216,7 @@ func TestAuthServiceRequestOTPByTelegram(t *testing.T) {
 	refreshRepo := newFakeRefreshTokenRepo()
 	linkRepo := newFakeTelegramLinkRepo()
 	jwtProvider := security.NewJWTProvider("secret")
-	service := NewAuthServiceWithTelegramLinks(userRepo, otpRepo, refreshRepo, noopAnalyticsRepo{}, jwtProvider, nil, linkRepo, nil, time.Minute, time.Hour, 5*time.Minute)
+	service := NewAuthServiceWithTelegramLinks(userRepo, otpRepo, refreshRepo, noopAnalyticsRepo{}, directUnitOfWork{}, jwtProvider, nil, linkRepo, nil, time.Minute, time.Hour, 5*time.Minute)
 
 	account, err := userRepo.Create(context.Background(), "")
 	if err != nil {
@@ -377,7 +248,7 @@ func TestAuthServiceVerifyOTP_Success(t *testing.T) {
 	userRepo := newFakeUserRepo()
 	refreshRepo := newFakeRefreshTokenRepo()
 	jwtProvider := security.NewJWTProvider("secret")
-	service := NewAuthService(userRepo, otpRepo, refreshRepo, noopAnalyticsRepo{}, jwtProvider, nil, nil, time.Minute, time.Hour, 5*time.Minute)
+	service := NewAuthService(userRepo, otpRepo, refreshRepo, noopAnalyticsRepo{}, directUnitOfWork{}, jwtProvider, nil, nil, time.Minute, time.Hour, 5*time.Minute)
 
 	account, err := userRepo.Create(context.Background(), "")
 	if err != nil {
@@ -406,12 +277,42 @@ func TestAuthServiceVerifyOTP_Success(t *testing.T) {
 	}
 }
 
+type failingAnalyticsRepo struct{}
+
+func (failingAnalyticsRepo) Create(ctx context.Context, event analytics.Event) error {
+	return common.NewError(common.CodeInternal, "failed to enqueue outbox message", nil)
+}
+
+func TestAuthServiceVerifyOTP_ReturnsAnalyticsError(t *testing.T) {
+	otpRepo := newFakeOTPRepo()
+	userRepo := newFakeUserRepo()
+	refreshRepo := newFakeRefreshTokenRepo()
+	jwtProvider := security.NewJWTProvider("secret")
+	service := NewAuthService(userRepo, otpRepo, refreshRepo, failingAnalyticsRepo{}, directUnitOfWork{}, jwtProvider, nil, nil, time.Minute, time.Hour, 5*time.Minute)
+
+	account, err := userRepo.Create(context.Background(), "")
+	if err != nil {
+		t.Fatalf("expected user created, got %v", err)
+	}
+	code := "123456"
+	otpRepo.entries[account.ID.String()] = &otpEntry{
+		hash:         hashOTP(code),
+		expiresAt:    time.Now().Add(5 * time.Minute).UTC().Unix(),
+		attemptsLeft: otpMaxAttempts,
+		requestedAt:  time.Now().UTC().Unix(),
+	}
+
+	if _, _, _, err := service.VerifyOTP(context.Background(), account.ID.String(), code); !common.Is(err, common.CodeInternal) {
+		t.Fatalf("expected internal error, got %v", err)
+	}
+}
+
 func TestAuthServiceVerifyOTP_Expired(t *testing.T) {
 	otpRepo := newFakeOTPRepo()
 	userRepo := newFakeUserRepo()
 	refreshRepo := newFakeRefreshTokenRepo()
 	jwtProvider := security.NewJWTProvider("secret")
-	service := NewAuthService(userRepo, otpRepo, refreshRepo, noopAnalyticsRepo{}, jwtProvider, nil, nil, time.Minute, time.Hour, 5*time.Minute)
+	service := NewAuthService(userRepo, otpRepo, refreshRepo, noopAnalyticsRepo{}, directUnitOfWork{}, jwtProvider, nil, nil, time.Minute, time.Hour, 5*time.Minute)
 
 	account, err := userRepo.Create(context.Background(), "")
 	if err != nil {
@@ -439,7 +340,7 @@ func TestAuthServiceVerifyOTP_AttemptsExceeded(t *testing.T) {
 	userRepo := newFakeUserRepo()
 	refreshRepo := newFakeRefreshTokenRepo()
 	jwtProvider := security.NewJWTProvider("secret")
-	service := NewAuthService(userRepo, otpRepo, refreshRepo, noopAnalyticsRepo{}, jwtProvider, nil, nil, time.Minute, time.Hour, 5*time.Minute)
+	service := NewAuthService(userRepo, otpRepo, refreshRepo, noopAnalyticsRepo{}, directUnitOfWork{}, jwtProvider, nil, nil, time.Minute, time.Hour, 5*time.Minute)
 
 	account, err := userRepo.Create(context.Background(), "")
 	if err != nil {
@@ -467,7 +368,7 @@ func TestAuthServiceVerifyOTPByTelegram(t *testing.T) {
 	refreshRepo := newFakeRefreshTokenRepo()
 	linkRepo := newFakeTelegramLinkRepo()
 	jwtProvider := security.NewJWTProvider("secret")
-	service := NewAuthServiceWithTelegramLinks(userRepo, otpRepo, refreshRepo, noopAnalyticsRepo{}, jwtProvider, nil, linkRepo, nil, time.Minute, time.Hour, 5*time.Minute)
+	service := NewAuthServiceWithTelegramLinks(userRepo, otpRepo, refreshRepo, noopAnalyticsRepo{}, directUnitOfWork{}, jwtProvider, nil, linkRepo, nil, time.Minute, time.Hour, 5*time.Minute)
 
 	account, err := userRepo.Create(context.Background(), "")
 	if err != nil {
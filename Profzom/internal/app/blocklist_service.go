@@ -0,0 +1,50 @@
+package app
+
+import (
+	"context"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/user"
+)
+
+// BlockListService — личный чёрный список пользователя. Блокировка действует в обе стороны:
+// пока один из двоих в списке у другого, они не могут переписываться, студент — откликаться на вакансии компании,
+// а компания — приглашать студента.
+type BlockListService struct {
+	blocks user.BlockListRepository
+}
+
+func NewBlockListService(blocks user.BlockListRepository) *BlockListService {
+	return &BlockListService{blocks: blocks}
+}
+
+func (s *BlockListService) Block(ctx context.Context, blockerID, blockedID common.UUID) (*user.BlockedUser, error) {
+	if blockerID == blockedID {
+		return nil, common.NewError(common.CodeValidation, "cannot block yourself", nil)
+	}
+	return s.blocks.Add(ctx, blockerID, blockedID)
+}
+
+func (s *BlockListService) Unblock(ctx context.Context, blockerID, blockedID common.UUID) error {
+	return s.blocks.Remove(ctx, blockerID, blockedID)
+}
+
+func (s *BlockListService) List(ctx context.Context, blockerID common.UUID, page common.PageRequest) ([]user.BlockedUser, *common.Cursor, error) {
+	return s.blocks.List(ctx, blockerID, normalizePage(page))
+}
+
+// ensureNotBlocked возвращает CodeForbidden, если один из пользователей внёс другого в чёрный список.
+// Без репозитория проверка не выполняется.
+func ensureNotBlocked(ctx context.Context, blocks user.BlockListRepository, a, b common.UUID) error {
+	if blocks == nil {
+		return nil
+	}
+	blocked, err := blocks.Between(ctx, a, b)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return common.NewError(common.CodeForbidden, "user is blocked", nil)
+	}
+	return nil
+}
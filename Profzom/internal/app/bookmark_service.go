@@ -0,0 +1,48 @@
+package app
+
+import (
+	"context"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/vacancy"
+)
+
+type BookmarkService struct {
+	bookmarks vacancy.BookmarkRepository
+	vacancies vacancy.Repository
+}
+
+func NewBookmarkService(bookmarks vacancy.BookmarkRepository, vacancies vacancy.Repository) *BookmarkService {
+	return &BookmarkService{bookmarks: bookmarks, vacancies: vacancies}
+}
+
+// Save сохраняет вакансию в закладки. Повторное сохранение снимает отметку об изменениях;
+// для уже сохранённой закрытой вакансии оно разрешено, новую закладку можно добавить только на опубликованную.
+func (s *BookmarkService) Save(ctx context.Context, studentID, vacancyID common.UUID) (*vacancy.Bookmark, error) {
+	vac, err := s.vacancies.GetByID(ctx, vacancyID)
+	if err != nil {
+		return nil, err
+	}
+	if vac.Status != vacancy.StatusPublished {
+		saved, err := s.bookmarks.SavedIDs(ctx, studentID, []common.UUID{vacancyID})
+		if err != nil {
+			return nil, err
+		}
+		if !saved[vacancyID] {
+			return nil, common.NewError(common.CodeValidation, "vacancy is not published", nil)
+		}
+	}
+	return s.bookmarks.Save(ctx, studentID, vacancyID)
+}
+
+func (s *BookmarkService) Remove(ctx context.Context, studentID, vacancyID common.UUID) error {
+	return s.bookmarks.Delete(ctx, studentID, vacancyID)
+}
+
+func (s *BookmarkService) List(ctx context.Context, studentID common.UUID, page common.PageRequest) ([]vacancy.SavedVacancy, *common.Cursor, error) {
+	return s.bookmarks.List(ctx, studentID, normalizePage(page))
+}
+
+func (s *BookmarkService) SavedIDs(ctx context.Context, studentID common.UUID, vacancyIDs []common.UUID) (map[common.UUID]bool, error) {
+	return s.bookmarks.SavedIDs(ctx, studentID, vacancyIDs)
+}
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/vacancy"
+)
+
+type fakeBookmarkRepo struct {
+	items map[common.UUID]map[common.UUID]vacancy.Bookmark
+}
+
+func newFakeBookmarkRepo() *fakeBookmarkRepo {
+	return &fakeBookmarkRepo{items: make(map[common.UUID]map[common.UUID]vacancy.Bookmark)}
+}
+
+func (r *fakeBookmarkRepo) Save(ctx context.Context, studentID, vacancyID common.UUID) (*vacancy.Bookmark, error) {
+	if r.items[studentID] == nil {
+		r.items[studentID] = make(map[common.UUID]vacancy.Bookmark)
+	}
+	bookmark := vacancy.Bookmark{StudentID: studentID, VacancyID: vacancyID, SavedAt: time.Now().UTC()}
+	r.items[studentID][vacancyID] = bookmark
+	return &bookmark, nil
+}
+
+func (r *fakeBookmarkRepo) Delete(ctx context.Context, studentID, vacancyID common.UUID) error {
+	if _, ok := r.items[studentID][vacancyID]; !ok {
+		return common.NewError(common.CodeNotFound, "saved vacancy not found", nil)
+	}
+	delete(r.items[studentID], vacancyID)
+	return nil
+}
+
+func (r *fakeBookmarkRepo) List(ctx context.Context, studentID common.UUID, page common.PageRequest) ([]vacancy.SavedVacancy, *common.Cursor, error) {
+	return nil, nil, nil
+}
+
+func (r *fakeBookmarkRepo) SavedIDs(ctx context.Context, studentID common.UUID, vacancyIDs []common.UUID) (map[common.UUID]bool, error) {
+	saved := make(map[common.UUID]bool)
+	for _, id := range vacancyIDs {
+		if _, ok := r.items[studentID][id]; ok {
+			saved[id] = true
+		}
+	}
+	return saved, nil
+}
+
+func TestBookmarkService_SaveRequiresPublishedVacancy(t *testing.T) {
+	vacancies := newFakeVacancyRepo()
+	draft, _ := vacancies.Create(context.Background(), vacancy.Vacancy{CompanyID: common.NewUUID(), Title: "Draft", Status: vacancy.StatusDraft})
+	service := NewBookmarkService(newFakeBookmarkRepo(), vacancies)
+
+	_, err := service.Save(context.Background(), common.NewUUID(), draft.ID)
+	if !common.Is(err, common.CodeValidation) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestBookmarkService_ResaveClosedVacancy(t *testing.T) {
+	vacancies := newFakeVacancyRepo()
+	vac, _ := vacancies.Create(context.Background(), vacancy.Vacancy{CompanyID: common.NewUUID(), Title: "Go intern", Status: vacancy.StatusPublished})
+	bookmarks := newFakeBookmarkRepo()
+	service := NewBookmarkService(bookmarks, vacancies)
+	studentID := common.NewUUID()
+
+	if _, err := service.Save(context.Background(), studentID, vac.ID); err != nil {
+		t.Fatalf("expected save to succeed, got %v", err)
+	}
+	vac.Status = vacancy.StatusClosed
+	if _, err := vacancies.Update(context.Background(), *vac); err != nil {
+		t.Fatalf("expected update to succeed, got %v", err)
+	}
+	if _, err := service.Save(context.Background(), studentID, vac.ID); err != nil {
+		t.Fatalf("expected re-save of closed vacancy to succeed, got %v", err)
+	}
+	if _, err := service.Save(context.Background(), common.NewUUID(), vac.ID); !common.Is(err, common.CodeValidation) {
+		t.Fatalf("expected validation error for new bookmark on closed vacancy, got %v", err)
+	}
+}
+
+func TestBookmarkService_RemoveMissing(t *testing.T) {
+	service := NewBookmarkService(newFakeBookmarkRepo(), newFakeVacancyRepo())
+	err := service.Remove(context.Background(), common.NewUUID(), common.NewUUID())
+	if !common.Is(err, common.CodeNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
@@ -0,0 +1,263 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/analytics"
+	"profzom/internal/domain/notification"
+	"profzom/internal/domain/profile"
+)
+
+const (
+	verificationLegalNameMaxLength = 300
+	verificationReasonMaxLength    = 500
+)
+
+// CompanyVerificationService ведёт проверку компаний: unverified → pending → verified или rejected.
+// Отклонённая компания может исправить реквизиты и подать заявку снова.
+type CompanyVerificationService struct {
+	verifications profile.VerificationRepository
+	companies     profile.CompanyRepository
+	analytics     analytics.Repository
+	tx            UnitOfWork
+	notifier      Notifier
+}
+
+// VerificationRequest — реквизиты, которые компания подаёт на проверку.
+type VerificationRequest struct {
+	LegalName string
+	INN       string
+	OGRN      string
+}
+
+func NewCompanyVerificationService(verifications profile.VerificationRepository, companies profile.CompanyRepository, analytics analytics.Repository, tx UnitOfWork, notifier Notifier) *CompanyVerificationService {
+	if notifier == nil {
+		notifier = noopNotifier{}
+	}
+	return &CompanyVerificationService{verifications: verifications, companies: companies, analytics: analytics, tx: tx, notifier: notifier}
+}
+
+// Get возвращает состояние проверки; компания без заявки получает статус unverified.
+func (s *CompanyVerificationService) Get(ctx context.Context, companyID common.UUID) (*profile.CompanyVerification, error) {
+	current, err := s.verifications.GetByCompany(ctx, companyID)
+	if common.Is(err, common.CodeNotFound) {
+		return &profile.CompanyVerification{CompanyID: companyID, Status: profile.VerificationUnverified}, nil
+	}
+	return current, err
+}
+
+// Submit отправляет реквизиты на проверку. Заявку в очереди можно исправить до решения модератора,
+// а подтверждённую компанию повторно не проверяем.
+func (s *CompanyVerificationService) Submit(ctx context.Context, companyID common.UUID, req VerificationRequest) (*profile.CompanyVerification, error) {
+	req, err := normalizeVerificationRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.companies.GetByUserID(ctx, companyID); err != nil {
+		if common.Is(err, common.CodeNotFound) {
+			return nil, common.NewError(common.CodeValidation, "company profile is required", nil)
+		}
+		return nil, err
+	}
+	current, err := s.Get(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if current.Status == profile.VerificationVerified {
+		return nil, common.NewError(common.CodeConflict, "company is already verified", nil)
+	}
+	now := time.Now().UTC()
+	var saved *profile.CompanyVerification
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		saved, err = s.verifications.Save(ctx, profile.CompanyVerification{
+			CompanyID:   companyID,
+			Status:      profile.VerificationPending,
+			LegalName:   req.LegalName,
+			INN:         req.INN,
+			OGRN:        req.OGRN,
+			SubmittedAt: &now,
+		})
+		if err != nil {
+			return err
+		}
+		return s.analytics.Create(ctx, analytics.Event{Name: "company.verification.submitted", UserID: &companyID, Payload: analyticsPayload(ctx, map[string]string{"company_id": companyID.String()})})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+// ListForReview отдаёт заявки с указанным статусом, по умолчанию — очередь pending.
+func (s *CompanyVerificationService) ListForReview(ctx context.Context, status profile.VerificationStatus, page common.PageRequest) ([]profile.CompanyVerification, *common.Cursor, error) {
+	switch status {
+	case "":
+		status = profile.VerificationPending
+	case profile.VerificationPending, profile.VerificationVerified, profile.VerificationRejected:
+	default:
+		return nil, nil, common.NewValidationError("invalid status", map[string]string{"status": "status must be pending, verified or rejected"})
+	}
+	return s.verifications.ListByStatus(ctx, status, normalizePage(page))
+}
+
+// Approve и Reject принимают seen — updated_at заявки, которую видел модератор: если компания успела
+// исправить реквизиты, решение по старой версии отклоняется с конфликтом.
+func (s *CompanyVerificationService) Approve(ctx context.Context, reviewerID, companyID common.UUID, seen time.Time) (*profile.CompanyVerification, error) {
+	return s.review(ctx, reviewerID, companyID, seen, profile.VerificationVerified, "")
+}
+
+func (s *CompanyVerificationService) Reject(ctx context.Context, reviewerID, companyID common.UUID, seen time.Time, reason string) (*profile.CompanyVerification, error) {
+	reason = strings.TrimSpace(reason)
+	switch {
+	case reason == "":
+		return nil, common.NewValidationError("invalid review", map[string]string{"reason": "reason is required"})
+	case len([]rune(reason)) > verificationReasonMaxLength:
+		return nil, common.NewValidationError("invalid review", map[string]string{"reason": "reason is too long"})
+	}
+	return s.review(ctx, reviewerID, companyID, seen, profile.VerificationRejected, reason)
+}
+
+// review фиксирует решение по заявке из очереди и уведомляет компанию в той же транзакции.
+// Решение записывается условно: если другой модератор успел рассмотреть заявку, возвращается CodeConflict.
+func (s *CompanyVerificationService) review(ctx context.Context, reviewerID, companyID common.UUID, seen time.Time, status profile.VerificationStatus, reason string) (*profile.CompanyVerification, error) {
+	if seen.IsZero() {
+		return nil, common.NewValidationError("invalid review", map[string]string{"updated_at": "updated_at is required"})
+	}
+	current, err := s.verifications.GetByCompany(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if current.Status != profile.VerificationPending {
+		return nil, common.NewError(common.CodeValidation, "verification is not pending review", nil)
+	}
+	if !current.UpdatedAt.Equal(seen) {
+		return nil, common.NewError(common.CodeConflict, "verification has changed since it was loaded", nil)
+	}
+	now := time.Now().UTC()
+	current.Status = status
+	current.RejectionReason = reason
+	current.ReviewedBy = &reviewerID
+	current.ReviewedAt = &now
+	var saved *profile.CompanyVerification
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		saved, err = s.verifications.Review(ctx, *current)
+		if err != nil {
+			return err
+		}
+		if err := s.analytics.Create(ctx, analytics.Event{Name: "company.verification." + verificationEventSuffix(status), UserID: &reviewerID, Payload: analyticsPayload(ctx, map[string]string{"company_id": companyID.String()})}); err != nil {
+			return err
+		}
+		return s.notifier.Notify(ctx, notification.Notification{
+			UserID:  companyID,
+			Type:    notification.TypeCompanyVerification,
+			Text:    verificationNotificationText(status, current.LegalName, reason),
+			Payload: map[string]string{"company_id": companyID.String(), "status": string(status)},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+func verificationEventSuffix(status profile.VerificationStatus) string {
+	if status == profile.VerificationVerified {
+		return "approved"
+	}
+	return "rejected"
+}
+
+func normalizeVerificationRequest(req VerificationRequest) (VerificationRequest, error) {
+	fields := map[string]string{}
+	req.LegalName = strings.TrimSpace(req.LegalName)
+	req.INN = strings.TrimSpace(req.INN)
+	req.OGRN = strings.TrimSpace(req.OGRN)
+	switch {
+	case req.LegalName == "":
+		fields["legal_name"] = "legal_name is required"
+	case len([]rune(req.LegalName)) > verificationLegalNameMaxLength:
+		fields["legal_name"] = "legal_name is too long"
+	}
+	if !validINN(req.INN) {
+		fields["inn"] = "must be a valid 10 or 12 digit INN"
+	}
+	if !validOGRN(req.OGRN) {
+		fields["ogrn"] = "must be a valid 13 digit OGRN or 15 digit OGRNIP"
+	}
+	// ИНН организации (10 цифр) идёт в паре с ОГРН, ИНН предпринимателя (12 цифр) — с ОГРНИП
+	if len(fields) == 0 && (len(req.INN) == 10) != (len(req.OGRN) == 13) {
+		fields["ogrn"] = "does not match the INN type"
+	}
+	if len(fields) > 0 {
+		return req, common.NewValidationError("invalid verification request", fields)
+	}
+	return req, nil
+}
+
+var (
+	innWeights10 = []int{2, 4, 10, 3, 5, 9, 4, 6, 8}
+	innWeights11 = []int{7, 2, 4, 10, 3, 5, 9, 4, 6, 8}
+	innWeights12 = []int{3, 7, 2, 4, 10, 3, 5, 9, 4, 6, 8}
+)
+
+// validINN проверяет длину и контрольные цифры ИНН.
+func validINN(value string) bool {
+	digits, ok := parseDigits(value)
+	if !ok {
+		return false
+	}
+	switch len(digits) {
+	case 10:
+		return innCheckDigit(digits, innWeights10) == digits[9]
+	case 12:
+		return innCheckDigit(digits, innWeights11) == digits[10] && innCheckDigit(digits, innWeights12) == digits[11]
+	default:
+		return false
+	}
+}
+
+func innCheckDigit(digits, weights []int) int {
+	sum := 0
+	for i, weight := range weights {
+		sum += digits[i] * weight
+	}
+	return sum % 11 % 10
+}
+
+// validOGRN проверяет контрольную цифру ОГРН (остаток от деления на 11) и ОГРНИП (на 13).
+func validOGRN(value string) bool {
+	digits, ok := parseDigits(value)
+	if !ok {
+		return false
+	}
+	var divisor int
+	switch len(digits) {
+	case 13:
+		divisor = 11
+	case 15:
+		divisor = 13
+	default:
+		return false
+	}
+	remainder := 0
+	for _, digit := range digits[:len(digits)-1] {
+		remainder = (remainder*10 + digit) % divisor
+	}
+	return remainder%10 == digits[len(digits)-1]
+}
+
+func parseDigits(value string) ([]int, bool) {
+	digits := make([]int, 0, len(value))
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return nil, false
+		}
+		digits = append(digits, int(r-'0'))
+	}
+	return digits, len(digits) > 0
+}
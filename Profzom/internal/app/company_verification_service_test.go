@@ -0,0 +1,215 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/notification"
+	"profzom/internal/domain/profile"
+	"profzom/internal/domain/vacancy"
+)
+
+// staleVerificationRepo отдаёт заявку такой, какой она была при первом чтении, как при гонке двух модераторов.
+type staleVerificationRepo struct {
+	*fakeVerificationRepo
+	snapshot *profile.CompanyVerification
+}
+
+func (r *staleVerificationRepo) GetByCompany(ctx context.Context, companyID common.UUID) (*profile.CompanyVerification, error) {
+	if r.snapshot == nil {
+		current, err := r.fakeVerificationRepo.GetByCompany(ctx, companyID)
+		if err != nil {
+			return nil, err
+		}
+		r.snapshot = current
+	}
+	copy := *r.snapshot
+	return &copy, nil
+}
+
+func TestValidINNAndOGRN(t *testing.T) {
+	for _, value := range []string{"7707083893", "500100732259"} {
+		if !validINN(value) {
+			t.Fatalf("expected %s to be a valid INN", value)
+		}
+	}
+	for _, value := range []string{"7707083894", "770708389", "77070838ab", ""} {
+		if validINN(value) {
+			t.Fatalf("expected %q to be an invalid INN", value)
+		}
+	}
+	for _, value := range []string{"1027700132195", "304500116000157"} {
+		if !validOGRN(value) {
+			t.Fatalf("expected %s to be a valid OGRN", value)
+		}
+	}
+	if validOGRN("1027700132196") {
+		t.Fatal("expected OGRN with wrong check digit to be invalid")
+	}
+}
+
+func TestCompanyVerificationSubmit_ValidatesRequisites(t *testing.T) {
+	f := newTestEnv(t)
+	_, err := f.verificationSvc.Submit(context.Background(), f.companyID, VerificationRequest{LegalName: "ООО Акме", INN: "7707083893", OGRN: "304500116000157"})
+	if !common.Is(err, common.CodeValidation) {
+		t.Fatalf("expected validation error for INN and OGRNIP mismatch, got %v", err)
+	}
+	if _, err := f.verificationSvc.Submit(context.Background(), common.NewUUID(), VerificationRequest{LegalName: "ООО Акме", INN: "7707083893", OGRN: "1027700132195"}); !common.Is(err, common.CodeValidation) {
+		t.Fatalf("expected validation error without company profile, got %v", err)
+	}
+}
+
+func TestCompanyVerification_ReviewFlow(t *testing.T) {
+	f := newTestEnv(t)
+	ctx := context.Background()
+	adminID := common.NewUUID()
+
+	current, err := f.verificationSvc.Get(ctx, f.companyID)
+	if err != nil || current.Status != profile.VerificationUnverified {
+		t.Fatalf("expected unverified status, got %+v, %v", current, err)
+	}
+	if _, err := f.verificationSvc.Approve(ctx, adminID, f.companyID, time.Now()); !common.Is(err, common.CodeNotFound) {
+		t.Fatalf("expected not found before submission, got %v", err)
+	}
+	submitted, err := f.verificationSvc.Submit(ctx, f.companyID, VerificationRequest{LegalName: " ООО Акме ", INN: "7707083893", OGRN: "1027700132195"})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if submitted.Status != profile.VerificationPending || submitted.LegalName != "ООО Акме" || submitted.SubmittedAt == nil {
+		t.Fatalf("expected pending verification, got %+v", submitted)
+	}
+	if _, err := f.verificationSvc.Reject(ctx, adminID, f.companyID, submitted.UpdatedAt, " "); !common.Is(err, common.CodeValidation) {
+		t.Fatalf("expected reason to be required, got %v", err)
+	}
+	rejected, err := f.verificationSvc.Reject(ctx, adminID, f.companyID, submitted.UpdatedAt, "OGRN belongs to another company")
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if rejected.Status != profile.VerificationRejected || rejected.ReviewedBy == nil || *rejected.ReviewedBy != adminID {
+		t.Fatalf("expected rejected verification with reviewer, got %+v", rejected)
+	}
+	if _, err := f.verificationSvc.Approve(ctx, adminID, f.companyID, rejected.UpdatedAt); !common.Is(err, common.CodeValidation) {
+		t.Fatalf("expected rejected verification not to be approvable, got %v", err)
+	}
+
+	resubmitted, err := f.verificationSvc.Submit(ctx, f.companyID, VerificationRequest{LegalName: "ООО Акме", INN: "7707083893", OGRN: "1027700132195"})
+	if err != nil {
+		t.Fatalf("expected resubmission after rejection, got %v", err)
+	}
+	if resubmitted.RejectionReason != "" || resubmitted.ReviewedBy != nil {
+		t.Fatalf("expected review fields to be reset, got %+v", resubmitted)
+	}
+	if _, err := f.verificationSvc.Approve(ctx, adminID, f.companyID, resubmitted.UpdatedAt); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if _, err := f.verificationSvc.Submit(ctx, f.companyID, VerificationRequest{LegalName: "ООО Акме", INN: "7707083893", OGRN: "1027700132195"}); !common.Is(err, common.CodeConflict) {
+		t.Fatalf("expected conflict for verified company, got %v", err)
+	}
+	if len(f.notifier.sent) != 2 || f.notifier.sent[0].Type != notification.TypeCompanyVerification || f.notifier.sent[1].Payload["status"] != string(profile.VerificationVerified) {
+		t.Fatalf("expected rejection and approval notifications, got %+v", f.notifier.sent)
+	}
+}
+
+func TestCompanyVerificationReview_ConflictOnConcurrentDecision(t *testing.T) {
+	f := newTestEnv(t)
+	ctx := context.Background()
+	submitted, err := f.verificationSvc.Submit(ctx, f.companyID, VerificationRequest{LegalName: "ООО Акме", INN: "7707083893", OGRN: "1027700132195"})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	stale := &staleVerificationRepo{fakeVerificationRepo: f.verifications}
+	first := NewCompanyVerificationService(stale, nil, noopAnalyticsRepo{}, directUnitOfWork{}, f.notifier)
+	second := NewCompanyVerificationService(stale, nil, noopAnalyticsRepo{}, directUnitOfWork{}, f.notifier)
+
+	if _, err := first.Approve(ctx, common.NewUUID(), f.companyID, submitted.UpdatedAt); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if _, err := second.Reject(ctx, common.NewUUID(), f.companyID, submitted.UpdatedAt, "documents are fake"); !common.Is(err, common.CodeConflict) {
+		t.Fatalf("expected conflict for second decision, got %v", err)
+	}
+	if stored := f.verifications.items[f.companyID]; stored.Status != profile.VerificationVerified {
+		t.Fatalf("expected first decision to stay, got %q", stored.Status)
+	}
+	if len(f.notifier.sent) != 1 {
+		t.Fatalf("expected single notification, got %+v", f.notifier.sent)
+	}
+}
+
+func TestCompanyVerificationReview_ConflictWhenRequisitesChanged(t *testing.T) {
+	f := newTestEnv(t)
+	ctx := context.Background()
+	seen, err := f.verificationSvc.Submit(ctx, f.companyID, VerificationRequest{LegalName: "ООО Акме", INN: "7707083893", OGRN: "1027700132195"})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if _, err := f.verificationSvc.Submit(ctx, f.companyID, VerificationRequest{LegalName: "ИП Иванов", INN: "500100732259", OGRN: "304500116000157"}); err != nil {
+		t.Fatalf("expected pending request to be editable, got %v", err)
+	}
+	if _, err := f.verificationSvc.Approve(ctx, common.NewUUID(), f.companyID, seen.UpdatedAt); !common.Is(err, common.CodeConflict) {
+		t.Fatalf("expected conflict for outdated requisites, got %v", err)
+	}
+	if stored := f.verifications.items[f.companyID]; stored.Status != profile.VerificationPending || stored.INN != "500100732259" {
+		t.Fatalf("expected corrected request to stay pending, got %+v", stored)
+	}
+	if len(f.notifier.sent) != 0 {
+		t.Fatalf("expected no notifications, got %+v", f.notifier.sent)
+	}
+}
+
+func TestCompanyVerificationSubmit_KeepsVerifiedOnStaleRead(t *testing.T) {
+	f := newTestEnv(t)
+	ctx := context.Background()
+	submitted, err := f.verificationSvc.Submit(ctx, f.companyID, VerificationRequest{LegalName: "ООО Акме", INN: "7707083893", OGRN: "1027700132195"})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	stale := &staleVerificationRepo{fakeVerificationRepo: f.verifications}
+	if _, err := stale.GetByCompany(ctx, f.companyID); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if _, err := f.verificationSvc.Approve(ctx, common.NewUUID(), f.companyID, submitted.UpdatedAt); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	resubmit := NewCompanyVerificationService(stale, f.companies, noopAnalyticsRepo{}, directUnitOfWork{}, f.notifier)
+	if _, err := resubmit.Submit(ctx, f.companyID, VerificationRequest{LegalName: "ИП Иванов", INN: "500100732259", OGRN: "304500116000157"}); !common.Is(err, common.CodeConflict) {
+		t.Fatalf("expected conflict for verified company, got %v", err)
+	}
+	if stored := f.verifications.items[f.companyID]; stored.Status != profile.VerificationVerified || stored.INN != "7707083893" {
+		t.Fatalf("expected verified requisites to stay, got %+v", stored)
+	}
+}
+
+func TestVacancyServicePublish_RequiresVerification(t *testing.T) {
+	repo := newFakeVacancyRepo()
+	companyID := common.NewUUID()
+	companies := &fakeCompanyRepo{profiles: map[common.UUID]*profile.CompanyProfile{companyID: {
+		UserID: companyID, Name: "Acme", Industry: "IT", Description: "Software", ContactName: "Anna", ContactEmail: "hr@acme.ru",
+	}}}
+	verifications := newFakeVerificationRepo()
+	service := NewVacancyService(repo, companies, noopAnalyticsRepo{}, directUnitOfWork{}).RequireVerification(verifications)
+	salary := 50000
+	draft, _ := repo.Create(context.Background(), vacancy.Vacancy{
+		CompanyID: companyID, Title: "Go intern", Type: "internship", Description: "Backend", Requirements: []string{"Go"},
+		Conditions: []string{"Remote"}, SalaryMin: &salary, Currency: "RUB", Period: vacancy.SalaryPeriodMonth, Location: "Moscow", Status: vacancy.StatusDraft,
+	})
+
+	if _, err := service.Publish(context.Background(), companyID, draft.ID); !common.Is(err, common.CodeValidation) {
+		t.Fatalf("expected validation error for unverified company, got %v", err)
+	}
+	if _, err := service.Create(context.Background(), vacancy.Vacancy{
+		CompanyID: companyID, Title: "Go intern", Type: "internship", Description: "Backend", Requirements: []string{"Go"},
+		Conditions: []string{"Remote"}, IsNegotiable: true, Location: "Moscow",
+	}); !common.Is(err, common.CodeValidation) {
+		t.Fatalf("expected create-and-publish to require verification, got %v", err)
+	}
+	verifications.items[companyID] = profile.CompanyVerification{CompanyID: companyID, Status: profile.VerificationVerified}
+	published, err := service.Publish(context.Background(), companyID, draft.ID)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if published.Status != vacancy.StatusPublished {
+		t.Fatalf("expected published vacancy, got %q", published.Status)
+	}
+}
@@ -0,0 +1,1079 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/admin"
+	"profzom/internal/domain/analytics"
+	"profzom/internal/domain/application"
+	"profzom/internal/domain/auth"
+	"profzom/internal/domain/interview"
+	"profzom/internal/domain/invitation"
+	"profzom/internal/domain/message"
+	"profzom/internal/domain/moderation"
+	"profzom/internal/domain/notification"
+	"profzom/internal/domain/profile"
+	"profzom/internal/domain/user"
+	"profzom/internal/domain/vacancy"
+)
+
+type noopAnalyticsRepo struct{}
+
+func (noopAnalyticsRepo) Create(ctx context.Context, event analytics.Event) error {
+	return nil
+}
+
+// directUnitOfWork выполняет fn без транзакции: фейковые репозитории её не поддерживают.
+type directUnitOfWork struct{}
+
+func (directUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type recordingNotifier struct {
+	mu   sync.Mutex
+	sent []notification.Notification
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, notice notification.Notification) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.sent = append(n.sent, notice)
+	return nil
+}
+
+type fakeUserRepo struct {
+	mu      sync.Mutex
+	byPhone map[string]*user.User
+	byID    map[common.UUID]*user.User
+}
+
+func newFakeUserRepo() *fakeUserRepo {
+	return &fakeUserRepo{
+		byPhone: make(map[string]*user.User),
+		byID:    make(map[common.UUID]*user.User),
+	}
+}
+
+func (r *fakeUserRepo) FindByPhone(ctx context.Context, phone string) (*user.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	account := r.byPhone[phone]
+	if account == nil {
+		return nil, common.NewError(common.CodeNotFound, "user not found", nil)
+	}
+	return cloneUser(account), nil
+}
+
+func (r *fakeUserRepo) GetByID(ctx context.Context, id common.UUID) (*user.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	account := r.byID[id]
+	if account == nil {
+		return nil, common.NewError(common.CodeNotFound, "user not found", nil)
+	}
+	return cloneUser(account), nil
+}
+
+func (r *fakeUserRepo) Create(ctx context.Context, phone string) (*user.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id := common.NewUUID()
+	now := time.Now().UTC()
+	account := &user.User{ID: id, Phone: phone, CreatedAt: now, UpdatedAt: now}
+	if phone != "" {
+		r.byPhone[phone] = account
+	}
+	r.byID[id] = account
+	return cloneUser(account), nil
+}
+
+func (r *fakeUserRepo) SetRoles(ctx context.Context, userID common.UUID, roles []user.Role) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	account := r.byID[userID]
+	if account == nil {
+		return common.NewError(common.CodeNotFound, "user not found", nil)
+	}
+	account.Roles = append([]user.Role(nil), roles...)
+	return nil
+}
+
+func (r *fakeUserRepo) ListRoles(ctx context.Context, userID common.UUID) ([]user.Role, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	account := r.byID[userID]
+	if account == nil {
+		return nil, common.NewError(common.CodeNotFound, "user not found", nil)
+	}
+	return append([]user.Role(nil), account.Roles...), nil
+}
+
+func (r *fakeUserRepo) AddRole(ctx context.Context, userID common.UUID, role user.Role) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	account := r.byID[userID]
+	if account == nil {
+		return common.NewError(common.CodeNotFound, "user not found", nil)
+	}
+	for _, existing := range account.Roles {
+		if existing == role {
+			return nil
+		}
+	}
+	account.Roles = append(account.Roles, role)
+	return nil
+}
+
+func (r *fakeUserRepo) RemoveRole(ctx context.Context, userID common.UUID, role user.Role) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	account := r.byID[userID]
+	if account == nil {
+		return common.NewError(common.CodeNotFound, "user not found", nil)
+	}
+	roles := account.Roles[:0]
+	for _, existing := range account.Roles {
+		if existing != role {
+			roles = append(roles, existing)
+		}
+	}
+	account.Roles = roles
+	return nil
+}
+
+func (r *fakeUserRepo) SetBlocked(ctx context.Context, userID common.UUID, blockedAt *time.Time, reason string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	account := r.byID[userID]
+	if account == nil {
+		return common.NewError(common.CodeNotFound, "user not found", nil)
+	}
+	account.BlockedAt = blockedAt
+	account.BlockReason = reason
+	return nil
+}
+
+func (r *fakeUserRepo) IsBlocked(ctx context.Context, userID common.UUID) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	account := r.byID[userID]
+	if account == nil {
+		return false, common.NewError(common.CodeNotFound, "user not found", nil)
+	}
+	return account.BlockedAt != nil, nil
+}
+
+func (r *fakeUserRepo) Search(ctx context.Context, query user.SearchQuery) ([]user.Account, *common.Cursor, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var items []user.Account
+	for _, account := range r.byID {
+		if query.Blocked != nil && *query.Blocked != (account.BlockedAt != nil) {
+			continue
+		}
+		items = append(items, user.Account{ID: account.ID, Phone: account.Phone, Roles: account.Roles, BlockedAt: account.BlockedAt, BlockReason: account.BlockReason, CreatedAt: account.CreatedAt})
+	}
+	return items, nil, nil
+}
+
+func cloneUser(account *user.User) *user.User {
+	copy := *account
+	copy.Roles = append([]user.Role(nil), account.Roles...)
+	return &copy
+}
+
+type fakeRefreshTokenRepo struct {
+	mu     sync.Mutex
+	tokens map[string]auth.RefreshToken
+}
+
+func newFakeRefreshTokenRepo() *fakeRefreshTokenRepo {
+	return &fakeRefreshTokenRepo{tokens: make(map[string]auth.RefreshToken)}
+}
+
+func (r *fakeRefreshTokenRepo) Store(ctx context.Context, token auth.RefreshToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tokens[token.Token] = token
+	return nil
+}
+
+func (r *fakeRefreshTokenRepo) GetByToken(ctx context.Context, token string) (*auth.RefreshToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	value, ok := r.tokens[token]
+	if !ok {
+		return nil, common.NewError(common.CodeNotFound, "refresh token not found", nil)
+	}
+	copy := value
+	return &copy, nil
+}
+
+func (r *fakeRefreshTokenRepo) Revoke(ctx context.Context, token string, revokedAtUnix int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	value, ok := r.tokens[token]
+	if !ok {
+		return common.NewError(common.CodeNotFound, "refresh token not found", nil)
+	}
+	revokedAt := time.Unix(revokedAtUnix, 0).UTC()
+	value.RevokedAt = &revokedAt
+	r.tokens[token] = value
+	return nil
+}
+
+func (r *fakeRefreshTokenRepo) RevokeAll(ctx context.Context, userID common.UUID, revokedAtUnix int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	revokedAt := time.Unix(revokedAtUnix, 0).UTC()
+	for key, value := range r.tokens {
+		if value.UserID == userID {
+			value.RevokedAt = &revokedAt
+			r.tokens[key] = value
+		}
+	}
+	return nil
+}
+
+type fakeStudentRepo struct {
+	profiles  map[common.UUID]*profile.StudentProfile
+	lastQuery profile.CandidateQuery
+}
+
+func (r *fakeStudentRepo) GetByUserID(ctx context.Context, userID common.UUID) (*profile.StudentProfile, error) {
+	p, ok := r.profiles[userID]
+	if !ok {
+		return nil, common.NewError(common.CodeNotFound, "student profile not found", nil)
+	}
+	copy := *p
+	return &copy, nil
+}
+
+func (r *fakeStudentRepo) Search(ctx context.Context, query profile.CandidateQuery) ([]profile.StudentProfile, *common.Cursor, error) {
+	r.lastQuery = query
+	var items []profile.StudentProfile
+	for _, p := range r.profiles {
+		if !p.VisibleToCompanies {
+			continue
+		}
+		tags := map[string]bool{}
+		for _, skill := range profile.NormalizeSkills(p.Skills) {
+			tags[skill] = true
+		}
+		matched := 0
+		for _, skill := range query.Skills {
+			if tags[skill] {
+				matched++
+			}
+		}
+		if len(query.Skills) > 0 && (matched == 0 || query.SkillsMode == profile.SkillsMatchAll && matched < len(query.Skills)) {
+			continue
+		}
+		items = append(items, *p)
+	}
+	return items, nil, nil
+}
+
+func (r *fakeStudentRepo) Upsert(ctx context.Context, p profile.StudentProfile) (*profile.StudentProfile, error) {
+	r.profiles[p.UserID] = &p
+	return &p, nil
+}
+
+func completeStudentProfile(userID common.UUID) profile.StudentProfile {
+	return profile.StudentProfile{UserID: userID, Name: "Анна Смирнова", University: "МГУ", Course: 3, Specialty: "Backend", Skills: []string{"go"}, About: "Backend"}
+}
+
+type fakeCompanyRepo struct {
+	profiles map[common.UUID]*profile.CompanyProfile
+}
+
+func (r *fakeCompanyRepo) GetByUserID(ctx context.Context, userID common.UUID) (*profile.CompanyProfile, error) {
+	p, ok := r.profiles[userID]
+	if !ok {
+		return nil, common.NewError(common.CodeNotFound, "company profile not found", nil)
+	}
+	copy := *p
+	return &copy, nil
+}
+
+func (r *fakeCompanyRepo) Upsert(ctx context.Context, p profile.CompanyProfile) (*profile.CompanyProfile, error) {
+	r.profiles[p.UserID] = &p
+	return &p, nil
+}
+
+type fakeVerificationRepo struct {
+	items map[common.UUID]profile.CompanyVerification
+}
+
+func newFakeVerificationRepo() *fakeVerificationRepo {
+	return &fakeVerificationRepo{items: map[common.UUID]profile.CompanyVerification{}}
+}
+
+func (r *fakeVerificationRepo) GetByCompany(ctx context.Context, companyID common.UUID) (*profile.CompanyVerification, error) {
+	v, ok := r.items[companyID]
+	if !ok {
+		return nil, common.NewError(common.CodeNotFound, "company verification not found", nil)
+	}
+	return &v, nil
+}
+
+func (r *fakeVerificationRepo) Save(ctx context.Context, v profile.CompanyVerification) (*profile.CompanyVerification, error) {
+	if stored, ok := r.items[v.CompanyID]; ok && stored.Status == profile.VerificationVerified {
+		return nil, common.NewError(common.CodeConflict, "company is already verified", nil)
+	}
+	v.UpdatedAt = time.Now().UTC()
+	r.items[v.CompanyID] = v
+	return &v, nil
+}
+
+func (r *fakeVerificationRepo) Review(ctx context.Context, v profile.CompanyVerification) (*profile.CompanyVerification, error) {
+	if stored, ok := r.items[v.CompanyID]; !ok || stored.Status != profile.VerificationPending || !stored.UpdatedAt.Equal(v.UpdatedAt) {
+		return nil, common.NewError(common.CodeConflict, "verification is no longer pending review", nil)
+	}
+	v.UpdatedAt = time.Now().UTC()
+	r.items[v.CompanyID] = v
+	return &v, nil
+}
+
+func (r *fakeVerificationRepo) ListByStatus(ctx context.Context, status profile.VerificationStatus, page common.PageRequest) ([]profile.CompanyVerification, *common.Cursor, error) {
+	var items []profile.CompanyVerification
+	for _, v := range r.items {
+		if v.Status == status {
+			items = append(items, v)
+		}
+	}
+	return items, nil, nil
+}
+
+type fakeVacancyRepo struct {
+	mu    sync.Mutex
+	items map[common.UUID]*vacancy.Vacancy
+}
+
+func newFakeVacancyRepo() *fakeVacancyRepo {
+	return &fakeVacancyRepo{items: make(map[common.UUID]*vacancy.Vacancy)}
+}
+
+func (r *fakeVacancyRepo) Create(ctx context.Context, v vacancy.Vacancy) (*vacancy.Vacancy, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	v.ID = common.NewUUID()
+	v.CreatedAt = time.Now().UTC()
+	v.UpdatedAt = v.CreatedAt
+	stored := v
+	r.items[v.ID] = &stored
+	return &v, nil
+}
+
+func (r *fakeVacancyRepo) Update(ctx context.Context, v vacancy.Vacancy) (*vacancy.Vacancy, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	v.UpdatedAt = time.Now().UTC()
+	stored := v
+	r.items[v.ID] = &stored
+	return &v, nil
+}
+
+func (r *fakeVacancyRepo) GetByID(ctx context.Context, id common.UUID) (*vacancy.Vacancy, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	v, ok := r.items[id]
+	if !ok {
+		return nil, common.NewError(common.CodeNotFound, "vacancy not found", nil)
+	}
+	copy := *v
+	return &copy, nil
+}
+
+func (r *fakeVacancyRepo) ListPublished(ctx context.Context, page common.PageRequest) ([]vacancy.Vacancy, *common.Cursor, error) {
+	return nil, nil, nil
+}
+
+func (r *fakeVacancyRepo) Search(ctx context.Context, query vacancy.SearchQuery) ([]vacancy.Vacancy, *common.Cursor, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var items []vacancy.Vacancy
+	for _, v := range r.items {
+		if v.Status == vacancy.StatusPublished {
+			items = append(items, *v)
+		}
+	}
+	return items, nil, nil
+}
+
+func (r *fakeVacancyRepo) ListByCompany(ctx context.Context, companyID common.UUID, page common.PageRequest) ([]vacancy.Vacancy, *common.Cursor, error) {
+	return nil, nil, nil
+}
+
+func (r *fakeVacancyRepo) CloseExpired(ctx context.Context, now time.Time) ([]vacancy.Vacancy, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var closed []vacancy.Vacancy
+	for _, v := range r.items {
+		if v.Status == vacancy.StatusPublished && v.ExpiresAt != nil && !v.ExpiresAt.After(now) {
+			v.Status = vacancy.StatusClosed
+			closed = append(closed, *v)
+		}
+	}
+	return closed, nil
+}
+
+type fakeApplicationRepo struct {
+	mu     sync.Mutex
+	items  map[common.UUID]*application.Application
+	events []application.StatusEvent
+}
+
+func newFakeApplicationRepo() *fakeApplicationRepo {
+	return &fakeApplicationRepo{items: make(map[common.UUID]*application.Application)}
+}
+
+func (r *fakeApplicationRepo) Create(ctx context.Context, app application.Application) (*application.Application, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	app.ID = common.NewUUID()
+	app.CreatedAt = time.Now().UTC()
+	app.UpdatedAt = app.CreatedAt
+	stored := app
+	r.items[app.ID] = &stored
+	r.events = append(r.events, application.StatusEvent{ID: common.NewUUID(), ApplicationID: app.ID, ToStatus: app.Status, ChangedBy: app.StudentID, CreatedAt: app.CreatedAt})
+	return &app, nil
+}
+
+func (r *fakeApplicationRepo) GetByID(ctx context.Context, id common.UUID) (*application.Application, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	app, ok := r.items[id]
+	if !ok {
+		return nil, common.NewError(common.CodeNotFound, "application not found", nil)
+	}
+	copy := *app
+	return &copy, nil
+}
+
+func (r *fakeApplicationRepo) ListByVacancy(ctx context.Context, vacancyID common.UUID) ([]application.Application, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var items []application.Application
+	for _, app := range r.items {
+		if app.VacancyID == vacancyID {
+			items = append(items, *app)
+		}
+	}
+	return items, nil
+}
+
+func (r *fakeApplicationRepo) ListByStudent(ctx context.Context, studentID common.UUID, page common.PageRequest) ([]application.Application, *common.Cursor, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var items []application.Application
+	for _, app := range r.items {
+		if app.StudentID == studentID {
+			items = append(items, *app)
+		}
+	}
+	return items, nil, nil
+}
+
+func (r *fakeApplicationRepo) ListByCompany(ctx context.Context, companyID common.UUID, page common.PageRequest) ([]application.Application, *common.Cursor, error) {
+	return nil, nil, nil
+}
+
+// ListPipeline фейка не фильтрует по компании: в тестах все отклики принадлежат одной.
+func (r *fakeApplicationRepo) ListPipeline(ctx context.Context, query application.PipelineQuery) ([]application.PipelineItem, *common.Cursor, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var items []application.PipelineItem
+	for _, app := range r.items {
+		if query.VacancyID != nil && app.VacancyID != *query.VacancyID {
+			continue
+		}
+		if len(query.Statuses) > 0 && !containsStatus(query.Statuses, normalizeApplicationStatus(app.Status)) {
+			continue
+		}
+		items = append(items, application.PipelineItem{Application: *app, Vacancy: application.VacancySummary{ID: app.VacancyID}})
+	}
+	return items, nil, nil
+}
+
+func (r *fakeApplicationRepo) CountByStatus(ctx context.Context, companyID common.UUID, vacancyID *common.UUID) (map[application.Status]int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	counts := make(map[application.Status]int)
+	for _, app := range r.items {
+		if vacancyID == nil || app.VacancyID == *vacancyID {
+			counts[normalizeApplicationStatus(app.Status)]++
+		}
+	}
+	return counts, nil
+}
+
+func containsStatus(statuses []application.Status, status application.Status) bool {
+	for _, item := range statuses {
+		if item == status {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *fakeApplicationRepo) UpdateStatus(ctx context.Context, event application.StatusEvent) (*application.Application, error) {
+	r.mu.Lock()
+	app, ok := r.items[event.ApplicationID]
+	if !ok {
+		r.mu.Unlock()
+		return nil, common.NewError(common.CodeNotFound, "application not found", nil)
+	}
+	if normalizeApplicationStatus(app.Status) != event.FromStatus {
+		r.mu.Unlock()
+		return nil, common.NewError(common.CodeConflict, "application status has changed", nil)
+	}
+	app.Status = event.ToStatus
+	app.Feedback = event.Feedback
+	event.ID = common.NewUUID()
+	event.CreatedAt = time.Now().UTC()
+	r.events = append(r.events, event)
+	r.mu.Unlock()
+	return r.GetByID(ctx, event.ApplicationID)
+}
+
+func (r *fakeApplicationRepo) ListStatusEvents(ctx context.Context, applicationID common.UUID) ([]application.StatusEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var items []application.StatusEvent
+	for _, event := range r.events {
+		if event.ApplicationID == applicationID {
+			items = append(items, event)
+		}
+	}
+	return items, nil
+}
+
+func (r *fakeApplicationRepo) FindByVacancyAndStudent(ctx context.Context, vacancyID, studentID common.UUID) (*application.Application, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, app := range r.items {
+		if app.VacancyID == vacancyID && app.StudentID == studentID {
+			copy := *app
+			return &copy, nil
+		}
+	}
+	return nil, common.NewError(common.CodeNotFound, "application not found", nil)
+}
+
+type fakeMessageRepo struct {
+	mu    sync.Mutex
+	items []message.Message
+	reads map[string]message.ReadState
+}
+
+func (r *fakeMessageRepo) Create(ctx context.Context, msg message.Message) (*message.Message, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	msg.ID = common.NewUUID()
+	msg.CreatedAt = time.Now().UTC()
+	// время строго растёт, чтобы порядок сообщений в тестах не зависел от случайных ID
+	if n := len(r.items); n > 0 && !msg.CreatedAt.After(r.items[n-1].CreatedAt) {
+		msg.CreatedAt = r.items[n-1].CreatedAt.Add(time.Microsecond)
+	}
+	if msg.Kind == "" {
+		msg.Kind = message.KindUser
+	}
+	r.items = append(r.items, msg)
+	return &msg, nil
+}
+
+func (r *fakeMessageRepo) GetByID(ctx context.Context, id common.UUID) (*message.Message, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, msg := range r.items {
+		if msg.ID == id {
+			return &msg, nil
+		}
+	}
+	return nil, common.NewError(common.CodeNotFound, "message not found", nil)
+}
+
+func (r *fakeMessageRepo) ListByApplication(ctx context.Context, applicationID, viewerID common.UUID, page common.PageRequest) ([]message.Message, *common.Cursor, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var items []message.Message
+	for _, msg := range r.items {
+		if msg.ApplicationID == applicationID && (msg.Moderation == "" || viewerID == "" || msg.SenderID == viewerID) {
+			items = append(items, msg)
+		}
+	}
+	return items, nil, nil
+}
+
+func (r *fakeMessageRepo) SetModeration(ctx context.Context, id common.UUID, status message.ModerationStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.items {
+		if r.items[i].ID == id {
+			r.items[i].Moderation = status
+			return nil
+		}
+	}
+	return common.NewError(common.CodeNotFound, "message not found", nil)
+}
+
+func (r *fakeMessageRepo) LatestByApplication(ctx context.Context, applicationID common.UUID) (*message.Message, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := len(r.items) - 1; i >= 0; i-- {
+		if r.items[i].ApplicationID == applicationID {
+			msg := r.items[i]
+			return &msg, nil
+		}
+	}
+	return nil, common.NewError(common.CodeNotFound, "message not found", nil)
+}
+
+func (r *fakeMessageRepo) MarkRead(ctx context.Context, applicationID, userID, messageID common.UUID) (*message.ReadState, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, msg := range r.items {
+		if msg.ID != messageID || msg.ApplicationID != applicationID {
+			continue
+		}
+		if r.reads == nil {
+			r.reads = map[string]message.ReadState{}
+		}
+		key := applicationID.String() + ":" + userID.String()
+		state, ok := r.reads[key]
+		if !ok || !state.Covers(msg) {
+			state = message.ReadState{ApplicationID: applicationID, UserID: userID, LastReadMessageID: msg.ID, LastReadCreatedAt: msg.CreatedAt, ReadAt: time.Now().UTC()}
+			r.reads[key] = state
+		}
+		return &state, nil
+	}
+	return nil, common.NewError(common.CodeNotFound, "message not found", nil)
+}
+
+func (r *fakeMessageRepo) ReadStates(ctx context.Context, applicationID common.UUID) ([]message.ReadState, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var states []message.ReadState
+	for _, state := range r.reads {
+		if state.ApplicationID == applicationID {
+			states = append(states, state)
+		}
+	}
+	return states, nil
+}
+
+func (r *fakeMessageRepo) CountUnread(ctx context.Context, userID common.UUID, applicationIDs []common.UUID) (map[common.UUID]int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	counts := map[common.UUID]int{}
+	for _, applicationID := range applicationIDs {
+		state, read := r.reads[applicationID.String()+":"+userID.String()]
+		for _, msg := range r.items {
+			if msg.ApplicationID != applicationID || msg.SenderID == userID || msg.Moderation != "" || (read && state.Covers(msg)) {
+				continue
+			}
+			counts[applicationID]++
+		}
+	}
+	return counts, nil
+}
+
+type fakeInterviewRepo struct {
+	mu    sync.Mutex
+	items map[common.UUID]*interview.Interview
+}
+
+func newFakeInterviewRepo() *fakeInterviewRepo {
+	return &fakeInterviewRepo{items: make(map[common.UUID]*interview.Interview)}
+}
+
+func (r *fakeInterviewRepo) Propose(ctx context.Context, iv interview.Interview) (*interview.Interview, error) {
+	r.mu.Lock()
+	now := time.Now().UTC()
+	if existing, ok := r.items[iv.ApplicationID]; ok {
+		iv.ID = existing.ID
+		iv.CreatedAt = existing.CreatedAt
+	} else {
+		iv.ID = common.NewUUID()
+		iv.CreatedAt = now
+	}
+	iv.UpdatedAt = now
+	iv.SelectedSlotID = nil
+	iv.CancelledBy = nil
+	slots := make([]interview.Slot, len(iv.Slots))
+	for i, slot := range iv.Slots {
+		slot.ID = common.NewUUID()
+		slots[i] = slot
+	}
+	iv.Slots = slots
+	r.items[iv.ApplicationID] = &iv
+	r.mu.Unlock()
+	return r.GetByApplication(ctx, iv.ApplicationID)
+}
+
+func (r *fakeInterviewRepo) GetByApplication(ctx context.Context, applicationID common.UUID) (*interview.Interview, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	iv, ok := r.items[applicationID]
+	if !ok {
+		return nil, common.NewError(common.CodeNotFound, "interview not found", nil)
+	}
+	copy := *iv
+	copy.Slots = append([]interview.Slot(nil), iv.Slots...)
+	return &copy, nil
+}
+
+func (r *fakeInterviewRepo) UpdateState(ctx context.Context, iv interview.Interview) (*interview.Interview, error) {
+	r.mu.Lock()
+	stored, ok := r.items[iv.ApplicationID]
+	if !ok {
+		r.mu.Unlock()
+		return nil, common.NewError(common.CodeNotFound, "interview not found", nil)
+	}
+	stored.Status = iv.Status
+	stored.SelectedSlotID = iv.SelectedSlotID
+	stored.CancelledBy = iv.CancelledBy
+	stored.UpdatedAt = time.Now().UTC()
+	r.mu.Unlock()
+	return r.GetByApplication(ctx, iv.ApplicationID)
+}
+
+type fakeInvitationRepo struct {
+	mu    sync.Mutex
+	items map[common.UUID]*invitation.Invitation
+}
+
+func newFakeInvitationRepo() *fakeInvitationRepo {
+	return &fakeInvitationRepo{items: make(map[common.UUID]*invitation.Invitation)}
+}
+
+func (r *fakeInvitationRepo) Create(ctx context.Context, inv invitation.Invitation) (*invitation.Invitation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	inv.ID = common.NewUUID()
+	inv.CreatedAt = time.Now().UTC()
+	inv.UpdatedAt = inv.CreatedAt
+	stored := inv
+	r.items[inv.ID] = &stored
+	return &inv, nil
+}
+
+func (r *fakeInvitationRepo) GetByID(ctx context.Context, id common.UUID) (*invitation.Invitation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	inv, ok := r.items[id]
+	if !ok {
+		return nil, common.NewError(common.CodeNotFound, "invitation not found", nil)
+	}
+	copy := *inv
+	return &copy, nil
+}
+
+func (r *fakeInvitationRepo) FindPending(ctx context.Context, vacancyID, studentID common.UUID) (*invitation.Invitation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, inv := range r.items {
+		if inv.VacancyID == vacancyID && inv.StudentID == studentID && inv.Status == invitation.StatusPending {
+			copy := *inv
+			return &copy, nil
+		}
+	}
+	return nil, common.NewError(common.CodeNotFound, "invitation not found", nil)
+}
+
+func (r *fakeInvitationRepo) ListByStudent(ctx context.Context, studentID common.UUID, page common.PageRequest) ([]invitation.Invitation, *common.Cursor, error) {
+	return nil, nil, nil
+}
+
+func (r *fakeInvitationRepo) ListByCompany(ctx context.Context, companyID common.UUID, page common.PageRequest) ([]invitation.Invitation, *common.Cursor, error) {
+	return nil, nil, nil
+}
+
+func (r *fakeInvitationRepo) UpdateStatus(ctx context.Context, inv invitation.Invitation) (*invitation.Invitation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.items[inv.ID]
+	if !ok || stored.Status != invitation.StatusPending {
+		return nil, common.NewError(common.CodeConflict, "invitation is no longer pending", nil)
+	}
+	now := time.Now().UTC()
+	stored.Status = inv.Status
+	stored.ApplicationID = inv.ApplicationID
+	stored.RespondedAt = &now
+	copy := *stored
+	return &copy, nil
+}
+
+type fakeModerationRepo struct {
+	items map[common.UUID]moderation.Item
+}
+
+func newFakeModerationRepo() *fakeModerationRepo {
+	return &fakeModerationRepo{items: map[common.UUID]moderation.Item{}}
+}
+
+func (r *fakeModerationRepo) Enqueue(ctx context.Context, item moderation.Item) (*moderation.Item, error) {
+	for id, existing := range r.items {
+		if existing.Status == moderation.StatusPending && existing.ContentType == item.ContentType && existing.ContentID == item.ContentID {
+			existing.Text, existing.Reasons = item.Text, item.Reasons
+			existing.UpdatedAt = time.Now().UTC()
+			r.items[id] = existing
+			return &existing, nil
+		}
+	}
+	item.ID = common.NewUUID()
+	item.Status = moderation.StatusPending
+	item.CreatedAt = time.Now().UTC()
+	item.UpdatedAt = item.CreatedAt
+	r.items[item.ID] = item
+	return &item, nil
+}
+
+func (r *fakeModerationRepo) GetByID(ctx context.Context, id common.UUID) (*moderation.Item, error) {
+	item, ok := r.items[id]
+	if !ok {
+		return nil, common.NewError(common.CodeNotFound, "moderation item not found", nil)
+	}
+	return &item, nil
+}
+
+func (r *fakeModerationRepo) Save(ctx context.Context, item moderation.Item) (*moderation.Item, error) {
+	current, ok := r.items[item.ID]
+	if !ok || current.Status != moderation.StatusPending || !current.UpdatedAt.Equal(item.UpdatedAt) {
+		return nil, common.NewError(common.CodeConflict, "moderation item was changed or reviewed concurrently", nil)
+	}
+	item.UpdatedAt = time.Now().UTC()
+	r.items[item.ID] = item
+	return &item, nil
+}
+
+func (r *fakeModerationRepo) ListByStatus(ctx context.Context, status moderation.Status, contentType moderation.ContentType, page common.PageRequest) ([]moderation.Item, *common.Cursor, error) {
+	var items []moderation.Item
+	for _, item := range r.items {
+		if item.Status == status && (contentType == "" || item.ContentType == contentType) {
+			items = append(items, item)
+		}
+	}
+	return items, nil, nil
+}
+
+// stopWordChecker задерживает текст, в котором встречается слово word.
+type stopWordChecker struct {
+	word string
+}
+
+func (c stopWordChecker) Check(ctx context.Context, content moderation.Content) (moderation.Verdict, error) {
+	if strings.Contains(strings.ToLower(content.Text), c.word) {
+		return moderation.Verdict{Hold: true, Reasons: []string{"stop word: " + c.word}}, nil
+	}
+	return moderation.Verdict{}, nil
+}
+
+type fakeReportRepo struct {
+	reports map[common.UUID]moderation.Report
+}
+
+func newFakeReportRepo() *fakeReportRepo {
+	return &fakeReportRepo{reports: map[common.UUID]moderation.Report{}}
+}
+
+func (r *fakeReportRepo) Create(ctx context.Context, report moderation.Report) (*moderation.Report, error) {
+	for _, existing := range r.reports {
+		if existing.Status == moderation.ReportOpen && existing.ReporterID == report.ReporterID && existing.TargetType == report.TargetType && existing.TargetID == report.TargetID {
+			return nil, common.NewError(common.CodeConflict, "report already submitted", nil)
+		}
+	}
+	report.ID = common.NewUUID()
+	report.Status = moderation.ReportOpen
+	r.reports[report.ID] = report
+	return &report, nil
+}
+
+func (r *fakeReportRepo) GetByID(ctx context.Context, id common.UUID) (*moderation.Report, error) {
+	report, ok := r.reports[id]
+	if !ok {
+		return nil, common.NewError(common.CodeNotFound, "report not found", nil)
+	}
+	return &report, nil
+}
+
+func (r *fakeReportRepo) Save(ctx context.Context, report moderation.Report) (*moderation.Report, error) {
+	r.reports[report.ID] = report
+	return &report, nil
+}
+
+func (r *fakeReportRepo) List(ctx context.Context, query moderation.ReportQuery) ([]moderation.Report, *common.Cursor, error) {
+	var items []moderation.Report
+	for _, report := range r.reports {
+		if report.Status == query.Status && (query.TargetType == "" || report.TargetType == query.TargetType) {
+			items = append(items, report)
+		}
+	}
+	return items, nil, nil
+}
+
+type fakeBlockListRepo struct {
+	blocked map[[2]common.UUID]bool
+}
+
+func newFakeBlockListRepo() *fakeBlockListRepo {
+	return &fakeBlockListRepo{blocked: map[[2]common.UUID]bool{}}
+}
+
+func (r *fakeBlockListRepo) Add(ctx context.Context, blockerID, blockedID common.UUID) (*user.BlockedUser, error) {
+	r.blocked[[2]common.UUID{blockerID, blockedID}] = true
+	return &user.BlockedUser{BlockerID: blockerID, BlockedID: blockedID}, nil
+}
+
+func (r *fakeBlockListRepo) Remove(ctx context.Context, blockerID, blockedID common.UUID) error {
+	key := [2]common.UUID{blockerID, blockedID}
+	if !r.blocked[key] {
+		return common.NewError(common.CodeNotFound, "blocked user not found", nil)
+	}
+	delete(r.blocked, key)
+	return nil
+}
+
+func (r *fakeBlockListRepo) List(ctx context.Context, blockerID common.UUID, page common.PageRequest) ([]user.BlockedUser, *common.Cursor, error) {
+	var items []user.BlockedUser
+	for key := range r.blocked {
+		if key[0] == blockerID {
+			items = append(items, user.BlockedUser{BlockerID: key[0], BlockedID: key[1]})
+		}
+	}
+	return items, nil, nil
+}
+
+func (r *fakeBlockListRepo) Between(ctx context.Context, a, b common.UUID) (bool, error) {
+	return r.blocked[[2]common.UUID{a, b}] || r.blocked[[2]common.UUID{b, a}], nil
+}
+
+type fakeAuditRepo struct {
+	entries []admin.AuditEntry
+}
+
+func (r *fakeAuditRepo) Create(ctx context.Context, entry admin.AuditEntry) error {
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func (r *fakeAuditRepo) List(ctx context.Context, query admin.AuditQuery) ([]admin.AuditEntry, *common.Cursor, error) {
+	return r.entries, nil, nil
+}
+
+// testEnv — общий набор фейков и сервисов для тестов пакета. Сервисы связаны так же, как в cmd/api:
+// модерация со стоп-словом casino и список блокировок подключены везде, где они есть в проде.
+// Окружение уже содержит администратора, компанию с профилем, студента с заполненным видимым профилем,
+// опубликованную вакансию компании и отклик студента на неё.
+type testEnv struct {
+	users         *fakeUserRepo
+	refresh       *fakeRefreshTokenRepo
+	students      *fakeStudentRepo
+	companies     *fakeCompanyRepo
+	verifications *fakeVerificationRepo
+	vacancies     *fakeVacancyRepo
+	applications  *fakeApplicationRepo
+	messages      *fakeMessageRepo
+	interviews    *fakeInterviewRepo
+	invitations   *fakeInvitationRepo
+	queue         *fakeModerationRepo
+	reports       *fakeReportRepo
+	blocks        *fakeBlockListRepo
+	audit         *fakeAuditRepo
+	notifier      *recordingNotifier
+
+	profileSvc      *ProfileService
+	verificationSvc *CompanyVerificationService
+	vacancySvc      *VacancyService
+	applicationSvc  *ApplicationService
+	messageSvc      *MessageService
+	interviewSvc    *InterviewService
+	invitationSvc   *InvitationService
+	adminSvc        *AdminService
+	moderationSvc   *ModerationService
+	reportSvc       *ReportService
+	blockListSvc    *BlockListService
+
+	adminID     common.UUID
+	companyID   common.UUID
+	studentID   common.UUID
+	application *application.Application
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	ctx := context.Background()
+	e := &testEnv{
+		users:         newFakeUserRepo(),
+		refresh:       newFakeRefreshTokenRepo(),
+		students:      &fakeStudentRepo{profiles: map[common.UUID]*profile.StudentProfile{}},
+		companies:     &fakeCompanyRepo{profiles: map[common.UUID]*profile.CompanyProfile{}},
+		verifications: newFakeVerificationRepo(),
+		vacancies:     newFakeVacancyRepo(),
+		applications:  newFakeApplicationRepo(),
+		messages:      &fakeMessageRepo{},
+		interviews:    newFakeInterviewRepo(),
+		invitations:   newFakeInvitationRepo(),
+		queue:         newFakeModerationRepo(),
+		reports:       newFakeReportRepo(),
+		blocks:        newFakeBlockListRepo(),
+		audit:         &fakeAuditRepo{},
+		notifier:      &recordingNotifier{},
+	}
+	checker := stopWordChecker{word: "casino"}
+	noop, tx := noopAnalyticsRepo{}, directUnitOfWork{}
+	e.profileSvc = NewProfileService(e.students, e.companies, nil, noop, tx)
+	e.verificationSvc = NewCompanyVerificationService(e.verifications, e.companies, noop, tx, e.notifier)
+	e.vacancySvc = NewVacancyServiceWithNotifier(e.vacancies, e.companies, noop, tx, e.applications, e.notifier).WithModeration(checker, e.queue)
+	e.applicationSvc = NewApplicationServiceWithNotifier(e.applications, e.vacancies, e.students, e.messages, noop, tx, e.notifier).WithBlockList(e.blocks)
+	e.messageSvc = NewMessageServiceWithNotifier(e.messages, e.applications, e.vacancies, noop, tx, e.notifier).WithModeration(checker, e.queue).WithBlockList(e.blocks)
+	e.interviewSvc = NewInterviewService(e.interviews, e.applications, e.vacancies, noop, tx).WithApplicationService(e.applicationSvc)
+	e.invitationSvc = NewInvitationService(e.invitations, e.applications, e.vacancies, e.students, e.messages, noop, tx, e.notifier).WithModeration(checker).WithBlockList(e.blocks)
+	e.adminSvc = NewAdminService(e.users, e.refresh, e.vacancySvc, e.applications, nil, e.audit, tx)
+	e.moderationSvc = NewModerationService(e.queue, e.reports, e.vacancySvc, e.messageSvc, e.audit, tx)
+	e.reportSvc = NewReportService(e.reports, e.vacancies, e.companies, e.messages, e.applications)
+	e.blockListSvc = NewBlockListService(e.blocks)
+
+	adminAccount, _ := e.users.Create(ctx, "+70000000000")
+	_ = e.users.AddRole(ctx, adminAccount.ID, user.RoleAdmin)
+	e.adminID = adminAccount.ID
+	e.companyID = common.NewUUID()
+	e.companies.profiles[e.companyID] = &profile.CompanyProfile{UserID: e.companyID, Name: "Acme"}
+	e.studentID = common.NewUUID()
+	student := completeStudentProfile(e.studentID)
+	student.VisibleToCompanies = true
+	e.students.profiles[e.studentID] = &student
+	vacancyID := e.publishedVacancy(t)
+	e.application, _ = e.applications.Create(ctx, application.Application{VacancyID: vacancyID, StudentID: e.studentID, Status: application.StatusApplied})
+	return e
+}
+
+// publishedVacancy добавляет компании окружения ещё одну опубликованную вакансию без откликов.
+func (e *testEnv) publishedVacancy(t *testing.T) common.UUID {
+	t.Helper()
+	created, err := e.vacancies.Create(context.Background(), vacancy.Vacancy{CompanyID: e.companyID, Title: "Go intern", Status: vacancy.StatusPublished})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	return created.ID
+}
+
+// pendingItem находит запись очереди модерации, которая ждёт решения по contentID.
+func (e *testEnv) pendingItem(t *testing.T, contentID common.UUID) moderation.Item {
+	t.Helper()
+	items, _, _ := e.queue.ListByStatus(context.Background(), moderation.StatusPending, "", common.PageRequest{})
+	for _, item := range items {
+		if item.ContentID == contentID {
+			return item
+		}
+	}
+	t.Fatalf("expected pending moderation item for %s, got %+v", contentID, items)
+	return moderation.Item{}
+}
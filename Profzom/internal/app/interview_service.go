@@ -0,0 +1,305 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"profzom/internal/calendar"
+	"profzom/internal/common"
+	"profzom/internal/domain/analytics"
+	"profzom/internal/domain/application"
+	"profzom/internal/domain/interview"
+	"profzom/internal/domain/vacancy"
+)
+
+const (
+	interviewMaxSlots       = 10
+	interviewMaxDuration    = 8 * time.Hour
+	interviewLocationMaxLen = 500
+)
+
+type InterviewService struct {
+	interviews   interview.Repository
+	applications application.Repository
+	vacancies    vacancy.Repository
+	analytics    analytics.Repository
+	tx           UnitOfWork
+	statuses     *ApplicationService
+}
+
+// InterviewProposal — предложение собеседования: набор слотов и общие для них формат, место и часовой пояс.
+type InterviewProposal struct {
+	Slots    []interview.Slot
+	Format   interview.Format
+	Location string
+	Timezone string
+}
+
+func NewInterviewService(interviews interview.Repository, applications application.Repository, vacancies vacancy.Repository, analytics analytics.Repository, tx UnitOfWork) *InterviewService {
+	return &InterviewService{interviews: interviews, applications: applications, vacancies: vacancies, analytics: analytics, tx: tx}
+}
+
+// WithApplicationService подключает смену статуса отклика, через которую Invite переводит отклик в invited.
+func (s *InterviewService) WithApplicationService(statuses *ApplicationService) *InterviewService {
+	s.statuses = statuses
+	return s
+}
+
+// Invite приглашает студента и сразу предлагает слоты собеседования. Смена статуса, уведомление студента
+// и предложение пишутся в одной транзакции: если слоты не сохранились, отклик остаётся в прежнем статусе.
+func (s *InterviewService) Invite(ctx context.Context, applicationID, companyID common.UUID, feedback string, proposal InterviewProposal) (*application.Application, *interview.Interview, error) {
+	if s.statuses == nil {
+		return nil, nil, common.NewError(common.CodeInternal, "application service is not configured", nil)
+	}
+	proposal, err := s.NormalizeProposal(proposal)
+	if err != nil {
+		return nil, nil, err
+	}
+	var updated *application.Application
+	var stored *interview.Interview
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		updated, err = s.statuses.UpdateStatus(ctx, applicationID, application.StatusInvited, feedback, companyID)
+		if err != nil {
+			return err
+		}
+		stored, err = s.Propose(ctx, applicationID, companyID, proposal)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return updated, stored, nil
+}
+
+// Propose предлагает слоты собеседования. Повторный вызов (любой стороной) переносит собеседование:
+// прежние слоты заменяются, выбор сбрасывается, и выбирать слот снова должна другая сторона.
+func (s *InterviewService) Propose(ctx context.Context, applicationID, userID common.UUID, proposal InterviewProposal) (*interview.Interview, error) {
+	app, _, err := s.participantApplication(ctx, applicationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if app.Status != application.StatusInvited {
+		return nil, common.NewError(common.CodeValidation, "interview requires invited application", nil)
+	}
+	proposal, err = s.NormalizeProposal(proposal)
+	if err != nil {
+		return nil, err
+	}
+	event := "interview.proposed"
+	if _, err := s.interviews.GetByApplication(ctx, applicationID); err == nil {
+		event = "interview.rescheduled"
+	} else if !common.Is(err, common.CodeNotFound) {
+		return nil, err
+	}
+	var stored *interview.Interview
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		stored, err = s.interviews.Propose(ctx, interview.Interview{
+			ApplicationID: applicationID,
+			Status:        interview.StatusProposed,
+			Format:        proposal.Format,
+			Location:      proposal.Location,
+			Timezone:      proposal.Timezone,
+			ProposedBy:    userID,
+			Slots:         proposal.Slots,
+		})
+		if err != nil {
+			return err
+		}
+		return s.analytics.Create(ctx, analytics.Event{Name: event, UserID: &userID, Payload: analyticsPayload(ctx, map[string]string{"application_id": applicationID.String(), "interview_id": stored.ID.String(), "slots": fmt.Sprint(len(stored.Slots))})})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+// NormalizeProposal проверяет предложение и приводит слоты к UTC.
+func (s *InterviewService) NormalizeProposal(proposal InterviewProposal) (InterviewProposal, error) {
+	fields := map[string]string{}
+	proposal.Format = interview.Format(strings.ToLower(strings.TrimSpace(string(proposal.Format))))
+	proposal.Location = strings.TrimSpace(proposal.Location)
+	proposal.Timezone = strings.TrimSpace(proposal.Timezone)
+	if proposal.Timezone == "" {
+		proposal.Timezone = "UTC"
+	}
+	switch proposal.Format {
+	case interview.FormatOnline, interview.FormatOffline:
+	default:
+		fields["format"] = "must be online or offline"
+	}
+	switch {
+	case proposal.Location == "" && proposal.Format == interview.FormatOnline:
+		fields["location"] = "meeting link is required"
+	case proposal.Location == "":
+		fields["location"] = "address is required"
+	case len(proposal.Location) > interviewLocationMaxLen:
+		fields["location"] = "too long"
+	case proposal.Format == interview.FormatOnline && !isHTTPLink(proposal.Location):
+		fields["location"] = "must be an http(s) link"
+	}
+	if _, err := time.LoadLocation(proposal.Timezone); err != nil {
+		fields["timezone"] = "unknown timezone"
+	}
+	now := time.Now().UTC()
+	switch {
+	case len(proposal.Slots) == 0:
+		fields["slots"] = "at least one slot is required"
+	case len(proposal.Slots) > interviewMaxSlots:
+		fields["slots"] = fmt.Sprintf("at most %d slots are allowed", interviewMaxSlots)
+	}
+	for i, slot := range proposal.Slots {
+		key := fmt.Sprintf("slots[%d]", i)
+		switch {
+		case slot.StartsAt.IsZero() || slot.EndsAt.IsZero():
+			fields[key] = "starts_at and ends_at are required"
+		case !slot.EndsAt.After(slot.StartsAt):
+			fields[key] = "ends_at must be after starts_at"
+		case slot.EndsAt.Sub(slot.StartsAt) > interviewMaxDuration:
+			fields[key] = "slot is too long"
+		case !slot.StartsAt.After(now):
+			fields[key] = "slot must be in the future"
+		}
+		proposal.Slots[i] = interview.Slot{StartsAt: slot.StartsAt.UTC(), EndsAt: slot.EndsAt.UTC()}
+	}
+	if len(fields) > 0 {
+		return proposal, common.NewValidationError("invalid interview proposal", fields)
+	}
+	return proposal, nil
+}
+
+// Select фиксирует выбранный слот; выбирает сторона, которая слоты не предлагала.
+func (s *InterviewService) Select(ctx context.Context, applicationID, userID, slotID common.UUID) (*interview.Interview, error) {
+	app, _, err := s.participantApplication(ctx, applicationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if app.Status != application.StatusInvited {
+		return nil, common.NewError(common.CodeValidation, "interview requires invited application", nil)
+	}
+	iv, err := s.interviews.GetByApplication(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	if iv.Status != interview.StatusProposed {
+		return nil, common.NewError(common.CodeValidation, "interview slot is already chosen or cancelled", nil)
+	}
+	if iv.ProposedBy == userID {
+		return nil, common.NewError(common.CodeForbidden, "slot must be chosen by the other side", nil)
+	}
+	var slot *interview.Slot
+	for i := range iv.Slots {
+		if iv.Slots[i].ID == slotID {
+			slot = &iv.Slots[i]
+			break
+		}
+	}
+	if slot == nil {
+		return nil, common.NewValidationError("invalid slot", map[string]string{"slot_id": "not one of the proposed slots"})
+	}
+	if !slot.StartsAt.After(time.Now().UTC()) {
+		return nil, common.NewValidationError("invalid slot", map[string]string{"slot_id": "slot is in the past"})
+	}
+	iv.Status = interview.StatusScheduled
+	iv.SelectedSlotID = &slot.ID
+	return s.updateWithEvent(ctx, *iv, "interview.scheduled", userID, map[string]string{"application_id": applicationID.String(), "interview_id": iv.ID.String(), "slot_id": slotID.String()})
+}
+
+func (s *InterviewService) Cancel(ctx context.Context, applicationID, userID common.UUID) (*interview.Interview, error) {
+	if _, _, err := s.participantApplication(ctx, applicationID, userID); err != nil {
+		return nil, err
+	}
+	iv, err := s.interviews.GetByApplication(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	if iv.Status == interview.StatusCancelled {
+		return nil, common.NewError(common.CodeValidation, "interview is already cancelled", nil)
+	}
+	iv.Status = interview.StatusCancelled
+	iv.CancelledBy = &userID
+	return s.updateWithEvent(ctx, *iv, "interview.cancelled", userID, map[string]string{"application_id": applicationID.String(), "interview_id": iv.ID.String()})
+}
+
+func (s *InterviewService) updateWithEvent(ctx context.Context, iv interview.Interview, name string, userID common.UUID, payload map[string]string) (*interview.Interview, error) {
+	var updated *interview.Interview
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		updated, err = s.interviews.UpdateState(ctx, iv)
+		if err != nil {
+			return err
+		}
+		return s.analytics.Create(ctx, analytics.Event{Name: name, UserID: &userID, Payload: analyticsPayload(ctx, payload)})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (s *InterviewService) Get(ctx context.Context, applicationID, userID common.UUID) (*interview.Interview, error) {
+	if _, _, err := s.participantApplication(ctx, applicationID, userID); err != nil {
+		return nil, err
+	}
+	return s.interviews.GetByApplication(ctx, applicationID)
+}
+
+// Calendar отдаёт согласованный слот в формате iCalendar. Для отменённого собеседования событие помечается STATUS:CANCELLED,
+// чтобы календарь участника удалил его при повторном импорте.
+func (s *InterviewService) Calendar(ctx context.Context, applicationID, userID common.UUID) ([]byte, error) {
+	_, vac, err := s.participantApplication(ctx, applicationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	iv, err := s.interviews.GetByApplication(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	slot := iv.SelectedSlot()
+	if slot == nil {
+		return nil, common.NewError(common.CodeNotFound, "interview slot is not chosen yet", nil)
+	}
+	event := calendar.Event{
+		UID:         slot.ID.String() + "@profzoom",
+		Summary:     "Interview: " + vac.Title,
+		Description: fmt.Sprintf("Format: %s. Timezone: %s.", iv.Format, iv.Timezone),
+		StartsAt:    slot.StartsAt,
+		EndsAt:      slot.EndsAt,
+		Stamp:       iv.UpdatedAt,
+		Cancelled:   iv.Status == interview.StatusCancelled,
+	}
+	if iv.Format == interview.FormatOnline {
+		event.URL = iv.Location
+		event.Location = "Online"
+	} else {
+		event.Location = iv.Location
+	}
+	return calendar.RenderICS(event), nil
+}
+
+func isHTTPLink(value string) bool {
+	if strings.ContainsAny(value, " \t\r\n") {
+		return false
+	}
+	parsed, err := url.Parse(value)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+func (s *InterviewService) participantApplication(ctx context.Context, applicationID, userID common.UUID) (*application.Application, *vacancy.Vacancy, error) {
+	app, err := s.applications.GetByID(ctx, applicationID)
+	if err != nil {
+		return nil, nil, err
+	}
+	vac, err := s.vacancies.GetByID(ctx, app.VacancyID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if userID != app.StudentID && userID != vac.CompanyID {
+		return nil, nil, common.NewError(common.CodeForbidden, "user is not allowed to manage interview", nil)
+	}
+	return app, vac, nil
+}
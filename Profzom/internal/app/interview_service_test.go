@@ -0,0 +1,231 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/application"
+	"profzom/internal/domain/interview"
+)
+
+// inviteApplication переводит отклик окружения в invited: интервью предлагают только по приглашённому отклику.
+func inviteApplication(t *testing.T, f *testEnv) {
+	t.Helper()
+	if _, err := f.applicationSvc.UpdateStatus(context.Background(), f.application.ID, application.StatusInvited, "", f.companyID); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+}
+
+func futureProposal() InterviewProposal {
+	start := time.Now().UTC().Add(48 * time.Hour).Truncate(time.Hour)
+	return InterviewProposal{
+		Slots: []interview.Slot{
+			{StartsAt: start, EndsAt: start.Add(time.Hour)},
+			{StartsAt: start.Add(24 * time.Hour), EndsAt: start.Add(25 * time.Hour)},
+		},
+		Format:   interview.FormatOnline,
+		Location: "https://meet.example.com/abc",
+		Timezone: "Europe/Moscow",
+	}
+}
+
+func TestInterviewServicePropose_RequiresInvitedApplication(t *testing.T) {
+	f := newTestEnv(t)
+
+	_, err := f.interviewSvc.Propose(context.Background(), f.application.ID, f.companyID, futureProposal())
+	if !common.Is(err, common.CodeValidation) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestInterviewServiceNormalizeProposal_RejectsPastAndInvalidSlots(t *testing.T) {
+	service := NewInterviewService(nil, nil, nil, noopAnalyticsRepo{}, directUnitOfWork{})
+	past := time.Now().UTC().Add(-time.Hour)
+	proposal := InterviewProposal{
+		Slots:    []interview.Slot{{StartsAt: past, EndsAt: past.Add(time.Hour)}, {StartsAt: past.Add(48 * time.Hour), EndsAt: past.Add(47 * time.Hour)}},
+		Format:   interview.FormatOnline,
+		Location: "not a link",
+		Timezone: "Mars/Olympus",
+	}
+
+	_, err := service.NormalizeProposal(proposal)
+	appErr, ok := err.(*common.AppError)
+	if !ok || appErr.Code != common.CodeValidation {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	for _, field := range []string{"slots[0]", "slots[1]", "location", "timezone"} {
+		if _, ok := appErr.Fields[field]; !ok {
+			t.Fatalf("expected field %q in %v", field, appErr.Fields)
+		}
+	}
+}
+
+func TestInterviewServiceSelect_StudentPicksCompanySlot(t *testing.T) {
+	f := newTestEnv(t)
+	inviteApplication(t, f)
+	proposed, err := f.interviewSvc.Propose(context.Background(), f.application.ID, f.companyID, futureProposal())
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if _, err := f.interviewSvc.Select(context.Background(), f.application.ID, f.companyID, proposed.Slots[0].ID); !common.Is(err, common.CodeForbidden) {
+		t.Fatalf("expected forbidden error for proposer, got %v", err)
+	}
+	scheduled, err := f.interviewSvc.Select(context.Background(), f.application.ID, f.studentID, proposed.Slots[1].ID)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if scheduled.Status != interview.StatusScheduled || scheduled.SelectedSlot() == nil || scheduled.SelectedSlot().ID != proposed.Slots[1].ID {
+		t.Fatalf("unexpected interview %+v", scheduled)
+	}
+}
+
+func TestInterviewServicePropose_RescheduleResetsSelection(t *testing.T) {
+	f := newTestEnv(t)
+	inviteApplication(t, f)
+	proposed, _ := f.interviewSvc.Propose(context.Background(), f.application.ID, f.companyID, futureProposal())
+	if _, err := f.interviewSvc.Select(context.Background(), f.application.ID, f.studentID, proposed.Slots[0].ID); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	rescheduled, err := f.interviewSvc.Propose(context.Background(), f.application.ID, f.studentID, futureProposal())
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if rescheduled.Status != interview.StatusProposed || rescheduled.SelectedSlotID != nil || rescheduled.ProposedBy != f.studentID {
+		t.Fatalf("unexpected interview %+v", rescheduled)
+	}
+	if _, err := f.interviewSvc.Select(context.Background(), f.application.ID, f.companyID, rescheduled.Slots[0].ID); err != nil {
+		t.Fatalf("expected company to pick student slot, got %v", err)
+	}
+}
+
+func TestInterviewServiceCalendar_ExportsChosenSlot(t *testing.T) {
+	f := newTestEnv(t)
+	inviteApplication(t, f)
+	proposed, _ := f.interviewSvc.Propose(context.Background(), f.application.ID, f.companyID, futureProposal())
+
+	if _, err := f.interviewSvc.Calendar(context.Background(), f.application.ID, f.studentID); !common.Is(err, common.CodeNotFound) {
+		t.Fatalf("expected not found before slot is chosen, got %v", err)
+	}
+	if _, err := f.interviewSvc.Select(context.Background(), f.application.ID, f.studentID, proposed.Slots[0].ID); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	doc, err := f.interviewSvc.Calendar(context.Background(), f.application.ID, f.companyID)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	want := "DTSTART:" + proposed.Slots[0].StartsAt.UTC().Format("20060102T150405Z")
+	if !strings.Contains(string(doc), want) || !strings.Contains(string(doc), "URL:https://meet.example.com/abc") {
+		t.Fatalf("expected %q and meeting link in calendar, got %q", want, doc)
+	}
+
+	if _, err := f.interviewSvc.Cancel(context.Background(), f.application.ID, f.studentID); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	doc, _ = f.interviewSvc.Calendar(context.Background(), f.application.ID, f.companyID)
+	if !strings.Contains(string(doc), "STATUS:CANCELLED") {
+		t.Fatalf("expected cancelled event, got %q", doc)
+	}
+}
+
+func TestInterviewServiceGet_ForbiddenForStranger(t *testing.T) {
+	f := newTestEnv(t)
+	inviteApplication(t, f)
+	_, _ = f.interviewSvc.Propose(context.Background(), f.application.ID, f.companyID, futureProposal())
+
+	if _, err := f.interviewSvc.Get(context.Background(), f.application.ID, common.NewUUID()); !common.Is(err, common.CodeForbidden) {
+		t.Fatalf("expected forbidden error, got %v", err)
+	}
+}
+
+// failingInterviewRepo отказывает в сохранении предложения, чтобы проверить откат приглашения.
+type failingInterviewRepo struct {
+	*fakeInterviewRepo
+}
+
+func (r failingInterviewRepo) Propose(ctx context.Context, iv interview.Interview) (*interview.Interview, error) {
+	return nil, common.NewError(common.CodeInternal, "interview insert failed", nil)
+}
+
+// rollbackUnitOfWork имитирует транзакцию поверх фейков: при ошибке внешнего Do откатывает отклики и уведомления.
+type rollbackUnitOfWork struct {
+	applications *fakeApplicationRepo
+	notifier     *recordingNotifier
+}
+
+type rollbackTxKey struct{}
+
+func (u rollbackUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(rollbackTxKey{}) != nil {
+		return fn(ctx)
+	}
+	u.applications.mu.Lock()
+	items := make(map[common.UUID]application.Application, len(u.applications.items))
+	for id, app := range u.applications.items {
+		items[id] = *app
+	}
+	events := len(u.applications.events)
+	u.applications.mu.Unlock()
+	sent := len(u.notifier.sent)
+
+	if err := fn(context.WithValue(ctx, rollbackTxKey{}, true)); err != nil {
+		u.applications.mu.Lock()
+		u.applications.items = make(map[common.UUID]*application.Application, len(items))
+		for id, app := range items {
+			app := app
+			u.applications.items[id] = &app
+		}
+		u.applications.events = u.applications.events[:events]
+		u.applications.mu.Unlock()
+		u.notifier.sent = u.notifier.sent[:sent]
+		return err
+	}
+	return nil
+}
+
+func TestInterviewServiceInvite_ProposesSlots(t *testing.T) {
+	f := newTestEnv(t)
+
+	updated, proposed, err := f.interviewSvc.Invite(context.Background(), f.application.ID, f.companyID, "", futureProposal())
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if updated.Status != application.StatusInvited || proposed.Status != interview.StatusProposed || len(proposed.Slots) != 2 {
+		t.Fatalf("expected invited application with proposed interview, got %+v, %+v", updated, proposed)
+	}
+}
+
+func TestInterviewServiceInvite_RollsBackStatusWhenProposalFails(t *testing.T) {
+	f := newTestEnv(t)
+	tx := rollbackUnitOfWork{applications: f.applications, notifier: f.notifier}
+	service := NewInterviewService(failingInterviewRepo{newFakeInterviewRepo()}, f.applications, f.vacancies, noopAnalyticsRepo{}, tx).WithApplicationService(f.applicationSvc)
+
+	if _, _, err := service.Invite(context.Background(), f.application.ID, f.companyID, "", futureProposal()); !common.Is(err, common.CodeInternal) {
+		t.Fatalf("expected internal error, got %v", err)
+	}
+	stored, _ := f.applications.GetByID(context.Background(), f.application.ID)
+	if stored.Status != application.StatusApplied {
+		t.Fatalf("expected status applied after rollback, got %q", stored.Status)
+	}
+	if len(f.notifier.sent) != 0 {
+		t.Fatalf("expected no invitation notification after rollback, got %+v", f.notifier.sent)
+	}
+}
+
+func TestInterviewServiceInvite_InvalidProposalKeepsStatus(t *testing.T) {
+	f := newTestEnv(t)
+	proposal := futureProposal()
+	proposal.Location = ""
+
+	if _, _, err := f.interviewSvc.Invite(context.Background(), f.application.ID, f.companyID, "", proposal); !common.Is(err, common.CodeValidation) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	stored, _ := f.applications.GetByID(context.Background(), f.application.ID)
+	if stored.Status != application.StatusApplied || len(f.notifier.sent) != 0 {
+		t.Fatalf("expected untouched application, got %q and %+v", stored.Status, f.notifier.sent)
+	}
+}
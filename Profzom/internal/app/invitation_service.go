@@ -0,0 +1,266 @@
+package app
+
+import (
+	"context"
+	"strings"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/analytics"
+	"profzom/internal/domain/application"
+	"profzom/internal/domain/invitation"
+	"profzom/internal/domain/message"
+	"profzom/internal/domain/moderation"
+	"profzom/internal/domain/notification"
+	"profzom/internal/domain/profile"
+	"profzom/internal/domain/user"
+	"profzom/internal/domain/vacancy"
+)
+
+const invitationMessageMaxLength = messageMaxLength
+
+type InvitationService struct {
+	invitations  invitation.Repository
+	applications application.Repository
+	vacancies    vacancy.Repository
+	students     profile.StudentRepository
+	messages     message.Repository
+	analytics    analytics.Repository
+	tx           UnitOfWork
+	notifier     Notifier
+	// screen проверяет текст приглашения: он попадает студенту и затем в переписку отклика.
+	screen contentScreen
+	blocks user.BlockListRepository
+}
+
+func NewInvitationService(invitations invitation.Repository, applications application.Repository, vacancies vacancy.Repository, students profile.StudentRepository, messages message.Repository, analytics analytics.Repository, tx UnitOfWork, notifier Notifier) *InvitationService {
+	return &InvitationService{invitations: invitations, applications: applications, vacancies: vacancies, students: students, messages: messages, analytics: analytics, tx: tx, notifier: notifier}
+}
+
+// WithModeration проверяет текст приглашения тем же checker'ом, что и сообщения. Приглашение показывается
+// студенту сразу, поэтому текст, который задержала бы модерация, отклоняется, а не ставится в очередь.
+func (s *InvitationService) WithModeration(checker moderation.Checker) *InvitationService {
+	s.screen = contentScreen{checker: checker}
+	return s
+}
+
+// WithBlockList запрещает приглашать и принимать приглашение, если студент или компания внесли другого в чёрный список.
+func (s *InvitationService) WithBlockList(blocks user.BlockListRepository) *InvitationService {
+	s.blocks = blocks
+	return s
+}
+
+// Invite приглашает студента откликнуться на опубликованную вакансию компании. Пригласить можно только студента,
+// открывшего профиль компаниям, и только если он ещё не откликался и не имеет ожидающего приглашения.
+func (s *InvitationService) Invite(ctx context.Context, companyID, vacancyID, studentID common.UUID, text string) (*invitation.Invitation, error) {
+	text = strings.TrimSpace(text)
+	if len(text) > invitationMessageMaxLength {
+		return nil, common.NewValidationError("invalid invitation", map[string]string{"message": "message is too long"})
+	}
+	verdict, err := s.screen.check(ctx, moderation.ContentMessage, companyID, text)
+	if err != nil {
+		return nil, err
+	}
+	if verdict.Hold {
+		return nil, common.NewValidationError("invalid invitation", map[string]string{"message": "message was flagged by moderation"})
+	}
+	vac, err := s.vacancies.GetByID(ctx, vacancyID)
+	if err != nil {
+		return nil, err
+	}
+	if vac.CompanyID != companyID {
+		return nil, common.NewError(common.CodeForbidden, "vacancy belongs to another company", nil)
+	}
+	if vac.Status != vacancy.StatusPublished {
+		return nil, common.NewError(common.CodeValidation, "vacancy is not published", nil)
+	}
+	if err := ensureNotBlocked(ctx, s.blocks, companyID, studentID); err != nil {
+		return nil, err
+	}
+	student, err := s.students.GetByUserID(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if !student.VisibleToCompanies {
+		return nil, common.NewError(common.CodeForbidden, "student does not accept invitations", nil)
+	}
+	if _, err := s.applications.FindByVacancyAndStudent(ctx, vacancyID, studentID); err == nil {
+		return nil, common.NewError(common.CodeConflict, "student already applied", nil)
+	} else if !common.Is(err, common.CodeNotFound) {
+		return nil, err
+	}
+	if _, err := s.invitations.FindPending(ctx, vacancyID, studentID); err == nil {
+		return nil, common.NewError(common.CodeConflict, "invitation already sent", nil)
+	} else if !common.Is(err, common.CodeNotFound) {
+		return nil, err
+	}
+	var created *invitation.Invitation
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		created, err = s.invitations.Create(ctx, invitation.Invitation{VacancyID: vacancyID, CompanyID: companyID, StudentID: studentID, Message: text, Status: invitation.StatusPending})
+		if err != nil {
+			return err
+		}
+		if err := s.analytics.Create(ctx, analytics.Event{Name: "invitation.sent", UserID: &companyID, Payload: analyticsPayload(ctx, map[string]string{"invitation_id": created.ID.String(), "vacancy_id": vacancyID.String()})}); err != nil {
+			return err
+		}
+		return s.notifier.Notify(ctx, notification.Notification{
+			UserID:  studentID,
+			Type:    notification.TypeInvitationReceived,
+			Text:    invitationNotificationText(vac.Title),
+			Payload: map[string]string{"invitation_id": created.ID.String(), "vacancy_id": vacancyID.String()},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// Accept принимает приглашение: создаёт отклик сразу в статусе invited и переносит текст приглашения в его переписку.
+func (s *InvitationService) Accept(ctx context.Context, invitationID, studentID common.UUID) (*invitation.Invitation, *application.Application, error) {
+	inv, err := s.pendingForStudent(ctx, invitationID, studentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := ensureNotBlocked(ctx, s.blocks, inv.CompanyID, studentID); err != nil {
+		return nil, nil, err
+	}
+	studentProfile, err := s.students.GetByUserID(ctx, studentID)
+	if err != nil {
+		if common.Is(err, common.CodeNotFound) {
+			return nil, nil, common.NewError(common.CodeValidation, "student profile is required", nil)
+		}
+		return nil, nil, err
+	}
+	if !IsStudentProfileComplete(*studentProfile) {
+		return nil, nil, common.NewError(common.CodeValidation, "student profile is incomplete", nil)
+	}
+	vac, err := s.vacancies.GetByID(ctx, inv.VacancyID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if vac.Status != vacancy.StatusPublished {
+		return nil, nil, common.NewError(common.CodeValidation, "vacancy is not published", nil)
+	}
+	if _, err := s.applications.FindByVacancyAndStudent(ctx, inv.VacancyID, studentID); err == nil {
+		return nil, nil, common.NewError(common.CodeConflict, "already applied", nil)
+	} else if !common.Is(err, common.CodeNotFound) {
+		return nil, nil, err
+	}
+	var accepted *invitation.Invitation
+	var created *application.Application
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		created, err = s.applications.Create(ctx, application.Application{VacancyID: inv.VacancyID, StudentID: studentID, Status: application.StatusInvited})
+		if err != nil {
+			return err
+		}
+		inv.Status = invitation.StatusAccepted
+		inv.ApplicationID = &created.ID
+		accepted, err = s.invitations.UpdateStatus(ctx, *inv)
+		if err != nil {
+			return err
+		}
+		if inv.Message != "" {
+			if _, err := s.messages.Create(ctx, message.Message{ApplicationID: created.ID, SenderID: inv.CompanyID, Body: inv.Message}); err != nil {
+				return err
+			}
+		}
+		if err := s.analytics.Create(ctx, analytics.Event{Name: "invitation.accepted", UserID: &studentID, Payload: analyticsPayload(ctx, map[string]string{"invitation_id": inv.ID.String(), "application_id": created.ID.String()})}); err != nil {
+			return err
+		}
+		return s.notifier.Notify(ctx, notification.Notification{
+			UserID:  inv.CompanyID,
+			Type:    notification.TypeInvitationAccepted,
+			Text:    invitationAcceptedNotificationText(vac.Title),
+			Payload: map[string]string{"invitation_id": inv.ID.String(), "application_id": created.ID.String()},
+		})
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return accepted, created, nil
+}
+
+func (s *InvitationService) Decline(ctx context.Context, invitationID, studentID common.UUID) (*invitation.Invitation, error) {
+	inv, err := s.pendingForStudent(ctx, invitationID, studentID)
+	if err != nil {
+		return nil, err
+	}
+	vac, err := s.vacancies.GetByID(ctx, inv.VacancyID)
+	if err != nil {
+		return nil, err
+	}
+	var declined *invitation.Invitation
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		inv.Status = invitation.StatusDeclined
+		var err error
+		declined, err = s.invitations.UpdateStatus(ctx, *inv)
+		if err != nil {
+			return err
+		}
+		if err := s.analytics.Create(ctx, analytics.Event{Name: "invitation.declined", UserID: &studentID, Payload: analyticsPayload(ctx, map[string]string{"invitation_id": inv.ID.String()})}); err != nil {
+			return err
+		}
+		return s.notifier.Notify(ctx, notification.Notification{
+			UserID:  inv.CompanyID,
+			Type:    notification.TypeInvitationDeclined,
+			Text:    invitationDeclinedNotificationText(vac.Title),
+			Payload: map[string]string{"invitation_id": inv.ID.String(), "vacancy_id": inv.VacancyID.String()},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return declined, nil
+}
+
+// Cancel отзывает приглашение, на которое студент ещё не ответил.
+func (s *InvitationService) Cancel(ctx context.Context, invitationID, companyID common.UUID) (*invitation.Invitation, error) {
+	inv, err := s.invitations.GetByID(ctx, invitationID)
+	if err != nil {
+		return nil, err
+	}
+	if inv.CompanyID != companyID {
+		return nil, common.NewError(common.CodeForbidden, "invitation belongs to another company", nil)
+	}
+	if inv.Status != invitation.StatusPending {
+		return nil, common.NewError(common.CodeConflict, "invitation is no longer pending", nil)
+	}
+	var cancelled *invitation.Invitation
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		inv.Status = invitation.StatusCancelled
+		var err error
+		cancelled, err = s.invitations.UpdateStatus(ctx, *inv)
+		if err != nil {
+			return err
+		}
+		return s.analytics.Create(ctx, analytics.Event{Name: "invitation.cancelled", UserID: &companyID, Payload: analyticsPayload(ctx, map[string]string{"invitation_id": inv.ID.String()})})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cancelled, nil
+}
+
+func (s *InvitationService) ListByStudent(ctx context.Context, studentID common.UUID, page common.PageRequest) ([]invitation.Invitation, *common.Cursor, error) {
+	return s.invitations.ListByStudent(ctx, studentID, normalizePage(page))
+}
+
+func (s *InvitationService) ListByCompany(ctx context.Context, companyID common.UUID, page common.PageRequest) ([]invitation.Invitation, *common.Cursor, error) {
+	return s.invitations.ListByCompany(ctx, companyID, normalizePage(page))
+}
+
+func (s *InvitationService) pendingForStudent(ctx context.Context, invitationID, studentID common.UUID) (*invitation.Invitation, error) {
+	inv, err := s.invitations.GetByID(ctx, invitationID)
+	if err != nil {
+		return nil, err
+	}
+	if inv.StudentID != studentID {
+		return nil, common.NewError(common.CodeForbidden, "invitation belongs to another student", nil)
+	}
+	if inv.Status != invitation.StatusPending {
+		return nil, common.NewError(common.CodeConflict, "invitation is no longer pending", nil)
+	}
+	return inv, nil
+}
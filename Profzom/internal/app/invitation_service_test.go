@@ -0,0 +1,102 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/application"
+	"profzom/internal/domain/invitation"
+	"profzom/internal/domain/message"
+)
+
+func TestInvitationServiceAccept_CreatesInvitedApplicationWithThread(t *testing.T) {
+	f := newTestEnv(t)
+	vacancyID := f.publishedVacancy(t)
+	inv, err := f.invitationSvc.Invite(context.Background(), f.companyID, vacancyID, f.studentID, "We liked your profile!")
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	accepted, created, err := f.invitationSvc.Accept(context.Background(), inv.ID, f.studentID)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if created.Status != application.StatusInvited || created.VacancyID != vacancyID {
+		t.Fatalf("expected invited application, got %+v", created)
+	}
+	if accepted.Status != invitation.StatusAccepted || accepted.ApplicationID == nil || *accepted.ApplicationID != created.ID {
+		t.Fatalf("expected accepted invitation linked to application, got %+v", accepted)
+	}
+	thread, _, _ := f.messages.ListByApplication(context.Background(), created.ID, "", common.PageRequest{})
+	if len(thread) != 1 || thread[0].SenderID != f.companyID || thread[0].Kind != message.KindUser {
+		t.Fatalf("expected invitation text as company message, got %+v", thread)
+	}
+	if len(f.notifier.sent) != 2 || f.notifier.sent[0].UserID != f.studentID || f.notifier.sent[1].UserID != f.companyID {
+		t.Fatalf("expected notifications to student and company, got %+v", f.notifier.sent)
+	}
+}
+
+func TestInvitationServiceInvite_RequiresVisibleProfile(t *testing.T) {
+	f := newTestEnv(t)
+	vacancyID := f.publishedVacancy(t)
+	f.students.profiles[f.studentID].VisibleToCompanies = false
+
+	_, err := f.invitationSvc.Invite(context.Background(), f.companyID, vacancyID, f.studentID, "")
+	if !common.Is(err, common.CodeForbidden) {
+		t.Fatalf("expected forbidden error, got %v", err)
+	}
+}
+
+func TestInvitationServiceInvite_ConflictsWithExistingApplication(t *testing.T) {
+	f := newTestEnv(t)
+
+	_, err := f.invitationSvc.Invite(context.Background(), f.companyID, f.application.VacancyID, f.studentID, "")
+	if !common.Is(err, common.CodeConflict) {
+		t.Fatalf("expected conflict error, got %v", err)
+	}
+}
+
+func TestInvitationServiceDecline_IsFinal(t *testing.T) {
+	f := newTestEnv(t)
+	vacancyID := f.publishedVacancy(t)
+	inv, _ := f.invitationSvc.Invite(context.Background(), f.companyID, vacancyID, f.studentID, "")
+
+	declined, err := f.invitationSvc.Decline(context.Background(), inv.ID, f.studentID)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if declined.Status != invitation.StatusDeclined {
+		t.Fatalf("expected declined status, got %q", declined.Status)
+	}
+	if _, _, err := f.invitationSvc.Accept(context.Background(), inv.ID, f.studentID); !common.Is(err, common.CodeConflict) {
+		t.Fatalf("expected conflict error, got %v", err)
+	}
+}
+
+func TestInvitationServiceCancel_ForbiddenForAnotherCompany(t *testing.T) {
+	f := newTestEnv(t)
+	vacancyID := f.publishedVacancy(t)
+	inv, _ := f.invitationSvc.Invite(context.Background(), f.companyID, vacancyID, f.studentID, "")
+
+	_, err := f.invitationSvc.Cancel(context.Background(), inv.ID, common.NewUUID())
+	if !common.Is(err, common.CodeForbidden) {
+		t.Fatalf("expected forbidden error, got %v", err)
+	}
+}
+
+func TestInvitationServiceInvite_RejectsFlaggedMessage(t *testing.T) {
+	f := newTestEnv(t)
+	vacancyID := f.publishedVacancy(t)
+
+	_, err := f.invitationSvc.Invite(context.Background(), f.companyID, vacancyID, f.studentID, "Join our casino team")
+	if !common.Is(err, common.CodeValidation) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	if len(f.notifier.sent) != 0 {
+		t.Fatalf("expected no invitation notification, got %+v", f.notifier.sent)
+	}
+	if _, err := f.invitationSvc.Invite(context.Background(), f.companyID, vacancyID, f.studentID, "We liked your profile!"); err != nil {
+		t.Fatalf("expected clean invitation to pass, got %v", err)
+	}
+}
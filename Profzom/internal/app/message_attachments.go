@@ -0,0 +1,184 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/message"
+	"profzom/internal/storage"
+)
+
+const (
+	// AttachmentMaxSize — предельный размер одного вложения в байтах.
+	AttachmentMaxSize      = 10 << 20
+	messageMaxAttachments  = 5
+	attachmentNameMaxRunes = 255
+)
+
+// attachmentType — допустимый тип вложения: Content-Type для скачивания и что http.DetectContentType видит в настоящем файле.
+// Для форматов, которые DetectContentType не различает, вместо sniffed задаётся сигнатура magic.
+type attachmentType struct {
+	contentType string
+	sniffed     string
+	magic       []byte
+}
+
+// oleMagic — сигнатура составного документа OLE2, в котором хранятся файлы Word 97–2003.
+var oleMagic = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+
+// Тип определяется по расширению и сверяется с содержимым, чтобы под видом резюме нельзя было прислать исполняемый файл или HTML.
+var attachmentTypes = map[string]attachmentType{
+	".pdf":  {contentType: "application/pdf", sniffed: "application/pdf"},
+	".doc":  {contentType: "application/msword", magic: oleMagic},
+	".docx": {contentType: "application/vnd.openxmlformats-officedocument.wordprocessingml.document", sniffed: "application/zip"},
+	".odt":  {contentType: "application/vnd.oasis.opendocument.text", sniffed: "application/zip"},
+	".txt":  {contentType: "text/plain; charset=utf-8", sniffed: "text/plain"},
+	".md":   {contentType: "text/markdown; charset=utf-8", sniffed: "text/plain"},
+	".png":  {contentType: "image/png", sniffed: "image/png"},
+	".jpg":  {contentType: "image/jpeg", sniffed: "image/jpeg"},
+	".jpeg": {contentType: "image/jpeg", sniffed: "image/jpeg"},
+	".zip":  {contentType: "application/zip", sniffed: "application/zip"},
+}
+
+func (t attachmentType) matches(data []byte) bool {
+	if t.magic != nil {
+		return bytes.HasPrefix(data, t.magic)
+	}
+	return strings.HasPrefix(http.DetectContentType(data), t.sniffed)
+}
+
+// WithAttachments включает вложения в переписке: метаданные хранятся в репозитории, сами файлы — в files.
+func (s *MessageService) WithAttachments(attachments message.AttachmentRepository, files storage.Storage) *MessageService {
+	s.attachments = attachments
+	s.files = files
+	return s
+}
+
+// Upload сохраняет файл участника отклика. Вложение появляется у собеседника только после отправки сообщения с ним.
+func (s *MessageService) Upload(ctx context.Context, applicationID, userID common.UUID, fileName string, data []byte) (*message.Attachment, error) {
+	if s.attachments == nil {
+		return nil, common.NewError(common.CodeValidation, "attachments are not supported", nil)
+	}
+	if _, _, err := s.participants(ctx, applicationID, userID, "user is not allowed to upload attachments"); err != nil {
+		return nil, err
+	}
+	fileName = cleanAttachmentName(fileName)
+	fields := map[string]string{}
+	if fileName == "" {
+		fields["file_name"] = "file name is required"
+	} else if utf8.RuneCountInString(fileName) > attachmentNameMaxRunes {
+		fields["file_name"] = "file name is too long"
+	}
+	if len(data) == 0 {
+		fields["file"] = "file is empty"
+	} else if len(data) > AttachmentMaxSize {
+		fields["file"] = "file is too large"
+	}
+	kind, ok := attachmentTypes[strings.ToLower(path.Ext(fileName))]
+	if !ok && fields["file_name"] == "" {
+		fields["file_name"] = "unsupported file type"
+	}
+	if len(fields) > 0 {
+		return nil, common.NewValidationError("invalid attachment", fields)
+	}
+	if !kind.matches(data) {
+		return nil, common.NewValidationError("invalid attachment", map[string]string{"file": "file content does not match its extension"})
+	}
+	key := "attachments/" + applicationID.String() + "/" + common.NewUUID().String()
+	if err := s.files.Put(ctx, key, kind.contentType, bytes.NewReader(data), int64(len(data))); err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to store attachment", err)
+	}
+	created, err := s.attachments.Create(ctx, message.Attachment{
+		ApplicationID: applicationID,
+		UploaderID:    userID,
+		FileName:      fileName,
+		ContentType:   kind.contentType,
+		Size:          int64(len(data)),
+		StorageKey:    key,
+	})
+	if err != nil {
+		// файл без метаданных никто не скачает, поэтому его удаляем; ошибку удаления перекрывает исходная
+		_ = s.files.Delete(ctx, key)
+		return nil, err
+	}
+	return created, nil
+}
+
+// OpenAttachment отдаёт файл участнику отклика. Вызывающий закрывает reader.
+func (s *MessageService) OpenAttachment(ctx context.Context, attachmentID, userID common.UUID) (*message.Attachment, io.ReadCloser, error) {
+	if s.attachments == nil {
+		return nil, nil, common.NewError(common.CodeNotFound, "attachment not found", nil)
+	}
+	attachment, err := s.attachments.GetByID(ctx, attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, _, err := s.participants(ctx, attachment.ApplicationID, userID, "user is not allowed to download attachments"); err != nil {
+		return nil, nil, err
+	}
+	if attachment.MessageID == nil && attachment.UploaderID != userID {
+		return nil, nil, common.NewError(common.CodeNotFound, "attachment not found", nil)
+	}
+	body, err := s.files.Open(ctx, attachment.StorageKey)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil, common.NewError(common.CodeNotFound, "attachment file not found", err)
+	}
+	if err != nil {
+		return nil, nil, common.NewError(common.CodeInternal, "failed to open attachment", err)
+	}
+	return attachment, body, nil
+}
+
+func (s *MessageService) loadAttachments(ctx context.Context, items []message.Message) error {
+	if s.attachments == nil || len(items) == 0 {
+		return nil
+	}
+	ids := make([]common.UUID, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+	byMessage, err := s.attachments.ListByMessages(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for i := range items {
+		items[i].Attachments = byMessage[items[i].ID]
+	}
+	return nil
+}
+
+// cleanAttachmentName оставляет от присланного имени только базовое имя без управляющих символов.
+func cleanAttachmentName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "." || name == "/" {
+		return ""
+	}
+	return name
+}
+
+func uniqueIDs(ids []common.UUID) []common.UUID {
+	seen := make(map[common.UUID]bool, len(ids))
+	result := make([]common.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
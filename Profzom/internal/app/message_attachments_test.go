@@ -0,0 +1,203 @@
+package app
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/message"
+	"profzom/internal/storage"
+)
+
+type fakeAttachmentRepo struct {
+	mu    sync.Mutex
+	items map[common.UUID]*message.Attachment
+}
+
+func newFakeAttachmentRepo() *fakeAttachmentRepo {
+	return &fakeAttachmentRepo{items: map[common.UUID]*message.Attachment{}}
+}
+
+func (r *fakeAttachmentRepo) Create(ctx context.Context, attachment message.Attachment) (*message.Attachment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	attachment.ID = common.NewUUID()
+	attachment.CreatedAt = time.Now().UTC()
+	stored := attachment
+	r.items[attachment.ID] = &stored
+	return &attachment, nil
+}
+
+func (r *fakeAttachmentRepo) GetByID(ctx context.Context, id common.UUID) (*message.Attachment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	attachment, ok := r.items[id]
+	if !ok {
+		return nil, common.NewError(common.CodeNotFound, "attachment not found", nil)
+	}
+	copy := *attachment
+	return &copy, nil
+}
+
+func (r *fakeAttachmentRepo) Attach(ctx context.Context, messageID, applicationID, uploaderID common.UUID, ids []common.UUID) ([]message.Attachment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var attached []message.Attachment
+	for _, id := range ids {
+		attachment, ok := r.items[id]
+		if !ok || attachment.ApplicationID != applicationID || attachment.UploaderID != uploaderID || attachment.MessageID != nil {
+			continue
+		}
+		msgID := messageID
+		attachment.MessageID = &msgID
+		attached = append(attached, *attachment)
+	}
+	return attached, nil
+}
+
+func (r *fakeAttachmentRepo) ListByMessages(ctx context.Context, messageIDs []common.UUID) (map[common.UUID][]message.Attachment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result := map[common.UUID][]message.Attachment{}
+	for _, id := range messageIDs {
+		for _, attachment := range r.items {
+			if attachment.MessageID != nil && *attachment.MessageID == id {
+				result[id] = append(result[id], *attachment)
+			}
+		}
+	}
+	return result, nil
+}
+
+// withAttachments подключает к сервису сообщений окружения вложения во временном каталоге.
+func withAttachments(t *testing.T, f *testEnv) *MessageService {
+	t.Helper()
+	files, err := storage.NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	return f.messageSvc.WithAttachments(newFakeAttachmentRepo(), files)
+}
+
+const testPDF = "%PDF-1.4\n1 0 obj\n<<>>\nendobj\n"
+
+func TestMessageServiceUpload_SendAndDownload(t *testing.T) {
+	f := newTestEnv(t)
+	service := withAttachments(t, f)
+	ctx := context.Background()
+
+	uploaded, err := service.Upload(ctx, f.application.ID, f.studentID, `C:\Users\me\cv.pdf`, []byte(testPDF))
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if uploaded.FileName != "cv.pdf" || uploaded.ContentType != "application/pdf" || uploaded.Size != int64(len(testPDF)) {
+		t.Fatalf("unexpected attachment metadata: %+v", uploaded)
+	}
+	if _, _, err := service.OpenAttachment(ctx, uploaded.ID, f.companyID); !common.Is(err, common.CodeNotFound) {
+		t.Fatalf("expected unsent attachment to be hidden from company, got %v", err)
+	}
+
+	sent, err := service.SendWithAttachments(ctx, f.application.ID, f.studentID, "", []common.UUID{uploaded.ID})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(sent.Attachments) != 1 || sent.Attachments[0].ID != uploaded.ID {
+		t.Fatalf("expected message with attachment, got %+v", sent.Attachments)
+	}
+	if len(f.notifier.sent) != 1 || !strings.Contains(f.notifier.sent[0].Text, "cv.pdf") {
+		t.Fatalf("expected notification mentioning file name, got %+v", f.notifier.sent)
+	}
+
+	_, body, err := service.OpenAttachment(ctx, uploaded.ID, f.companyID)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	data, _ := io.ReadAll(body)
+	body.Close()
+	if string(data) != testPDF {
+		t.Fatalf("expected downloaded content to match upload, got %q", data)
+	}
+
+	thread, _, err := service.List(ctx, f.application.ID, f.companyID, common.PageRequest{})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(thread) != 1 || len(thread[0].Attachments) != 1 {
+		t.Fatalf("expected listed message with attachment, got %+v", thread)
+	}
+}
+
+func TestMessageServiceUpload_RejectsInvalidFiles(t *testing.T) {
+	f := newTestEnv(t)
+	service := withAttachments(t, f)
+	cases := map[string]struct {
+		name string
+		data []byte
+	}{
+		"empty":       {name: "cv.pdf", data: nil},
+		"too large":   {name: "cv.pdf", data: []byte(testPDF + strings.Repeat("x", AttachmentMaxSize))},
+		"extension":   {name: "setup.exe", data: []byte("MZ")},
+		"mismatch":    {name: "cv.pdf", data: []byte("<html><script>alert(1)</script></html>")},
+		"binary doc":  {name: "cv.doc", data: []byte("MZ\x90\x00\x03\x00\x00\x00")},
+		"no filename": {name: "", data: []byte(testPDF)},
+	}
+	for name, tc := range cases {
+		if _, err := service.Upload(context.Background(), f.application.ID, f.studentID, tc.name, tc.data); !common.Is(err, common.CodeValidation) {
+			t.Fatalf("%s: expected validation error, got %v", name, err)
+		}
+	}
+}
+
+func TestMessageServiceUpload_AcceptsWordDocument(t *testing.T) {
+	f := newTestEnv(t)
+	service := withAttachments(t, f)
+	data := append(append([]byte{}, oleMagic...), make([]byte, 504)...)
+
+	uploaded, err := service.Upload(context.Background(), f.application.ID, f.studentID, "cv.doc", data)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if uploaded.ContentType != "application/msword" {
+		t.Fatalf("expected application/msword, got %q", uploaded.ContentType)
+	}
+}
+
+func TestMessageServiceAttachments_RestrictedToParticipants(t *testing.T) {
+	f := newTestEnv(t)
+	service := withAttachments(t, f)
+	ctx := context.Background()
+	outsider := common.NewUUID()
+
+	if _, err := service.Upload(ctx, f.application.ID, outsider, "cv.pdf", []byte(testPDF)); !common.Is(err, common.CodeForbidden) {
+		t.Fatalf("expected forbidden upload for outsider, got %v", err)
+	}
+	uploaded, err := service.Upload(ctx, f.application.ID, f.studentID, "cv.pdf", []byte(testPDF))
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if _, err := service.SendWithAttachments(ctx, f.application.ID, f.studentID, "CV", []common.UUID{uploaded.ID}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if _, _, err := service.OpenAttachment(ctx, uploaded.ID, outsider); !common.Is(err, common.CodeForbidden) {
+		t.Fatalf("expected forbidden download for outsider, got %v", err)
+	}
+}
+
+func TestMessageServiceSendWithAttachments_RejectsForeignAttachment(t *testing.T) {
+	f := newTestEnv(t)
+	service := withAttachments(t, f)
+	ctx := context.Background()
+	uploaded, err := service.Upload(ctx, f.application.ID, f.studentID, "task.zip", []byte("PK\x03\x04rest"))
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	_, err = service.SendWithAttachments(ctx, f.application.ID, f.companyID, "", []common.UUID{uploaded.ID})
+	if !common.Is(err, common.CodeValidation) {
+		t.Fatalf("expected validation error for someone else's attachment, got %v", err)
+	}
+}
@@ -8,7 +8,12 @@ import (
 	"profzom/internal/domain/analytics"
 	"profzom/internal/domain/application"
 	"profzom/internal/domain/message"
+	"profzom/internal/domain/moderation"
+	"profzom/internal/domain/notification"
+	"profzom/internal/domain/user"
 	"profzom/internal/domain/vacancy"
+	"profzom/internal/realtime"
+	"profzom/internal/storage"
 )
 
 type MessageService struct {
@@ -16,6 +21,14 @@ type MessageService struct {
 	applications application.Repository
 	vacancies    vacancy.Repository
 	analytics    analytics.Repository
+	tx           UnitOfWork
+	notifier     Notifier
+	realtime     RealtimePublisher
+	attachments  message.AttachmentRepository
+	files        storage.Storage
+	// screen задерживает сообщения, на которых сработала автоматическая модерация.
+	screen contentScreen
+	blocks user.BlockListRepository
 }
 
 const (
@@ -23,17 +36,54 @@ const (
 	messageMinInterval = 2 * time.Second
 )
 
-func NewMessageService(messages message.Repository, applications application.Repository, vacancies vacancy.Repository, analytics analytics.Repository) *MessageService {
-	return &MessageService{messages: messages, applications: applications, vacancies: vacancies, analytics: analytics}
+func NewMessageService(messages message.Repository, applications application.Repository, vacancies vacancy.Repository, analytics analytics.Repository, tx UnitOfWork) *MessageService {
+	return &MessageService{messages: messages, applications: applications, vacancies: vacancies, analytics: analytics, tx: tx, notifier: noopNotifier{}, realtime: noopRealtimePublisher{}}
+}
+
+func NewMessageServiceWithNotifier(messages message.Repository, applications application.Repository, vacancies vacancy.Repository, analytics analytics.Repository, tx UnitOfWork, notifier Notifier) *MessageService {
+	service := NewMessageService(messages, applications, vacancies, analytics, tx)
+	service.notifier = notifier
+	return service
+}
+
+// WithRealtime включает отправку новых сообщений в поток событий участников отклика.
+func (s *MessageService) WithRealtime(publisher RealtimePublisher) *MessageService {
+	s.realtime = publisher
+	return s
+}
+
+// WithModeration проверяет текст сообщений перед отправкой. Задержанное сообщение видит только отправитель,
+// собеседник получает его и уведомление после одобрения модератором.
+func (s *MessageService) WithModeration(checker moderation.Checker, queue moderation.Repository) *MessageService {
+	s.screen = contentScreen{checker: checker, queue: queue}
+	return s
+}
+
+// WithBlockList запрещает переписку, если один из участников внёс другого в чёрный список.
+func (s *MessageService) WithBlockList(blocks user.BlockListRepository) *MessageService {
+	s.blocks = blocks
+	return s
 }
 
 func (s *MessageService) Send(ctx context.Context, applicationID, senderID common.UUID, body string) (*message.Message, error) {
-	if body == "" {
+	return s.SendWithAttachments(ctx, applicationID, senderID, body, nil)
+}
+
+// SendWithAttachments отправляет сообщение с ранее загруженными вложениями отправителя; текст при этом может быть пустым.
+func (s *MessageService) SendWithAttachments(ctx context.Context, applicationID, senderID common.UUID, body string, attachmentIDs []common.UUID) (*message.Message, error) {
+	attachmentIDs = uniqueIDs(attachmentIDs)
+	if body == "" && len(attachmentIDs) == 0 {
 		return nil, common.NewError(common.CodeValidation, "message body is required", nil)
 	}
 	if len(body) > messageMaxLength {
 		return nil, common.NewError(common.CodeValidation, "message is too long", nil)
 	}
+	if len(attachmentIDs) > messageMaxAttachments {
+		return nil, common.NewError(common.CodeValidation, "too many attachments", nil)
+	}
+	if len(attachmentIDs) > 0 && s.attachments == nil {
+		return nil, common.NewError(common.CodeValidation, "attachments are not supported", nil)
+	}
 	app, err := s.applications.GetByID(ctx, applicationID)
 	if err != nil {
 		return nil, err
@@ -53,25 +103,217 @@ func (s *MessageService) Send(ctx context.Context, applicationID, senderID commo
 	} else if !common.Is(err, common.CodeNotFound) {
 		return nil, err
 	}
-	created, err := s.messages.Create(ctx, message.Message{ApplicationID: applicationID, SenderID: senderID, Body: body})
+	recipientID := vac.CompanyID
+	if senderID == vac.CompanyID {
+		recipientID = app.StudentID
+	}
+	if err := ensureNotBlocked(ctx, s.blocks, senderID, recipientID); err != nil {
+		return nil, err
+	}
+	verdict, err := s.screen.check(ctx, moderation.ContentMessage, senderID, body)
+	if err != nil {
+		return nil, err
+	}
+	draft := message.Message{ApplicationID: applicationID, SenderID: senderID, Body: body}
+	if verdict.Hold {
+		draft.Moderation = message.ModerationPending
+	}
+	var created *message.Message
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		created, err = s.messages.Create(ctx, draft)
+		if err != nil {
+			return err
+		}
+		if len(attachmentIDs) > 0 {
+			created.Attachments, err = s.attachments.Attach(ctx, created.ID, applicationID, senderID, attachmentIDs)
+			if err != nil {
+				return err
+			}
+			if len(created.Attachments) != len(attachmentIDs) {
+				return common.NewError(common.CodeValidation, "attachment not found or already sent", nil)
+			}
+		}
+		if err := s.analytics.Create(ctx, analytics.Event{Name: "message.sent", UserID: &senderID, Payload: analyticsPayload(ctx, map[string]string{"application_id": applicationID.String(), "message_id": created.ID.String()})}); err != nil {
+			return err
+		}
+		// отправитель видел переписку целиком, поэтому его отметка прочтения сдвигается на своё сообщение
+		if _, err := s.messages.MarkRead(ctx, applicationID, senderID, created.ID); err != nil {
+			return err
+		}
+		if verdict.Hold {
+			if err := s.screen.enqueue(ctx, moderation.ContentMessage, created.ID, senderID, body, verdict.Reasons); err != nil {
+				return err
+			}
+			return publishRealtime(ctx, s.realtime, realtime.TypeMessageCreated, created, senderID)
+		}
+		return s.deliver(ctx, *created, recipientID, app.StudentID, vac)
+	})
 	if err != nil {
 		return nil, err
 	}
-	_ = s.analytics.Create(ctx, analytics.Event{Name: "message.sent", UserID: &senderID, Payload: analyticsPayload(ctx, map[string]string{"application_id": applicationID.String(), "message_id": created.ID.String()})})
 	return created, nil
 }
 
-func (s *MessageService) List(ctx context.Context, applicationID, userID common.UUID, limit, offset int) ([]message.Message, error) {
-	app, err := s.applications.GetByID(ctx, applicationID)
+// deliver рассылает участникам событие о новом сообщении и уведомляет получателя.
+func (s *MessageService) deliver(ctx context.Context, msg message.Message, recipientID, studentID common.UUID, vac *vacancy.Vacancy) error {
+	if err := publishRealtime(ctx, s.realtime, realtime.TypeMessageCreated, msg, studentID, vac.CompanyID); err != nil {
+		return err
+	}
+	return s.notifier.Notify(ctx, notification.Notification{
+		UserID:  recipientID,
+		Type:    notification.TypeMessageReceived,
+		Text:    messageNotificationText(vac.Title, msg.Body, msg.Attachments),
+		Payload: map[string]string{"application_id": msg.ApplicationID.String(), "message_id": msg.ID.String()},
+	})
+}
+
+// ApproveHeld доставляет задержанное сообщение собеседнику. Вызывается в транзакции ModerationService.
+func (s *MessageService) ApproveHeld(ctx context.Context, moderatorID, messageID common.UUID) error {
+	msg, app, vac, err := s.heldMessage(ctx, messageID)
+	if err != nil || msg == nil {
+		return err
+	}
+	if err := s.messages.SetModeration(ctx, msg.ID, ""); err != nil {
+		return err
+	}
+	msg.Moderation = ""
+	loaded := []message.Message{*msg}
+	if err := s.loadAttachments(ctx, loaded); err != nil {
+		return err
+	}
+	msg = &loaded[0]
+	recipientID := vac.CompanyID
+	if msg.SenderID == vac.CompanyID {
+		recipientID = app.StudentID
+	}
+	if err := s.analytics.Create(ctx, analytics.Event{Name: "message.moderation_approved", UserID: &moderatorID, Payload: analyticsPayload(ctx, map[string]string{"message_id": msg.ID.String()})}); err != nil {
+		return err
+	}
+	return s.deliver(ctx, *msg, recipientID, app.StudentID, vac)
+}
+
+// RejectHeld оставляет сообщение недоставленным и сообщает отправителю причину.
+func (s *MessageService) RejectHeld(ctx context.Context, moderatorID, messageID common.UUID, reason string) error {
+	msg, _, vac, err := s.heldMessage(ctx, messageID)
+	if err != nil || msg == nil {
+		return err
+	}
+	if err := s.messages.SetModeration(ctx, msg.ID, message.ModerationRejected); err != nil {
+		return err
+	}
+	if err := s.analytics.Create(ctx, analytics.Event{Name: "message.moderation_rejected", UserID: &moderatorID, Payload: analyticsPayload(ctx, map[string]string{"message_id": msg.ID.String()})}); err != nil {
+		return err
+	}
+	return s.notifier.Notify(ctx, notification.Notification{
+		UserID:  msg.SenderID,
+		Type:    notification.TypeModerationReviewed,
+		Text:    messageModerationRejectedText(vac.Title, reason),
+		Payload: map[string]string{"application_id": msg.ApplicationID.String(), "message_id": msg.ID.String(), "status": string(moderation.StatusRejected)},
+	})
+}
+
+// heldMessage загружает сообщение, ожидающее модерации, вместе с откликом и вакансией; для уже разобранного возвращает nil.
+func (s *MessageService) heldMessage(ctx context.Context, messageID common.UUID) (*message.Message, *application.Application, *vacancy.Vacancy, error) {
+	msg, err := s.messages.GetByID(ctx, messageID)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
+	}
+	if msg.Moderation != message.ModerationPending {
+		return nil, nil, nil, nil
+	}
+	app, err := s.applications.GetByID(ctx, msg.ApplicationID)
+	if err != nil {
+		return nil, nil, nil, err
 	}
 	vac, err := s.vacancies.GetByID(ctx, app.VacancyID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return msg, app, vac, nil
+}
+
+// ThreadMessage — сообщение переписки с признаком, прочитал ли его получатель.
+// Для своих сообщений получатель — собеседник, для чужих и системных — сам пользователь.
+type ThreadMessage struct {
+	message.Message
+	IsRead bool `json:"is_read"`
+}
+
+func (s *MessageService) List(ctx context.Context, applicationID, userID common.UUID, page common.PageRequest) ([]ThreadMessage, *common.Cursor, error) {
+	if _, _, err := s.participants(ctx, applicationID, userID, "user is not allowed to view messages"); err != nil {
+		return nil, nil, err
+	}
+	items, next, err := s.messages.ListByApplication(ctx, applicationID, userID, normalizePage(page))
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.loadAttachments(ctx, items); err != nil {
+		return nil, nil, err
+	}
+	states, err := s.messages.ReadStates(ctx, applicationID)
+	if err != nil {
+		return nil, nil, err
+	}
+	var own, counterpart *message.ReadState
+	for i := range states {
+		if states[i].UserID == userID {
+			own = &states[i]
+		} else {
+			counterpart = &states[i]
+		}
+	}
+	result := make([]ThreadMessage, 0, len(items))
+	for _, msg := range items {
+		reader := own
+		if msg.SenderID == userID {
+			reader = counterpart
+		}
+		result = append(result, ThreadMessage{Message: msg, IsRead: reader != nil && reader.Covers(msg)})
+	}
+	return result, next, nil
+}
+
+// MarkRead отмечает переписку прочитанной до messageID, а без него — до последнего сообщения.
+// Собеседник получает событие message.read, чтобы обновить отметки у себя.
+func (s *MessageService) MarkRead(ctx context.Context, applicationID, userID, messageID common.UUID) (*message.ReadState, error) {
+	app, vac, err := s.participants(ctx, applicationID, userID, "user is not allowed to view messages")
 	if err != nil {
 		return nil, err
 	}
+	if messageID == "" {
+		latest, err := s.messages.LatestByApplication(ctx, applicationID)
+		if err != nil {
+			return nil, err
+		}
+		messageID = latest.ID
+	}
+	var state *message.ReadState
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		state, err = s.messages.MarkRead(ctx, applicationID, userID, messageID)
+		if err != nil {
+			return err
+		}
+		return publishRealtime(ctx, s.realtime, realtime.TypeMessageRead, state, app.StudentID, vac.CompanyID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+func (s *MessageService) participants(ctx context.Context, applicationID, userID common.UUID, deniedMessage string) (*application.Application, *vacancy.Vacancy, error) {
+	app, err := s.applications.GetByID(ctx, applicationID)
+	if err != nil {
+		return nil, nil, err
+	}
+	vac, err := s.vacancies.GetByID(ctx, app.VacancyID)
+	if err != nil {
+		return nil, nil, err
+	}
 	if userID != app.StudentID && userID != vac.CompanyID {
-		return nil, common.NewError(common.CodeForbidden, "user is not allowed to view messages", nil)
+		return nil, nil, common.NewError(common.CodeForbidden, deniedMessage, nil)
 	}
-	return s.messages.ListByApplication(ctx, applicationID, limit, offset)
+	return app, vac, nil
 }
@@ -0,0 +1,217 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/admin"
+	"profzom/internal/domain/moderation"
+)
+
+// contentScreen — автоматическая проверка контента и очередь для задержанного. Нулевое значение пропускает всё.
+type contentScreen struct {
+	checker moderation.Checker
+	queue   moderation.Repository
+}
+
+func (s contentScreen) check(ctx context.Context, contentType moderation.ContentType, authorID common.UUID, text string) (moderation.Verdict, error) {
+	if s.checker == nil || strings.TrimSpace(text) == "" {
+		return moderation.Verdict{}, nil
+	}
+	return s.checker.Check(ctx, moderation.Content{Type: contentType, AuthorID: authorID, Text: text})
+}
+
+// enqueue ставит задержанный объект в очередь; вызывается в транзакции, которая меняет сам объект.
+func (s contentScreen) enqueue(ctx context.Context, contentType moderation.ContentType, contentID, authorID common.UUID, text string, reasons []string) error {
+	_, err := s.queue.Enqueue(ctx, moderation.Item{ContentType: contentType, ContentID: contentID, AuthorID: authorID, Text: text, Reasons: reasons})
+	return err
+}
+
+// ModerationService — очередь модерации для администраторов: задержанные вакансии и сообщения
+// публикуются после approve или остаются скрытыми после reject. Здесь же разбираются жалобы пользователей.
+type ModerationService struct {
+	queue     moderation.Repository
+	reports   moderation.ReportRepository
+	vacancies *VacancyService
+	messages  *MessageService
+	audit     admin.AuditRepository
+	tx        UnitOfWork
+}
+
+func NewModerationService(queue moderation.Repository, reports moderation.ReportRepository, vacancies *VacancyService, messages *MessageService, audit admin.AuditRepository, tx UnitOfWork) *ModerationService {
+	return &ModerationService{queue: queue, reports: reports, vacancies: vacancies, messages: messages, audit: audit, tx: tx}
+}
+
+// ListQueue отдаёт записи очереди, по умолчанию — ожидающие решения.
+func (s *ModerationService) ListQueue(ctx context.Context, status moderation.Status, contentType moderation.ContentType, page common.PageRequest) ([]moderation.Item, *common.Cursor, error) {
+	fields := map[string]string{}
+	switch status {
+	case "":
+		status = moderation.StatusPending
+	case moderation.StatusPending, moderation.StatusApproved, moderation.StatusRejected:
+	default:
+		fields["status"] = "status must be pending, approved or rejected"
+	}
+	switch contentType {
+	case "", moderation.ContentVacancy, moderation.ContentMessage:
+	default:
+		fields["type"] = "type must be vacancy or message"
+	}
+	if len(fields) > 0 {
+		return nil, nil, common.NewValidationError("invalid moderation query", fields)
+	}
+	return s.queue.ListByStatus(ctx, status, contentType, normalizePage(page))
+}
+
+// Approve и Reject принимают seen — updated_at записи, которую видел модератор. Если текст успели
+// изменить или запись уже разобрал другой модератор, решение отклоняется с конфликтом.
+func (s *ModerationService) Approve(ctx context.Context, adminID, itemID common.UUID, seen time.Time) (*moderation.Item, error) {
+	return s.review(ctx, adminID, itemID, seen, moderation.StatusApproved, "")
+}
+
+func (s *ModerationService) Reject(ctx context.Context, adminID, itemID common.UUID, seen time.Time, reason string) (*moderation.Item, error) {
+	reason, err := normalizeAdminReason(reason, true)
+	if err != nil {
+		return nil, err
+	}
+	return s.review(ctx, adminID, itemID, seen, moderation.StatusRejected, reason)
+}
+
+// review применяет решение к объекту, закрывает запись очереди и пишет аудит в одной транзакции.
+// Запись закрывается первой: условный Save пропускает только одно решение по версии seen.
+func (s *ModerationService) review(ctx context.Context, adminID, itemID common.UUID, seen time.Time, status moderation.Status, reason string) (*moderation.Item, error) {
+	if seen.IsZero() {
+		return nil, common.NewValidationError("invalid moderation decision", map[string]string{"updated_at": "updated_at is required"})
+	}
+	var saved *moderation.Item
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		item, err := s.queue.GetByID(ctx, itemID)
+		if err != nil {
+			return err
+		}
+		if item.Status != moderation.StatusPending {
+			return common.NewError(common.CodeConflict, "moderation item is already reviewed", nil)
+		}
+		if !item.UpdatedAt.Equal(seen) {
+			return common.NewError(common.CodeConflict, "moderation item has changed since it was loaded", nil)
+		}
+		now := time.Now().UTC()
+		item.Status = status
+		item.ReviewReason = reason
+		item.ReviewedBy = &adminID
+		item.ReviewedAt = &now
+		saved, err = s.queue.Save(ctx, *item)
+		if err != nil {
+			return err
+		}
+		if err := s.apply(ctx, adminID, *saved); err != nil {
+			return err
+		}
+		return s.audit.Create(ctx, admin.AuditEntry{
+			ID:         common.NewUUID(),
+			AdminID:    &adminID,
+			Action:     "moderation." + string(status),
+			TargetType: string(item.ContentType),
+			TargetID:   item.ContentID.String(),
+			Details:    map[string]string{"item_id": item.ID.String(), "reason": reason},
+			CreatedAt:  now,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+func (s *ModerationService) apply(ctx context.Context, adminID common.UUID, item moderation.Item) error {
+	approved := item.Status == moderation.StatusApproved
+	switch item.ContentType {
+	case moderation.ContentVacancy:
+		if approved {
+			return s.vacancies.ApproveHeld(ctx, adminID, item.ContentID)
+		}
+		return s.vacancies.RejectHeld(ctx, adminID, item.ContentID, item.ReviewReason)
+	case moderation.ContentMessage:
+		if approved {
+			return s.messages.ApproveHeld(ctx, adminID, item.ContentID)
+		}
+		return s.messages.RejectHeld(ctx, adminID, item.ContentID, item.ReviewReason)
+	default:
+		return common.NewError(common.CodeInternal, "unknown moderation content type", nil)
+	}
+}
+
+// ListReports отдаёт жалобы, по умолчанию — открытые.
+func (s *ModerationService) ListReports(ctx context.Context, query moderation.ReportQuery) ([]moderation.Report, *common.Cursor, error) {
+	fields := map[string]string{}
+	switch query.Status {
+	case "":
+		query.Status = moderation.ReportOpen
+	case moderation.ReportOpen, moderation.ReportResolved, moderation.ReportDismissed:
+	default:
+		fields["status"] = "status must be open, resolved or dismissed"
+	}
+	switch query.TargetType {
+	case "", moderation.ReportVacancy, moderation.ReportCompany, moderation.ReportMessage:
+	default:
+		fields["target_type"] = "target_type must be vacancy, company or message"
+	}
+	if len(fields) > 0 {
+		return nil, nil, common.NewValidationError("invalid report query", fields)
+	}
+	page := normalizePage(common.PageRequest{Limit: query.Limit, After: query.After})
+	query.Limit = page.Limit
+	return s.reports.List(ctx, query)
+}
+
+// ResolveReport закрывает жалобу как обоснованную. Меры к нарушителю (снятие вакансии, блокировка аккаунта)
+// администратор принимает отдельными действиями.
+func (s *ModerationService) ResolveReport(ctx context.Context, adminID, reportID common.UUID, resolution string) (*moderation.Report, error) {
+	return s.closeReport(ctx, adminID, reportID, moderation.ReportResolved, resolution)
+}
+
+func (s *ModerationService) DismissReport(ctx context.Context, adminID, reportID common.UUID, resolution string) (*moderation.Report, error) {
+	return s.closeReport(ctx, adminID, reportID, moderation.ReportDismissed, resolution)
+}
+
+func (s *ModerationService) closeReport(ctx context.Context, adminID, reportID common.UUID, status moderation.ReportStatus, resolution string) (*moderation.Report, error) {
+	resolution, err := normalizeAdminReason(resolution, false)
+	if err != nil {
+		return nil, err
+	}
+	report, err := s.reports.GetByID(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+	if report.Status != moderation.ReportOpen {
+		return nil, common.NewError(common.CodeConflict, "report is already closed", nil)
+	}
+	now := time.Now().UTC()
+	report.Status = status
+	report.Resolution = resolution
+	report.ResolvedBy = &adminID
+	report.ResolvedAt = &now
+	var saved *moderation.Report
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		saved, err = s.reports.Save(ctx, *report)
+		if err != nil {
+			return err
+		}
+		return s.audit.Create(ctx, admin.AuditEntry{
+			ID:         common.NewUUID(),
+			AdminID:    &adminID,
+			Action:     "report." + string(status),
+			TargetType: string(report.TargetType),
+			TargetID:   report.TargetID.String(),
+			Details:    map[string]string{"report_id": report.ID.String(), "reason": resolution},
+			CreatedAt:  now,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
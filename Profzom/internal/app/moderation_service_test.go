@@ -0,0 +1,173 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/message"
+	"profzom/internal/domain/moderation"
+	"profzom/internal/domain/notification"
+	"profzom/internal/domain/vacancy"
+)
+
+func TestModerationVacancy_HeldUntilApproved(t *testing.T) {
+	f := newTestEnv(t)
+	created, err := f.vacancySvc.Create(context.Background(), vacancy.Vacancy{
+		CompanyID:    f.companyID,
+		Title:        "Promoter",
+		Type:         "internship",
+		Description:  "Invite friends to our casino",
+		Requirements: []string{"Sociability"},
+		Conditions:   []string{"Remote"},
+		Location:     "Moscow",
+		IsNegotiable: true,
+		Status:       vacancy.StatusPublished,
+	})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if created.Status != vacancy.StatusPendingReview {
+		t.Fatalf("expected status pending_review, got %q", created.Status)
+	}
+	if _, err := f.vacancySvc.Publish(context.Background(), f.companyID, created.ID); !common.Is(err, common.CodeValidation) {
+		t.Fatalf("expected validation error for publishing held vacancy, got %v", err)
+	}
+	item := f.pendingItem(t, created.ID)
+	if len(item.Reasons) != 1 || item.ContentType != moderation.ContentVacancy {
+		t.Fatalf("expected vacancy item with reason, got %+v", item)
+	}
+
+	if _, err := f.moderationSvc.Approve(context.Background(), f.adminID, item.ID, item.UpdatedAt); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	published, _ := f.vacancies.GetByID(context.Background(), created.ID)
+	if published.Status != vacancy.StatusPublished {
+		t.Fatalf("expected status published after approval, got %q", published.Status)
+	}
+	last := f.notifier.sent[len(f.notifier.sent)-1]
+	if last.UserID != f.companyID || last.Type != notification.TypeModerationReviewed {
+		t.Fatalf("expected moderation notification for company, got %+v", last)
+	}
+	if len(f.audit.entries) != 1 || f.audit.entries[0].Action != "moderation.approved" {
+		t.Fatalf("expected moderation.approved audit entry, got %+v", f.audit.entries)
+	}
+	if _, err := f.moderationSvc.Approve(context.Background(), f.adminID, item.ID, item.UpdatedAt); !common.Is(err, common.CodeConflict) {
+		t.Fatalf("expected conflict for reviewed item, got %v", err)
+	}
+}
+
+func TestModerationMessage_HiddenFromRecipientUntilApproved(t *testing.T) {
+	f := newTestEnv(t)
+	held, err := f.messageSvc.Send(context.Background(), f.application.ID, f.companyID, "Earn in our casino")
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if held.Moderation != message.ModerationPending {
+		t.Fatalf("expected held message, got %+v", held)
+	}
+	if len(f.notifier.sent) != 0 {
+		t.Fatalf("expected no notifications for held message, got %+v", f.notifier.sent)
+	}
+	studentView, _, _ := f.messageSvc.List(context.Background(), f.application.ID, f.studentID, common.PageRequest{})
+	companyView, _, _ := f.messageSvc.List(context.Background(), f.application.ID, f.companyID, common.PageRequest{})
+	if len(studentView) != 0 || len(companyView) != 1 {
+		t.Fatalf("expected held message visible only to sender, got student=%d company=%d", len(studentView), len(companyView))
+	}
+
+	item := f.pendingItem(t, held.ID)
+	if _, err := f.moderationSvc.Approve(context.Background(), f.adminID, item.ID, item.UpdatedAt); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	studentView, _, _ = f.messageSvc.List(context.Background(), f.application.ID, f.studentID, common.PageRequest{})
+	if len(studentView) != 1 || studentView[0].Moderation != "" {
+		t.Fatalf("expected delivered message for student, got %+v", studentView)
+	}
+	if len(f.notifier.sent) != 1 || f.notifier.sent[0].UserID != f.studentID || f.notifier.sent[0].Type != notification.TypeMessageReceived {
+		t.Fatalf("expected message.received notification for student, got %+v", f.notifier.sent)
+	}
+}
+
+func TestModerationMessage_RejectNotifiesSender(t *testing.T) {
+	f := newTestEnv(t)
+	held, _ := f.messageSvc.Send(context.Background(), f.application.ID, f.studentID, "casino bonus")
+	item := f.pendingItem(t, held.ID)
+
+	if _, err := f.moderationSvc.Reject(context.Background(), f.adminID, item.ID, item.UpdatedAt, ""); !common.Is(err, common.CodeValidation) {
+		t.Fatalf("expected validation error without reason, got %v", err)
+	}
+	reviewed, err := f.moderationSvc.Reject(context.Background(), f.adminID, item.ID, item.UpdatedAt, "gambling ads")
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if reviewed.Status != moderation.StatusRejected || reviewed.ReviewReason != "gambling ads" {
+		t.Fatalf("expected rejected item with reason, got %+v", reviewed)
+	}
+	stored, _ := f.messages.GetByID(context.Background(), held.ID)
+	if stored.Moderation != message.ModerationRejected {
+		t.Fatalf("expected rejected message, got %q", stored.Moderation)
+	}
+	if len(f.notifier.sent) != 1 || f.notifier.sent[0].UserID != f.studentID || f.notifier.sent[0].Type != notification.TypeModerationReviewed {
+		t.Fatalf("expected moderation notification for sender, got %+v", f.notifier.sent)
+	}
+}
+
+func TestModerationReview_SecondDecisionConflicts(t *testing.T) {
+	f := newTestEnv(t)
+	held, _ := f.messageSvc.Send(context.Background(), f.application.ID, f.studentID, "casino bonus")
+	item := f.pendingItem(t, held.ID)
+
+	if _, err := f.moderationSvc.Approve(context.Background(), f.adminID, item.ID, time.Time{}); !common.Is(err, common.CodeValidation) {
+		t.Fatalf("expected validation error without updated_at, got %v", err)
+	}
+	if _, err := f.moderationSvc.Reject(context.Background(), f.adminID, item.ID, item.UpdatedAt, "gambling ads"); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if _, err := f.moderationSvc.Approve(context.Background(), common.NewUUID(), item.ID, item.UpdatedAt); !common.Is(err, common.CodeConflict) {
+		t.Fatalf("expected conflict for second decision, got %v", err)
+	}
+	stored, _ := f.messages.GetByID(context.Background(), held.ID)
+	if stored.Moderation != message.ModerationRejected {
+		t.Fatalf("expected message to stay rejected, got %q", stored.Moderation)
+	}
+	if len(f.audit.entries) != 1 {
+		t.Fatalf("expected single audit entry, got %+v", f.audit.entries)
+	}
+}
+
+func TestModerationReview_ConflictsWhenTextChangedAfterLoad(t *testing.T) {
+	f := newTestEnv(t)
+	draft := vacancy.Vacancy{
+		CompanyID:    f.companyID,
+		Title:        "Promoter",
+		Type:         "internship",
+		Description:  "Invite friends to our casino",
+		Requirements: []string{"Sociability"},
+		Conditions:   []string{"Remote"},
+		Location:     "Moscow",
+		IsNegotiable: true,
+		Status:       vacancy.StatusPublished,
+	}
+	created, err := f.vacancySvc.Create(context.Background(), draft)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	seen := f.pendingItem(t, created.ID)
+
+	if _, err := f.queue.Enqueue(context.Background(), moderation.Item{ContentType: moderation.ContentVacancy, ContentID: created.ID, Text: "Invite friends to our casino, now with bigger bonuses"}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if _, err := f.moderationSvc.Approve(context.Background(), f.adminID, seen.ID, seen.UpdatedAt); !common.Is(err, common.CodeConflict) {
+		t.Fatalf("expected conflict for changed text, got %v", err)
+	}
+	stored, _ := f.vacancies.GetByID(context.Background(), created.ID)
+	if stored.Status != vacancy.StatusPendingReview {
+		t.Fatalf("expected vacancy to stay pending_review, got %q", stored.Status)
+	}
+
+	current := f.pendingItem(t, created.ID)
+	if _, err := f.moderationSvc.Approve(context.Background(), f.adminID, current.ID, current.UpdatedAt); err != nil {
+		t.Fatalf("expected nil error for current version, got %v", err)
+	}
+}
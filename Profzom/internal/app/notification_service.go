@@ -0,0 +1,33 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/notification"
+)
+
+type NotificationService struct {
+	repo notification.Repository
+}
+
+func NewNotificationService(repo notification.Repository) *NotificationService {
+	return &NotificationService{repo: repo}
+}
+
+func (s *NotificationService) List(ctx context.Context, userID common.UUID, unreadOnly bool, page common.PageRequest) ([]notification.Notification, *common.Cursor, error) {
+	return s.repo.List(ctx, userID, unreadOnly, normalizePage(page))
+}
+
+func (s *NotificationService) MarkRead(ctx context.Context, userID, id common.UUID) error {
+	return s.repo.MarkRead(ctx, userID, id, time.Now().UTC())
+}
+
+func (s *NotificationService) MarkAllRead(ctx context.Context, userID common.UUID) (int, error) {
+	return s.repo.MarkAllRead(ctx, userID, time.Now().UTC())
+}
+
+func (s *NotificationService) UnreadCount(ctx context.Context, userID common.UUID) (int, error) {
+	return s.repo.CountUnread(ctx, userID)
+}
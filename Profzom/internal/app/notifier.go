@@ -0,0 +1,155 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"profzom/internal/domain/application"
+	"profzom/internal/domain/message"
+	"profzom/internal/domain/notification"
+	"profzom/internal/domain/profile"
+	"profzom/internal/domain/savedsearch"
+	"profzom/internal/integration/otpbot"
+)
+
+const (
+	notificationTimeout     = 5 * time.Second
+	notificationPreviewSize = 200
+	savedSearchDigestLines  = 10
+)
+
+// Notifier доставляет пользователю короткое уведомление вне приложения.
+// Сервисы вызывают его внутри UnitOfWork, поэтому в рабочей сборке это OutboxPublisher, а реальная отправка — в диспетчере.
+type Notifier interface {
+	Notify(ctx context.Context, n notification.Notification) error
+}
+
+type noopNotifier struct{}
+
+func (noopNotifier) Notify(context.Context, notification.Notification) error { return nil }
+
+// TelegramNotifier отправляет уведомление через OTP-бота. Непривязанный чат и отключённые уведомления не считаются ошибкой.
+type TelegramNotifier struct {
+	bot    otpbot.Client
+	logger Logger
+}
+
+func NewTelegramNotifier(bot otpbot.Client, logger Logger) *TelegramNotifier {
+	return &TelegramNotifier{bot: bot, logger: logger}
+}
+
+func (n *TelegramNotifier) Notify(ctx context.Context, notice notification.Notification) error {
+	sendCtx, cancel := context.WithTimeout(ctx, notificationTimeout)
+	defer cancel()
+	err := n.bot.Notify(sendCtx, notice.UserID.String(), notice.Text)
+	if err == nil || errors.Is(err, otpbot.ErrNotLinked) || errors.Is(err, otpbot.ErrOptedOut) {
+		return nil
+	}
+	if n.logger != nil {
+		n.logger.Error(fmt.Sprintf("telegram notification failed user_id=%s error=%v", notice.UserID, err))
+	}
+	return err
+}
+
+func statusNotificationText(status application.Status, vacancyTitle, feedback string) (string, bool) {
+	switch status {
+	case application.StatusInvited:
+		return fmt.Sprintf("Good news! You are invited to an interview for %q. Open ProfZoom for details.", vacancyTitle), true
+	case application.StatusAccepted:
+		return fmt.Sprintf("Congratulations! Your application for %q was accepted.", vacancyTitle), true
+	case application.StatusRejected:
+		text := fmt.Sprintf("Your application for %q was declined.", vacancyTitle)
+		if feedback != "" {
+			text += " Feedback: " + feedback
+		}
+		return text, true
+	default:
+		return "", false
+	}
+}
+
+func withdrawalNotificationText(vacancyTitle string) string {
+	return fmt.Sprintf("A student withdrew their application for %q.", vacancyTitle)
+}
+
+func applicationReceivedNotificationText(vacancyTitle string) string {
+	return fmt.Sprintf("New application for %q.", vacancyTitle)
+}
+
+func vacancyClosedNotificationText(vacancyTitle string) string {
+	return fmt.Sprintf("The vacancy %q you applied to is no longer open.", vacancyTitle)
+}
+
+func vacancyUnpublishedNotificationText(vacancyTitle, reason string) string {
+	return fmt.Sprintf("Your vacancy %q was unpublished by a moderator: %s", vacancyTitle, reason)
+}
+
+func vacancyModerationText(vacancyTitle string, approved bool, reason string) string {
+	if approved {
+		return fmt.Sprintf("Your vacancy %q passed moderation and is now published.", vacancyTitle)
+	}
+	return fmt.Sprintf("Your vacancy %q did not pass moderation: %s. Edit it and publish again.", vacancyTitle, reason)
+}
+
+func messageModerationRejectedText(vacancyTitle, reason string) string {
+	return fmt.Sprintf("Your message about %q was not delivered: %s", vacancyTitle, reason)
+}
+
+func invitationNotificationText(vacancyTitle string) string {
+	return fmt.Sprintf("A company invites you to apply for %q. Open ProfZoom to accept or decline.", vacancyTitle)
+}
+
+func invitationAcceptedNotificationText(vacancyTitle string) string {
+	return fmt.Sprintf("A student accepted your invitation for %q.", vacancyTitle)
+}
+
+func invitationDeclinedNotificationText(vacancyTitle string) string {
+	return fmt.Sprintf("A student declined your invitation for %q.", vacancyTitle)
+}
+
+func verificationNotificationText(status profile.VerificationStatus, legalName, reason string) string {
+	if status == profile.VerificationVerified {
+		return fmt.Sprintf("%s is verified. Your vacancies now show the verified badge.", legalName)
+	}
+	return fmt.Sprintf("Verification of %s was declined: %s. Fix the details and submit again.", legalName, reason)
+}
+
+func savedSearchDigestText(matches []savedsearch.Match) string {
+	var builder strings.Builder
+	if len(matches) == 1 {
+		builder.WriteString("New vacancy for your saved search:")
+	} else {
+		fmt.Fprintf(&builder, "%d new vacancies for your saved searches:", len(matches))
+	}
+	for i, match := range matches {
+		if i == savedSearchDigestLines {
+			fmt.Fprintf(&builder, "\n…and %d more in ProfZoom.", len(matches)-i)
+			break
+		}
+		fmt.Fprintf(&builder, "\n• %s (%s)", match.VacancyTitle, match.SearchName)
+	}
+	return builder.String()
+}
+
+func messageNotificationText(vacancyTitle, body string, attachments []message.Attachment) string {
+	if body == "" && len(attachments) > 0 {
+		names := make([]string, 0, len(attachments))
+		for _, attachment := range attachments {
+			names = append(names, attachment.FileName)
+		}
+		body = "sent " + strings.Join(names, ", ")
+	}
+	return fmt.Sprintf("New message about %q: %s", vacancyTitle, previewText(body, notificationPreviewSize))
+}
+
+func previewText(value string, limit int) string {
+	if utf8.RuneCountInString(value) <= limit {
+		return value
+	}
+	runes := []rune(value)
+	return string(runes[:limit]) + "…"
+}
@@ -0,0 +1,131 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/analytics"
+	"profzom/internal/domain/notification"
+	"profzom/internal/domain/outbox"
+)
+
+// OutboxPublisher кладёт аналитические события и уведомления в outbox вместо прямой записи.
+// Он реализует analytics.Repository и Notifier, поэтому сервисы работают с ним как раньше,
+// но внутри UnitOfWork событие фиксируется или откатывается вместе с доменной записью.
+type OutboxPublisher struct {
+	repo outbox.Repository
+}
+
+func NewOutboxPublisher(repo outbox.Repository) *OutboxPublisher {
+	return &OutboxPublisher{repo: repo}
+}
+
+type analyticsMessage struct {
+	ID        common.UUID     `json:"id"`
+	UserID    *common.UUID    `json:"user_id,omitempty"`
+	Name      string          `json:"name"`
+	Payload   json.RawMessage `json:"payload,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type notificationMessage struct {
+	ID        common.UUID       `json:"id"`
+	UserID    common.UUID       `json:"user_id"`
+	Type      notification.Type `json:"type,omitempty"`
+	Text      string            `json:"text"`
+	Payload   map[string]string `json:"payload,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+func (p *OutboxPublisher) Create(ctx context.Context, event analytics.Event) error {
+	if event.ID == "" {
+		event.ID = common.NewUUID()
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now().UTC()
+	}
+	payload, err := json.Marshal(analyticsMessage{ID: event.ID, UserID: event.UserID, Name: event.Name, Payload: event.Payload, CreatedAt: event.CreatedAt})
+	if err != nil {
+		return common.NewError(common.CodeInternal, "failed to encode analytics event", err)
+	}
+	return p.repo.Enqueue(ctx, outbox.Message{Topic: outbox.TopicAnalytics, Payload: payload})
+}
+
+func (p *OutboxPublisher) Notify(ctx context.Context, n notification.Notification) error {
+	if n.ID == "" {
+		n.ID = common.NewUUID()
+	}
+	if n.CreatedAt.IsZero() {
+		n.CreatedAt = time.Now().UTC()
+	}
+	payload, err := json.Marshal(notificationMessage{ID: n.ID, UserID: n.UserID, Type: n.Type, Text: n.Text, Payload: n.Payload, CreatedAt: n.CreatedAt})
+	if err != nil {
+		return common.NewError(common.CodeInternal, "failed to encode notification", err)
+	}
+	return p.repo.Enqueue(ctx, outbox.Message{Topic: outbox.TopicNotification, Payload: payload})
+}
+
+// AnalyticsSink переносит события из outbox в analytics_events.
+type AnalyticsSink struct {
+	repo analytics.Repository
+}
+
+func NewAnalyticsSink(repo analytics.Repository) *AnalyticsSink {
+	return &AnalyticsSink{repo: repo}
+}
+
+func (s *AnalyticsSink) Deliver(ctx context.Context, message outbox.Message) error {
+	var decoded analyticsMessage
+	if err := json.Unmarshal(message.Payload, &decoded); err != nil {
+		return common.NewError(common.CodeInternal, "failed to decode analytics event", err)
+	}
+	return s.repo.Create(ctx, analytics.Event{ID: decoded.ID, UserID: decoded.UserID, Name: decoded.Name, Payload: decoded.Payload, CreatedAt: decoded.CreatedAt})
+}
+
+// NotificationSink доставляет уведомления из outbox через синхронный Notifier (например, TelegramNotifier).
+type NotificationSink struct {
+	notifier Notifier
+}
+
+func NewNotificationSink(notifier Notifier) *NotificationSink {
+	return &NotificationSink{notifier: notifier}
+}
+
+func (s *NotificationSink) Deliver(ctx context.Context, message outbox.Message) error {
+	decoded, err := decodeNotificationMessage(message)
+	if err != nil {
+		return err
+	}
+	return s.notifier.Notify(ctx, decoded)
+}
+
+// NotificationFeedSink сохраняет уведомления из outbox в ленту пользователя; ID уведомления задаётся при публикации,
+// поэтому повторная доставка не создаёт дублей.
+type NotificationFeedSink struct {
+	repo notification.Repository
+}
+
+func NewNotificationFeedSink(repo notification.Repository) *NotificationFeedSink {
+	return &NotificationFeedSink{repo: repo}
+}
+
+func (s *NotificationFeedSink) Deliver(ctx context.Context, message outbox.Message) error {
+	decoded, err := decodeNotificationMessage(message)
+	if err != nil {
+		return err
+	}
+	if decoded.ID == "" {
+		decoded.ID = message.ID
+	}
+	return s.repo.Create(ctx, decoded)
+}
+
+func decodeNotificationMessage(message outbox.Message) (notification.Notification, error) {
+	var decoded notificationMessage
+	if err := json.Unmarshal(message.Payload, &decoded); err != nil {
+		return notification.Notification{}, common.NewError(common.CodeInternal, "failed to decode notification", err)
+	}
+	return notification.Notification{ID: decoded.ID, UserID: decoded.UserID, Type: decoded.Type, Text: decoded.Text, Payload: decoded.Payload, CreatedAt: decoded.CreatedAt}, nil
+}
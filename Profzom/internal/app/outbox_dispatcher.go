@@ -0,0 +1,184 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"profzom/internal/domain/outbox"
+)
+
+const (
+	outboxBatchSize      = 50
+	outboxLease          = time.Minute
+	outboxMaxAttempts    = 10
+	outboxBaseBackoff    = 5 * time.Second
+	outboxMaxBackoff     = 30 * time.Minute
+	outboxDeliveryTTL    = 15 * time.Second
+	outboxRetainDelivery = 7 * 24 * time.Hour
+)
+
+// OutboxSink получает записи outbox одной темы. Доставка «как минимум один раз»: sink должен быть идемпотентным.
+type OutboxSink interface {
+	Deliver(ctx context.Context, message outbox.Message) error
+}
+
+// OutboxDispatcher периодически забирает записи outbox и раздаёт их зарегистрированным sink'ам.
+// Доставка учитывается по каждому sink'у: ошибка одного откладывает запись с экспоненциальной задержкой,
+// но при повторе запись получают только те sink'и, которые её ещё не приняли. После outboxMaxAttempts запись помечается failed.
+type OutboxDispatcher struct {
+	repo     outbox.Repository
+	sinks    map[outbox.Topic][]namedOutboxSink
+	interval time.Duration
+	logger   Logger
+}
+
+type namedOutboxSink struct {
+	name string
+	sink OutboxSink
+}
+
+func NewOutboxDispatcher(repo outbox.Repository, interval time.Duration, logger Logger) *OutboxDispatcher {
+	return &OutboxDispatcher{repo: repo, sinks: make(map[outbox.Topic][]namedOutboxSink), interval: interval, logger: logger}
+}
+
+// Register подключает sink к теме под именем name; вызывается до Run. Имя хранится в записи outbox
+// как отметка о доставке, поэтому оно должно быть уникальным в пределах темы и не меняться между релизами.
+func (d *OutboxDispatcher) Register(topic outbox.Topic, name string, sink OutboxSink) {
+	d.sinks[topic] = append(d.sinks[topic], namedOutboxSink{name: name, sink: sink})
+}
+
+func (d *OutboxDispatcher) Run(ctx context.Context) {
+	if d.interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	lastPurge := time.Time{}
+	for {
+		for {
+			processed, err := d.DispatchOnce(ctx)
+			if err != nil {
+				d.logError(fmt.Sprintf("outbox dispatch failed: %v", err))
+				break
+			}
+			if processed < outboxBatchSize {
+				break
+			}
+		}
+		if time.Since(lastPurge) > time.Hour {
+			if _, err := d.repo.PurgeDelivered(ctx, time.Now().UTC().Add(-outboxRetainDelivery)); err != nil {
+				d.logError(fmt.Sprintf("outbox purge failed: %v", err))
+			}
+			lastPurge = time.Now()
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchOnce обрабатывает одну пачку записей и возвращает их количество.
+func (d *OutboxDispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	messages, err := d.repo.Claim(ctx, now, outboxBatchSize, outboxLease)
+	if err != nil {
+		return 0, err
+	}
+	d.dispatch(ctx, messages, now.Add(outboxLease))
+	return len(messages), nil
+}
+
+// dispatch раздаёт пачку, пока аренда не истекла. Доставка в sink начинается, только если она успеет
+// закончиться до leaseEnd; остальные записи остаются захваченными и после аренды достанутся следующему Claim,
+// причём уже принятые sink'и их не получат повторно.
+func (d *OutboxDispatcher) dispatch(ctx context.Context, messages []outbox.Message, leaseEnd time.Time) {
+	for _, message := range messages {
+		err := d.deliver(ctx, message, leaseEnd)
+		if errors.Is(err, errOutboxLeaseExhausted) {
+			return
+		}
+		if err != nil {
+			d.fail(ctx, message, err)
+			continue
+		}
+		if err := d.repo.MarkDelivered(ctx, message.ID, time.Now().UTC()); err != nil {
+			d.logError(fmt.Sprintf("outbox mark delivered failed id=%s: %v", message.ID, err))
+		}
+	}
+}
+
+var errOutboxLeaseExhausted = errors.New("outbox lease exhausted")
+
+// deliver раздаёт запись sink'ам, которые её ещё не приняли, и отмечает каждую успешную доставку.
+// У каждого sink'а свой таймаут outboxDeliveryTTL. Ошибка одного sink'а не мешает остальным; возвращается первая из ошибок.
+func (d *OutboxDispatcher) deliver(ctx context.Context, message outbox.Message, leaseEnd time.Time) error {
+	sinks, ok := d.sinks[message.Topic]
+	if !ok {
+		return fmt.Errorf("no sink registered for topic %q", message.Topic)
+	}
+	delivered := make(map[string]bool, len(message.DeliveredSinks))
+	for _, name := range message.DeliveredSinks {
+		delivered[name] = true
+	}
+	var firstErr error
+	for _, sink := range sinks {
+		if delivered[sink.name] {
+			continue
+		}
+		if time.Until(leaseEnd) < outboxDeliveryTTL {
+			return errOutboxLeaseExhausted
+		}
+		if err := d.deliverTo(ctx, sink, message); err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("sink %s: %w", sink.name, err)
+			}
+			continue
+		}
+		if err := d.repo.MarkSinkDelivered(ctx, message.ID, sink.name); err != nil {
+			d.logError(fmt.Sprintf("outbox mark sink delivered failed id=%s sink=%s: %v", message.ID, sink.name, err))
+		}
+	}
+	return firstErr
+}
+
+func (d *OutboxDispatcher) deliverTo(ctx context.Context, sink namedOutboxSink, message outbox.Message) error {
+	ctx, cancel := context.WithTimeout(ctx, outboxDeliveryTTL)
+	defer cancel()
+	return sink.sink.Deliver(ctx, message)
+}
+
+func (d *OutboxDispatcher) fail(ctx context.Context, message outbox.Message, cause error) {
+	attempts := message.Attempts + 1
+	now := time.Now().UTC()
+	if attempts >= outboxMaxAttempts {
+		d.logError(fmt.Sprintf("outbox message failed permanently id=%s topic=%s attempts=%d: %v", message.ID, message.Topic, attempts, cause))
+		if err := d.repo.MarkFailed(ctx, message.ID, attempts, now, cause.Error()); err != nil {
+			d.logError(fmt.Sprintf("outbox mark failed failed id=%s: %v", message.ID, err))
+		}
+		return
+	}
+	if err := d.repo.Reschedule(ctx, message.ID, attempts, now.Add(outboxBackoff(attempts)), cause.Error()); err != nil {
+		d.logError(fmt.Sprintf("outbox reschedule failed id=%s: %v", message.ID, err))
+	}
+}
+
+func outboxBackoff(attempts int) time.Duration {
+	backoff := outboxBaseBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= outboxMaxBackoff {
+			return outboxMaxBackoff
+		}
+	}
+	return backoff
+}
+
+func (d *OutboxDispatcher) logError(msg string) {
+	if d.logger != nil {
+		d.logger.Error(msg)
+	}
+}
@@ -0,0 +1,345 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/analytics"
+	"profzom/internal/domain/notification"
+	"profzom/internal/domain/outbox"
+)
+
+type fakeOutboxRepo struct {
+	mu        sync.Mutex
+	pending   []outbox.Message
+	delivered []common.UUID
+	failed    []common.UUID
+}
+
+func (r *fakeOutboxRepo) Enqueue(ctx context.Context, message outbox.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	message.ID = common.NewUUID()
+	message.CreatedAt = time.Now().UTC()
+	message.AvailableAt = message.CreatedAt
+	r.pending = append(r.pending, message)
+	return nil
+}
+
+func (r *fakeOutboxRepo) Claim(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]outbox.Message, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var claimed []outbox.Message
+	for i := range r.pending {
+		if len(claimed) == limit {
+			break
+		}
+		if !r.pending[i].AvailableAt.After(now) {
+			claimed = append(claimed, r.pending[i])
+			r.pending[i].AvailableAt = now.Add(lease)
+		}
+	}
+	return claimed, nil
+}
+
+func (r *fakeOutboxRepo) remove(id common.UUID) {
+	for i := range r.pending {
+		if r.pending[i].ID == id {
+			r.pending = append(r.pending[:i], r.pending[i+1:]...)
+			return
+		}
+	}
+}
+
+func (r *fakeOutboxRepo) MarkSinkDelivered(ctx context.Context, id common.UUID, sink string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.pending {
+		if r.pending[i].ID == id {
+			r.pending[i].DeliveredSinks = append(r.pending[i].DeliveredSinks, sink)
+		}
+	}
+	return nil
+}
+
+func (r *fakeOutboxRepo) MarkDelivered(ctx context.Context, id common.UUID, deliveredAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.remove(id)
+	r.delivered = append(r.delivered, id)
+	return nil
+}
+
+func (r *fakeOutboxRepo) Reschedule(ctx context.Context, id common.UUID, attempts int, availableAt time.Time, lastError string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.pending {
+		if r.pending[i].ID == id {
+			r.pending[i].Attempts = attempts
+			r.pending[i].AvailableAt = availableAt
+			r.pending[i].LastError = lastError
+		}
+	}
+	return nil
+}
+
+func (r *fakeOutboxRepo) MarkFailed(ctx context.Context, id common.UUID, attempts int, failedAt time.Time, lastError string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.remove(id)
+	r.failed = append(r.failed, id)
+	return nil
+}
+
+func (r *fakeOutboxRepo) PurgeDelivered(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+type recordingAnalyticsRepo struct {
+	events []analytics.Event
+}
+
+func (r *recordingAnalyticsRepo) Create(ctx context.Context, event analytics.Event) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+type failingSink struct{}
+
+func (failingSink) Deliver(ctx context.Context, message outbox.Message) error {
+	return errors.New("sink unavailable")
+}
+
+func TestOutboxDispatcher_DeliversPublishedEvents(t *testing.T) {
+	repo := &fakeOutboxRepo{}
+	publisher := NewOutboxPublisher(repo)
+	events := &recordingAnalyticsRepo{}
+	notifier := &recordingNotifier{}
+	dispatcher := NewOutboxDispatcher(repo, time.Second, nil)
+	dispatcher.Register(outbox.TopicAnalytics, "analytics", NewAnalyticsSink(events))
+	dispatcher.Register(outbox.TopicNotification, "telegram", NewNotificationSink(notifier))
+	userID := common.NewUUID()
+
+	if err := publisher.Create(context.Background(), analytics.Event{Name: "vacancy.created", UserID: &userID, Payload: []byte(`{"vacancy_id":"v1"}`)}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if err := publisher.Notify(context.Background(), notification.Notification{UserID: userID, Text: "Hello"}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	processed, err := dispatcher.DispatchOnce(context.Background())
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if processed != 2 || len(repo.delivered) != 2 || len(repo.pending) != 0 {
+		t.Fatalf("expected 2 delivered messages, got processed=%d delivered=%d pending=%d", processed, len(repo.delivered), len(repo.pending))
+	}
+	if len(events.events) != 1 || events.events[0].Name != "vacancy.created" || events.events[0].ID == "" || string(events.events[0].Payload) != `{"vacancy_id":"v1"}` {
+		t.Fatalf("unexpected analytics events %+v", events.events)
+	}
+	if len(notifier.sent) != 1 || notifier.sent[0].UserID != userID || notifier.sent[0].Text != "Hello" {
+		t.Fatalf("unexpected notifications %+v", notifier.sent)
+	}
+}
+
+func TestOutboxDispatcher_ReschedulesFailedDelivery(t *testing.T) {
+	repo := &fakeOutboxRepo{}
+	dispatcher := NewOutboxDispatcher(repo, time.Second, nil)
+	dispatcher.Register(outbox.TopicNotification, "webhook", failingSink{})
+	if err := NewOutboxPublisher(repo).Notify(context.Background(), notification.Notification{UserID: common.NewUUID(), Text: "Hello"}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if _, err := dispatcher.DispatchOnce(context.Background()); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(repo.pending) != 1 || repo.pending[0].Attempts != 1 || repo.pending[0].LastError != "sink webhook: sink unavailable" {
+		t.Fatalf("expected rescheduled message, got %+v", repo.pending)
+	}
+	if !repo.pending[0].AvailableAt.After(time.Now()) {
+		t.Fatalf("expected delivery to be postponed, got %v", repo.pending[0].AvailableAt)
+	}
+	processed, _ := dispatcher.DispatchOnce(context.Background())
+	if processed != 0 {
+		t.Fatalf("expected postponed message to be skipped, got %d", processed)
+	}
+}
+
+func TestOutboxDispatcher_MarksFailedAfterMaxAttempts(t *testing.T) {
+	repo := &fakeOutboxRepo{}
+	dispatcher := NewOutboxDispatcher(repo, time.Second, nil)
+	dispatcher.Register(outbox.TopicNotification, "webhook", failingSink{})
+	if err := NewOutboxPublisher(repo).Notify(context.Background(), notification.Notification{UserID: common.NewUUID(), Text: "Hello"}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	repo.pending[0].Attempts = outboxMaxAttempts - 1
+
+	if _, err := dispatcher.DispatchOnce(context.Background()); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(repo.failed) != 1 || len(repo.pending) != 0 {
+		t.Fatalf("expected message to be marked failed, got failed=%d pending=%d", len(repo.failed), len(repo.pending))
+	}
+}
+
+// flakySink отказывает, пока down выставлен, и считает успешные доставки.
+type flakySink struct {
+	down      bool
+	delivered int
+}
+
+func (s *flakySink) Deliver(ctx context.Context, message outbox.Message) error {
+	if s.down {
+		return errors.New("sink unavailable")
+	}
+	s.delivered++
+	return nil
+}
+
+func TestOutboxDispatcher_RetriesOnlyFailedSinks(t *testing.T) {
+	repo := &fakeOutboxRepo{}
+	feed := &flakySink{}
+	webhook := &flakySink{down: true}
+	dispatcher := NewOutboxDispatcher(repo, time.Second, nil)
+	dispatcher.Register(outbox.TopicNotification, "notification_feed", feed)
+	dispatcher.Register(outbox.TopicNotification, "webhook", webhook)
+	if err := NewOutboxPublisher(repo).Notify(context.Background(), notification.Notification{UserID: common.NewUUID(), Text: "Hello"}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if _, err := dispatcher.DispatchOnce(context.Background()); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if feed.delivered != 1 || len(repo.pending) != 1 || repo.pending[0].Attempts != 1 {
+		t.Fatalf("expected feed delivery and rescheduled message, got feed=%d pending=%+v", feed.delivered, repo.pending)
+	}
+	webhook.down = false
+	repo.pending[0].AvailableAt = time.Now().UTC()
+
+	if _, err := dispatcher.DispatchOnce(context.Background()); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if feed.delivered != 1 || webhook.delivered != 1 {
+		t.Fatalf("expected one delivery per sink, got feed=%d webhook=%d", feed.delivered, webhook.delivered)
+	}
+	if len(repo.delivered) != 1 || len(repo.pending) != 0 {
+		t.Fatalf("expected message delivered, got delivered=%d pending=%d", len(repo.delivered), len(repo.pending))
+	}
+}
+
+// deadlineSink запоминает, сколько времени оставалось до дедлайна контекста в момент доставки.
+type deadlineSink struct {
+	delay     time.Duration
+	remaining []time.Duration
+}
+
+func (s *deadlineSink) Deliver(ctx context.Context, message outbox.Message) error {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return errors.New("delivery context has no deadline")
+	}
+	s.remaining = append(s.remaining, time.Until(deadline))
+	time.Sleep(s.delay)
+	return nil
+}
+
+func TestOutboxDispatcher_EachSinkGetsOwnTimeout(t *testing.T) {
+	repo := &fakeOutboxRepo{}
+	slow := &deadlineSink{delay: 50 * time.Millisecond}
+	next := &deadlineSink{}
+	dispatcher := NewOutboxDispatcher(repo, time.Second, nil)
+	dispatcher.Register(outbox.TopicNotification, "notification_feed", slow)
+	dispatcher.Register(outbox.TopicNotification, "webhook", next)
+	if err := NewOutboxPublisher(repo).Notify(context.Background(), notification.Notification{UserID: common.NewUUID(), Text: "Hello"}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if _, err := dispatcher.DispatchOnce(context.Background()); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(next.remaining) != 1 || next.remaining[0] < outboxDeliveryTTL-slow.delay/2 {
+		t.Fatalf("expected full delivery timeout for second sink, got %v", next.remaining)
+	}
+}
+
+func TestOutboxDispatcher_StopsBeforeLeaseExpires(t *testing.T) {
+	repo := &fakeOutboxRepo{}
+	sink := &flakySink{}
+	dispatcher := NewOutboxDispatcher(repo, time.Second, nil)
+	dispatcher.Register(outbox.TopicNotification, "notification_feed", sink)
+	if err := NewOutboxPublisher(repo).Notify(context.Background(), notification.Notification{UserID: common.NewUUID(), Text: "Hello"}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	messages, _ := repo.Claim(context.Background(), time.Now().UTC(), outboxBatchSize, outboxLease)
+
+	dispatcher.dispatch(context.Background(), messages, time.Now().Add(outboxDeliveryTTL/2))
+	if sink.delivered != 0 || len(repo.delivered) != 0 || repo.pending[0].Attempts != 0 {
+		t.Fatalf("expected message to stay claimed without delivery attempts, got delivered=%d pending=%+v", sink.delivered, repo.pending)
+	}
+}
+
+func TestOutboxBackoff_IsCapped(t *testing.T) {
+	if got := outboxBackoff(1); got != outboxBaseBackoff {
+		t.Fatalf("expected %v, got %v", outboxBaseBackoff, got)
+	}
+	if got := outboxBackoff(3); got != 4*outboxBaseBackoff {
+		t.Fatalf("expected %v, got %v", 4*outboxBaseBackoff, got)
+	}
+	if got := outboxBackoff(50); got != outboxMaxBackoff {
+		t.Fatalf("expected %v, got %v", outboxMaxBackoff, got)
+	}
+}
+
+type fakeNotificationRepo struct {
+	items map[common.UUID]notification.Notification
+}
+
+func (r *fakeNotificationRepo) Create(ctx context.Context, n notification.Notification) error {
+	if _, ok := r.items[n.ID]; !ok {
+		r.items[n.ID] = n
+	}
+	return nil
+}
+
+func (r *fakeNotificationRepo) List(ctx context.Context, userID common.UUID, unreadOnly bool, page common.PageRequest) ([]notification.Notification, *common.Cursor, error) {
+	return nil, nil, nil
+}
+
+func (r *fakeNotificationRepo) MarkRead(ctx context.Context, userID, id common.UUID, readAt time.Time) error {
+	return nil
+}
+
+func (r *fakeNotificationRepo) MarkAllRead(ctx context.Context, userID common.UUID, readAt time.Time) (int, error) {
+	return 0, nil
+}
+
+func (r *fakeNotificationRepo) CountUnread(ctx context.Context, userID common.UUID) (int, error) {
+	return len(r.items), nil
+}
+
+func TestNotificationFeedSink_RedeliveryIsIdempotent(t *testing.T) {
+	repo := &fakeOutboxRepo{}
+	feed := &fakeNotificationRepo{items: make(map[common.UUID]notification.Notification)}
+	sink := NewNotificationFeedSink(feed)
+	userID := common.NewUUID()
+	if err := NewOutboxPublisher(repo).Notify(context.Background(), notification.Notification{UserID: userID, Type: notification.TypeMessageReceived, Text: "Hello", Payload: map[string]string{"application_id": "a1"}}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := sink.Deliver(context.Background(), repo.pending[0]); err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+	}
+	if len(feed.items) != 1 {
+		t.Fatalf("expected 1 stored notification, got %d", len(feed.items))
+	}
+	for _, stored := range feed.items {
+		if stored.UserID != userID || stored.Type != notification.TypeMessageReceived || stored.Payload["application_id"] != "a1" {
+			t.Fatalf("unexpected stored notification %+v", stored)
+		}
+	}
+}
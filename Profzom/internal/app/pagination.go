@@ -0,0 +1,18 @@
+package app
+
+import "profzom/internal/common"
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+func normalizePage(page common.PageRequest) common.PageRequest {
+	if page.Limit <= 0 {
+		page.Limit = defaultPageLimit
+	}
+	if page.Limit > maxPageLimit {
+		page.Limit = maxPageLimit
+	}
+	return page
+}
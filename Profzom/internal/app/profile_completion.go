@@ -6,8 +6,32 @@ import (
 	"profzom/internal/domain/profile"
 )
 
-func StudentCompletion(p profile.StudentProfile) int {
-	total := 6
+// StudentCompletion — заполненность профиля в процентах. Основные поля дают 60 (по 10 за каждое),
+// разделы резюме — 40: опыт 15, образование 10, ссылки, языки и сертификаты по 5.
+func StudentCompletion(p profile.StudentProfile, r profile.Resume) int {
+	score := studentRequiredFilled(p) * 10
+	if len(r.Experience) > 0 {
+		score += 15
+	}
+	if len(r.Education) > 0 {
+		score += 10
+	}
+	if len(r.Links) > 0 {
+		score += 5
+	}
+	if len(r.Languages) > 0 {
+		score += 5
+	}
+	if len(r.Certificates) > 0 {
+		score += 5
+	}
+	return score
+}
+
+const studentRequiredFields = 6
+
+// studentRequiredFilled считает заполненные обязательные поля профиля; резюме для отклика не обязательно.
+func studentRequiredFilled(p profile.StudentProfile) int {
 	filled := 0
 	if strings.TrimSpace(p.Name) != "" {
 		filled++
@@ -27,7 +51,7 @@ func StudentCompletion(p profile.StudentProfile) int {
 	if strings.TrimSpace(p.About) != "" {
 		filled++
 	}
-	return int(float64(filled) / float64(total) * 100)
+	return filled
 }
 
 func CompanyCompletion(p profile.CompanyProfile) int {
@@ -49,7 +73,7 @@ func CompanyCompletion(p profile.CompanyProfile) int {
 }
 
 func IsStudentProfileComplete(p profile.StudentProfile) bool {
-	return StudentCompletion(p) == 100
+	return studentRequiredFilled(p) == studentRequiredFields
 }
 
 func IsCompanyProfileComplete(p profile.CompanyProfile) bool {
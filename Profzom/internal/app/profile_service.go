@@ -2,6 +2,8 @@ package app
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"profzom/internal/common"
 	"profzom/internal/domain/analytics"
@@ -11,35 +13,105 @@ import (
 type ProfileService struct {
 	students  profile.StudentRepository
 	companies profile.CompanyRepository
+	resumes   profile.ResumeRepository
 	analytics analytics.Repository
+	tx        UnitOfWork
 }
 
-func NewProfileService(students profile.StudentRepository, companies profile.CompanyRepository, analytics analytics.Repository) *ProfileService {
-	return &ProfileService{students: students, companies: companies, analytics: analytics}
+func NewProfileService(students profile.StudentRepository, companies profile.CompanyRepository, resumes profile.ResumeRepository, analytics analytics.Repository, tx UnitOfWork) *ProfileService {
+	return &ProfileService{students: students, companies: companies, resumes: resumes, analytics: analytics, tx: tx}
 }
 
 func (s *ProfileService) GetStudent(ctx context.Context, userID common.UUID) (*profile.StudentProfile, error) {
 	return s.students.GetByUserID(ctx, userID)
 }
 
-func (s *ProfileService) UpsertStudent(ctx context.Context, profile profile.StudentProfile) (*profile.StudentProfile, error) {
-	updated, err := s.students.Upsert(ctx, profile)
+func (s *ProfileService) GetResume(ctx context.Context, studentID common.UUID) (*profile.Resume, error) {
+	return s.resumes.GetByStudent(ctx, studentID)
+}
+
+// UpsertStudent сохраняет профиль и, если resume передан, целиком заменяет резюме в той же транзакции.
+// Возвращает актуальное резюме, чтобы клиент сразу получил пересчитанную заполненность.
+func (s *ProfileService) UpsertStudent(ctx context.Context, input profile.StudentProfile, resume *profile.Resume) (*profile.StudentProfile, *profile.Resume, error) {
+	var normalized profile.Resume
+	if resume != nil {
+		var err error
+		normalized, err = normalizeResume(*resume, time.Now().UTC())
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	var updated *profile.StudentProfile
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		updated, err = s.students.Upsert(ctx, input)
+		if err != nil {
+			return err
+		}
+		if resume != nil {
+			if err := s.resumes.Replace(ctx, input.UserID, normalized); err != nil {
+				return err
+			}
+		}
+		return s.analytics.Create(ctx, analytics.Event{Name: "profile.student.updated", UserID: &input.UserID, Payload: analyticsPayload(ctx, map[string]string{"user_id": input.UserID.String()})})
+	})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	_ = s.analytics.Create(ctx, analytics.Event{Name: "profile.student.updated", UserID: &profile.UserID, Payload: analyticsPayload(ctx, map[string]string{"user_id": profile.UserID.String()})})
-	return updated, nil
+	current, err := s.resumes.GetByStudent(ctx, input.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return updated, current, nil
 }
 
 func (s *ProfileService) GetCompany(ctx context.Context, userID common.UUID) (*profile.CompanyProfile, error) {
 	return s.companies.GetByUserID(ctx, userID)
 }
 
-func (s *ProfileService) UpsertCompany(ctx context.Context, profile profile.CompanyProfile) (*profile.CompanyProfile, error) {
-	updated, err := s.companies.Upsert(ctx, profile)
+func (s *ProfileService) UpsertCompany(ctx context.Context, input profile.CompanyProfile) (*profile.CompanyProfile, error) {
+	var updated *profile.CompanyProfile
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		updated, err = s.companies.Upsert(ctx, input)
+		if err != nil {
+			return err
+		}
+		return s.analytics.Create(ctx, analytics.Event{Name: "profile.company.updated", UserID: &input.UserID, Payload: analyticsPayload(ctx, map[string]string{"user_id": input.UserID.String()})})
+	})
 	if err != nil {
 		return nil, err
 	}
-	_ = s.analytics.Create(ctx, analytics.Event{Name: "profile.company.updated", UserID: &profile.UserID, Payload: analyticsPayload(ctx, map[string]string{"user_id": profile.UserID.String()})})
 	return updated, nil
 }
+
+// SearchCandidates ищет студентов, открывших профиль компаниям; навыки сравниваются после нормализации и синонимов.
+func (s *ProfileService) SearchCandidates(ctx context.Context, query profile.CandidateQuery) ([]profile.StudentProfile, *common.Cursor, error) {
+	fields := map[string]string{}
+	query.Text = strings.TrimSpace(query.Text)
+	query.University = strings.TrimSpace(query.University)
+	query.Specialty = strings.TrimSpace(query.Specialty)
+	query.Skills = profile.NormalizeSkills(query.Skills)
+	switch query.SkillsMode {
+	case "":
+		query.SkillsMode = profile.SkillsMatchAny
+	case profile.SkillsMatchAny, profile.SkillsMatchAll:
+	default:
+		fields["skills_mode"] = "must be any or all"
+	}
+	if query.CourseMin != nil && *query.CourseMin < 1 {
+		fields["course_min"] = "must be > 0"
+	}
+	if query.CourseMax != nil && *query.CourseMax < 1 {
+		fields["course_max"] = "must be > 0"
+	}
+	if query.CourseMin != nil && query.CourseMax != nil && *query.CourseMin > *query.CourseMax {
+		fields["course_min"] = "must not exceed course_max"
+	}
+	if len(fields) > 0 {
+		return nil, nil, common.NewValidationError("invalid candidate search", fields)
+	}
+	page := normalizePage(common.PageRequest{Limit: query.Limit, After: query.After})
+	query.Limit = page.Limit
+	return s.students.Search(ctx, query)
+}
@@ -0,0 +1,59 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/profile"
+)
+
+func TestProfileServiceSearchCandidates_SkillsModes(t *testing.T) {
+	f := newTestEnv(t)
+	for _, p := range []profile.StudentProfile{
+		{UserID: common.NewUUID(), Name: "Anna", Skills: []string{"Golang", "Postgres"}, VisibleToCompanies: true},
+		{UserID: common.NewUUID(), Name: "Boris", Skills: []string{"Go", "Docker"}, VisibleToCompanies: true},
+		{UserID: common.NewUUID(), Name: "Hidden", Skills: []string{"go", "postgresql"}},
+	} {
+		p := p
+		f.students.profiles[p.UserID] = &p
+	}
+
+	anyMatch, _, err := f.profileSvc.SearchCandidates(context.Background(), profile.CandidateQuery{Skills: []string{"GO", "k8s"}})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	// видимые кандидаты с go: студент окружения, Anna и Boris
+	if len(anyMatch) != 3 {
+		t.Fatalf("expected 3 visible candidates, got %d", len(anyMatch))
+	}
+	allMatch, _, err := f.profileSvc.SearchCandidates(context.Background(), profile.CandidateQuery{Skills: []string{"go", "PostgreSQL"}, SkillsMode: profile.SkillsMatchAll})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(allMatch) != 1 || allMatch[0].Name != "Anna" {
+		t.Fatalf("expected only Anna, got %+v", allMatch)
+	}
+}
+
+func TestProfileServiceSearchCandidates_NormalizesQuery(t *testing.T) {
+	f := newTestEnv(t)
+
+	if _, _, err := f.profileSvc.SearchCandidates(context.Background(), profile.CandidateQuery{Skills: []string{" Golang ", "go", ""}, University: "  МГУ "}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	query := f.students.lastQuery
+	if len(query.Skills) != 1 || query.Skills[0] != "go" || query.SkillsMode != profile.SkillsMatchAny || query.University != "МГУ" || query.Limit != defaultPageLimit {
+		t.Fatalf("unexpected normalized query %+v", query)
+	}
+}
+
+func TestProfileServiceSearchCandidates_InvalidCourseRange(t *testing.T) {
+	f := newTestEnv(t)
+	min, max := 4, 2
+
+	_, _, err := f.profileSvc.SearchCandidates(context.Background(), profile.CandidateQuery{CourseMin: &min, CourseMax: &max})
+	if !common.Is(err, common.CodeValidation) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
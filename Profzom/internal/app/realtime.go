@@ -0,0 +1,27 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+
+	"profzom/internal/common"
+	"profzom/internal/realtime"
+)
+
+// RealtimePublisher отправляет событие в поток подписчиков. Вызывается внутри UnitOfWork,
+// поэтому реализация должна доставлять событие только после коммита (в рабочей сборке — pg_notify).
+type RealtimePublisher interface {
+	Publish(ctx context.Context, event realtime.Event) error
+}
+
+type noopRealtimePublisher struct{}
+
+func (noopRealtimePublisher) Publish(context.Context, realtime.Event) error { return nil }
+
+func publishRealtime(ctx context.Context, publisher RealtimePublisher, eventType string, data interface{}, userIDs ...common.UUID) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return common.NewError(common.CodeInternal, "failed to encode realtime event", err)
+	}
+	return publisher.Publish(ctx, realtime.Event{Type: eventType, UserIDs: userIDs, Data: encoded})
+}
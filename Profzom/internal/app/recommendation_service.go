@@ -0,0 +1,145 @@
+package app
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/profile"
+	"profzom/internal/domain/vacancy"
+)
+
+const (
+	// recommendationCandidates — сколько свежих опубликованных вакансий оценивается за один запрос.
+	recommendationCandidates = 500
+	skillsWeight             = 70
+	specialtyWeight          = 20
+	courseWeight             = 10
+)
+
+// coursePattern находит в требованиях минимальный курс: «3 курс», «с 3-го курса», «3+ курс», «course 3».
+var coursePattern = regexp.MustCompile(`(?i)(\d)\s*(?:\+|-?го|-?й)?\s*(?:курс|course)|course\s*(\d)`)
+
+// Recommendation — вакансия с оценкой соответствия студенту. MatchedSkills и MissingSkills — требования вакансии
+// как их написала компания, чтобы студент видел, чего ему не хватает.
+type Recommendation struct {
+	Vacancy       vacancy.Vacancy `json:"vacancy"`
+	Score         int             `json:"score"`
+	MatchedSkills []string        `json:"matched_skills"`
+	MissingSkills []string        `json:"missing_skills"`
+}
+
+type RecommendationService struct {
+	students  profile.StudentRepository
+	vacancies vacancy.Repository
+}
+
+func NewRecommendationService(students profile.StudentRepository, vacancies vacancy.Repository) *RecommendationService {
+	return &RecommendationService{students: students, vacancies: vacancies}
+}
+
+// ForStudent оценивает опубликованные вакансии по навыкам, специальности и курсу студента и возвращает лучшие limit штук.
+func (s *RecommendationService) ForStudent(ctx context.Context, studentID common.UUID, limit int) ([]Recommendation, error) {
+	student, err := s.students.GetByUserID(ctx, studentID)
+	if err != nil {
+		if common.Is(err, common.CodeNotFound) {
+			return nil, common.NewError(common.CodeValidation, "student profile is required", nil)
+		}
+		return nil, err
+	}
+	limit = normalizePage(common.PageRequest{Limit: limit}).Limit
+	candidates, _, err := s.vacancies.Search(ctx, vacancy.SearchQuery{Sort: vacancy.SortNewest, Limit: recommendationCandidates})
+	if err != nil {
+		return nil, err
+	}
+	skills := make(map[string]bool, len(student.Skills))
+	for _, skill := range student.Skills {
+		if normalized := profile.NormalizeSkill(skill); normalized != "" {
+			skills[normalized] = true
+		}
+	}
+	items := make([]Recommendation, 0, len(candidates))
+	for _, candidate := range candidates {
+		recommendation := scoreVacancy(*student, skills, candidate)
+		if recommendation.Score > 0 && (len(recommendation.MatchedSkills) > 0 || hasSpecialtyMatch(student.Specialty, candidate)) {
+			items = append(items, recommendation)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Score > items[j].Score
+	})
+	if len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+func scoreVacancy(student profile.StudentProfile, skills map[string]bool, v vacancy.Vacancy) Recommendation {
+	recommendation := Recommendation{Vacancy: v, MatchedSkills: []string{}, MissingSkills: []string{}}
+	minCourse := 0
+	for _, requirement := range v.Requirements {
+		if course := requiredCourse(requirement); course > 0 {
+			minCourse = course
+			continue
+		}
+		if requirementMatches(requirement, skills) {
+			recommendation.MatchedSkills = append(recommendation.MatchedSkills, requirement)
+		} else {
+			recommendation.MissingSkills = append(recommendation.MissingSkills, requirement)
+		}
+	}
+	total := len(recommendation.MatchedSkills) + len(recommendation.MissingSkills)
+	score := 0.0
+	if total > 0 {
+		score += skillsWeight * float64(len(recommendation.MatchedSkills)) / float64(total)
+	}
+	if hasSpecialtyMatch(student.Specialty, v) {
+		score += specialtyWeight
+	}
+	if minCourse == 0 || student.Course >= minCourse {
+		score += courseWeight
+	} else {
+		recommendation.MissingSkills = append(recommendation.MissingSkills, strconv.Itoa(minCourse)+"+ course")
+	}
+	recommendation.Score = int(score + 0.5)
+	return recommendation
+}
+
+func requirementMatches(requirement string, skills map[string]bool) bool {
+	if skills[profile.NormalizeSkill(requirement)] {
+		return true
+	}
+	for _, term := range skillTerms(requirement) {
+		if skills[term] {
+			return true
+		}
+	}
+	return false
+}
+
+func requiredCourse(requirement string) int {
+	match := coursePattern.FindStringSubmatch(requirement)
+	if match == nil {
+		return 0
+	}
+	digits := match[1]
+	if digits == "" {
+		digits = match[2]
+	}
+	course, _ := strconv.Atoi(digits)
+	return course
+}
+
+// hasSpecialtyMatch проверяет, упоминается ли значимое слово специальности студента в названии или описании вакансии.
+func hasSpecialtyMatch(specialty string, v vacancy.Vacancy) bool {
+	text := strings.ToLower(v.Title + " " + v.Description)
+	for _, word := range strings.Fields(strings.ToLower(specialty)) {
+		if len([]rune(word)) >= 4 && strings.Contains(text, word) {
+			return true
+		}
+	}
+	return false
+}
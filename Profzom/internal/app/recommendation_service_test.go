@@ -0,0 +1,65 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/profile"
+	"profzom/internal/domain/vacancy"
+)
+
+func TestNormalizeSkill_Synonyms(t *testing.T) {
+	cases := map[string]string{
+		"Golang":            "go",
+		"  PostgreS ":       "postgresql",
+		"K8s":               "kubernetes",
+		"Node.JS":           "node.js",
+		"Machine  Learning": "machine learning",
+	}
+	for input, expected := range cases {
+		if got := profile.NormalizeSkill(input); got != expected {
+			t.Fatalf("expected %q for %q, got %q", expected, input, got)
+		}
+	}
+}
+
+func TestRecommendationServiceForStudent_RanksBySkills(t *testing.T) {
+	vacancies := newFakeVacancyRepo()
+	studentID := common.NewUUID()
+	students := &fakeStudentRepo{profiles: map[common.UUID]*profile.StudentProfile{
+		studentID: {UserID: studentID, Specialty: "Программная инженерия", Course: 2, Skills: []string{"golang", "Postgres"}},
+	}}
+	best, _ := vacancies.Create(context.Background(), vacancy.Vacancy{Title: "Go backend intern", Status: vacancy.StatusPublished, Requirements: []string{"Go", "Опыт с PostgreSQL", "Docker"}})
+	partial, _ := vacancies.Create(context.Background(), vacancy.Vacancy{Title: "Backend developer", Status: vacancy.StatusPublished, Requirements: []string{"Go", "Kubernetes", "Студент с 4 курса"}})
+	vacancies.Create(context.Background(), vacancy.Vacancy{Title: "Designer", Status: vacancy.StatusPublished, Requirements: []string{"Figma"}})
+	vacancies.Create(context.Background(), vacancy.Vacancy{Title: "Go draft", Status: vacancy.StatusDraft, Requirements: []string{"Go"}})
+	service := NewRecommendationService(students, vacancies)
+
+	items, err := service.ForStudent(context.Background(), studentID, 10)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("expected 2 recommendations, got %+v", items)
+	}
+	if items[0].Vacancy.ID != best.ID || items[1].Vacancy.ID != partial.ID {
+		t.Fatalf("unexpected order %q, %q", items[0].Vacancy.Title, items[1].Vacancy.Title)
+	}
+	if len(items[0].MatchedSkills) != 2 || len(items[0].MissingSkills) != 1 || items[0].MissingSkills[0] != "Docker" {
+		t.Fatalf("unexpected skills for best match %+v", items[0])
+	}
+	missing := items[1].MissingSkills
+	if len(missing) != 2 || missing[0] != "Kubernetes" || missing[1] != "4+ course" {
+		t.Fatalf("expected missing kubernetes and course, got %v", missing)
+	}
+}
+
+func TestRecommendationServiceForStudent_RequiresProfile(t *testing.T) {
+	service := NewRecommendationService(&fakeStudentRepo{profiles: map[common.UUID]*profile.StudentProfile{}}, newFakeVacancyRepo())
+
+	_, err := service.ForStudent(context.Background(), common.NewUUID(), 10)
+	if !common.Is(err, common.CodeValidation) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
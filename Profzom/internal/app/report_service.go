@@ -0,0 +1,117 @@
+package app
+
+import (
+	"context"
+	"strings"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/application"
+	"profzom/internal/domain/message"
+	"profzom/internal/domain/moderation"
+	"profzom/internal/domain/profile"
+	"profzom/internal/domain/vacancy"
+)
+
+const reportCommentMaxLength = 1000
+
+// ReportService принимает жалобы пользователей на вакансии, компании и сообщения; разбирают их администраторы
+// через ModerationService.
+type ReportService struct {
+	reports      moderation.ReportRepository
+	vacancies    vacancy.Repository
+	companies    profile.CompanyRepository
+	messages     message.Repository
+	applications application.Repository
+}
+
+func NewReportService(reports moderation.ReportRepository, vacancies vacancy.Repository, companies profile.CompanyRepository, messages message.Repository, applications application.Repository) *ReportService {
+	return &ReportService{reports: reports, vacancies: vacancies, companies: companies, messages: messages, applications: applications}
+}
+
+// Create сохраняет жалобу со снимком текста объекта. Жаловаться на себя нельзя, на сообщение — только участнику переписки.
+func (s *ReportService) Create(ctx context.Context, reporterID common.UUID, targetType moderation.ReportTarget, targetID common.UUID, reason moderation.ReportReason, comment string) (*moderation.Report, error) {
+	comment = strings.TrimSpace(comment)
+	fields := map[string]string{}
+	switch targetType {
+	case moderation.ReportVacancy, moderation.ReportCompany, moderation.ReportMessage:
+	default:
+		fields["target_type"] = "target_type must be vacancy, company or message"
+	}
+	if targetID == "" {
+		fields["target_id"] = "target_id is required"
+	}
+	if !isReportReason(reason) {
+		fields["reason"] = "reason must be spam, fraud, harassment, discrimination, inappropriate or other"
+	}
+	switch {
+	case reason == moderation.ReasonOther && comment == "":
+		fields["comment"] = "comment is required for reason other"
+	case len([]rune(comment)) > reportCommentMaxLength:
+		fields["comment"] = "comment is too long"
+	}
+	if len(fields) > 0 {
+		return nil, common.NewValidationError("invalid report", fields)
+	}
+	report := moderation.Report{ReporterID: reporterID, TargetType: targetType, TargetID: targetID, Reason: reason, Comment: comment}
+	if err := s.describeTarget(ctx, &report); err != nil {
+		return nil, err
+	}
+	if report.TargetUserID == reporterID {
+		return nil, common.NewError(common.CodeValidation, "cannot report own content", nil)
+	}
+	return s.reports.Create(ctx, report)
+}
+
+// describeTarget проверяет доступ к объекту жалобы и заполняет его автора и снимок текста.
+func (s *ReportService) describeTarget(ctx context.Context, report *moderation.Report) error {
+	switch report.TargetType {
+	case moderation.ReportVacancy:
+		vac, err := s.vacancies.GetByID(ctx, report.TargetID)
+		if err != nil {
+			return err
+		}
+		report.TargetUserID = vac.CompanyID
+		report.Text = vacancyText(*vac)
+	case moderation.ReportCompany:
+		company, err := s.companies.GetByUserID(ctx, report.TargetID)
+		if err != nil {
+			return err
+		}
+		report.TargetUserID = company.UserID
+		report.Text = strings.Join([]string{company.Name, company.Industry, company.Description}, "\n")
+	case moderation.ReportMessage:
+		msg, err := s.messages.GetByID(ctx, report.TargetID)
+		if err != nil {
+			return err
+		}
+		app, err := s.applications.GetByID(ctx, msg.ApplicationID)
+		if err != nil {
+			return err
+		}
+		vac, err := s.vacancies.GetByID(ctx, app.VacancyID)
+		if err != nil {
+			return err
+		}
+		if report.ReporterID != app.StudentID && report.ReporterID != vac.CompanyID {
+			return common.NewError(common.CodeForbidden, "user is not allowed to report this message", nil)
+		}
+		if msg.Kind == message.KindSystem {
+			return common.NewError(common.CodeValidation, "system messages cannot be reported", nil)
+		}
+		if msg.Moderation != "" && msg.SenderID != report.ReporterID {
+			return common.NewError(common.CodeNotFound, "message not found", nil)
+		}
+		report.TargetUserID = msg.SenderID
+		report.Text = msg.Body
+	}
+	return nil
+}
+
+func isReportReason(reason moderation.ReportReason) bool {
+	switch reason {
+	case moderation.ReasonSpam, moderation.ReasonFraud, moderation.ReasonHarassment, moderation.ReasonDiscrimination, moderation.ReasonInappropriate, moderation.ReasonOther:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,141 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/moderation"
+)
+
+func TestMessageServiceSend_ForbiddenWhenBlocked(t *testing.T) {
+	f := newTestEnv(t)
+	if _, err := f.blockListSvc.Block(context.Background(), f.studentID, f.companyID); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	// блокировка действует в обе стороны: не пишет ни заблокированный, ни тот, кто заблокировал
+	for _, senderID := range []common.UUID{f.companyID, f.studentID} {
+		if _, err := f.messageSvc.Send(context.Background(), f.application.ID, senderID, "Hello"); !common.Is(err, common.CodeForbidden) {
+			t.Fatalf("expected forbidden for sender %s, got %v", senderID, err)
+		}
+	}
+	if len(f.messages.items) != 0 || len(f.notifier.sent) != 0 {
+		t.Fatalf("expected no messages and notifications, got %d messages, %+v", len(f.messages.items), f.notifier.sent)
+	}
+
+	if err := f.blockListSvc.Unblock(context.Background(), f.studentID, f.companyID); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if _, err := f.messageSvc.Send(context.Background(), f.application.ID, f.companyID, "Hello"); err != nil {
+		t.Fatalf("expected message after unblock, got %v", err)
+	}
+}
+
+func TestApplicationServiceApply_ForbiddenWhenBlocked(t *testing.T) {
+	f := newTestEnv(t)
+	studentID := common.NewUUID()
+	student := completeStudentProfile(studentID)
+	f.students.profiles[studentID] = &student
+	f.blocks.Add(context.Background(), f.companyID, studentID)
+
+	if _, err := f.applicationSvc.Apply(context.Background(), f.application.VacancyID, studentID); !common.Is(err, common.CodeForbidden) {
+		t.Fatalf("expected forbidden, got %v", err)
+	}
+	if _, err := f.applications.FindByVacancyAndStudent(context.Background(), f.application.VacancyID, studentID); !common.Is(err, common.CodeNotFound) {
+		t.Fatalf("expected no application, got %v", err)
+	}
+}
+
+func TestInvitationService_ForbiddenWhenBlocked(t *testing.T) {
+	f := newTestEnv(t)
+	vacancyID := f.publishedVacancy(t)
+	inv, err := f.invitationSvc.Invite(context.Background(), f.companyID, vacancyID, f.studentID, "We liked your profile!")
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	f.blocks.Add(context.Background(), f.studentID, f.companyID)
+
+	if _, _, err := f.invitationSvc.Accept(context.Background(), inv.ID, f.studentID); !common.Is(err, common.CodeForbidden) {
+		t.Fatalf("expected forbidden accept, got %v", err)
+	}
+	if _, err := f.applications.FindByVacancyAndStudent(context.Background(), vacancyID, f.studentID); !common.Is(err, common.CodeNotFound) {
+		t.Fatalf("expected no application, got %v", err)
+	}
+	if _, err := f.invitationSvc.Cancel(context.Background(), inv.ID, f.companyID); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	sent := len(f.notifier.sent)
+	if _, err := f.invitationSvc.Invite(context.Background(), f.companyID, vacancyID, f.studentID, "Please reconsider"); !common.Is(err, common.CodeForbidden) {
+		t.Fatalf("expected forbidden invite, got %v", err)
+	}
+	if len(f.notifier.sent) != sent {
+		t.Fatalf("expected no new notifications, got %+v", f.notifier.sent[sent:])
+	}
+}
+
+func TestBlockListServiceBlock_RejectsSelf(t *testing.T) {
+	service := NewBlockListService(newFakeBlockListRepo())
+	userID := common.NewUUID()
+	if _, err := service.Block(context.Background(), userID, userID); !common.Is(err, common.CodeValidation) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestReportServiceCreate_Message(t *testing.T) {
+	f := newTestEnv(t)
+	sent, err := f.messageSvc.Send(context.Background(), f.application.ID, f.companyID, "Pay 5000 for the onboarding course")
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if _, err := f.reportSvc.Create(context.Background(), common.NewUUID(), moderation.ReportMessage, sent.ID, moderation.ReasonFraud, ""); !common.Is(err, common.CodeForbidden) {
+		t.Fatalf("expected forbidden for stranger, got %v", err)
+	}
+	if _, err := f.reportSvc.Create(context.Background(), f.companyID, moderation.ReportMessage, sent.ID, moderation.ReasonFraud, ""); !common.Is(err, common.CodeValidation) {
+		t.Fatalf("expected validation error for own message, got %v", err)
+	}
+	if _, err := f.reportSvc.Create(context.Background(), f.studentID, moderation.ReportMessage, sent.ID, moderation.ReasonOther, " "); !common.Is(err, common.CodeValidation) {
+		t.Fatalf("expected validation error for other without comment, got %v", err)
+	}
+
+	report, err := f.reportSvc.Create(context.Background(), f.studentID, moderation.ReportMessage, sent.ID, moderation.ReasonFraud, "asks for money")
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if report.TargetUserID != f.companyID || report.Text != sent.Body || report.Status != moderation.ReportOpen {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	if _, err := f.reportSvc.Create(context.Background(), f.studentID, moderation.ReportMessage, sent.ID, moderation.ReasonSpam, ""); !common.Is(err, common.CodeConflict) {
+		t.Fatalf("expected conflict for duplicate report, got %v", err)
+	}
+}
+
+func TestModerationServiceResolveReport(t *testing.T) {
+	f := newTestEnv(t)
+	report, err := f.reportSvc.Create(context.Background(), f.studentID, moderation.ReportVacancy, f.application.VacancyID, moderation.ReasonSpam, "")
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	open, _, err := f.moderationSvc.ListReports(context.Background(), moderation.ReportQuery{})
+	if err != nil || len(open) != 1 || open[0].ID != report.ID {
+		t.Fatalf("expected open report in admin list, got %+v, %v", open, err)
+	}
+
+	resolved, err := f.moderationSvc.ResolveReport(context.Background(), f.adminID, report.ID, "vacancy unpublished")
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if resolved.Status != moderation.ReportResolved || resolved.ResolvedBy == nil || *resolved.ResolvedBy != f.adminID {
+		t.Fatalf("unexpected resolved report %+v", resolved)
+	}
+	if len(f.audit.entries) != 1 || f.audit.entries[0].Action != "report.resolved" || f.audit.entries[0].TargetID != f.application.VacancyID.String() {
+		t.Fatalf("expected report.resolved audit entry, got %+v", f.audit.entries)
+	}
+	if _, err := f.moderationSvc.DismissReport(context.Background(), f.adminID, report.ID, ""); !common.Is(err, common.CodeConflict) {
+		t.Fatalf("expected conflict for closed report, got %v", err)
+	}
+	if _, _, err := f.moderationSvc.ListReports(context.Background(), moderation.ReportQuery{Status: "closed"}); !common.Is(err, common.CodeValidation) {
+		t.Fatalf("expected validation error for unknown status, got %v", err)
+	}
+}
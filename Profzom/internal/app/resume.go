@@ -0,0 +1,173 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/profile"
+)
+
+const (
+	resumeMaxEntries           = 20
+	resumeFieldMaxLength       = 200
+	resumeDescriptionMaxLength = 2000
+	resumeMonthLayout          = "2006-01"
+	resumeMinYear              = 1950
+)
+
+var linkKinds = map[profile.LinkKind]bool{
+	profile.LinkGitHub: true, profile.LinkGitLab: true, profile.LinkPortfolio: true,
+	profile.LinkLinkedIn: true, profile.LinkWebsite: true, profile.LinkOther: true,
+}
+
+var languageLevels = map[profile.LanguageLevel]bool{
+	"A1": true, "A2": true, "B1": true, "B2": true, "C1": true, "C2": true, "native": true,
+}
+
+// normalizeResume обрезает пробелы, приводит справочные значения к каноническому виду и проверяет разделы.
+// Ошибки полей адресуются путём вида experience[1].start_month.
+func normalizeResume(input profile.Resume, now time.Time) (profile.Resume, error) {
+	fields := map[string]string{}
+	maxYear := now.Year() + 10
+	out := profile.Resume{
+		Education:    make([]profile.Education, 0, len(input.Education)),
+		Experience:   make([]profile.Experience, 0, len(input.Experience)),
+		Links:        make([]profile.Link, 0, len(input.Links)),
+		Languages:    make([]profile.Language, 0, len(input.Languages)),
+		Certificates: make([]profile.Certificate, 0, len(input.Certificates)),
+	}
+	checkCount := func(name string, count int) {
+		if count > resumeMaxEntries {
+			fields[name] = fmt.Sprintf("at most %d entries", resumeMaxEntries)
+		}
+	}
+	checkCount("education", len(input.Education))
+	checkCount("experience", len(input.Experience))
+	checkCount("links", len(input.Links))
+	checkCount("languages", len(input.Languages))
+	checkCount("certificates", len(input.Certificates))
+	if len(fields) > 0 {
+		return profile.Resume{}, common.NewValidationError("invalid resume", fields)
+	}
+
+	for i, item := range input.Education {
+		prefix := fmt.Sprintf("education[%d].", i)
+		item.Institution = requiredText(fields, prefix+"institution", item.Institution)
+		item.Degree = optionalText(fields, prefix+"degree", item.Degree, resumeFieldMaxLength)
+		item.Field = optionalText(fields, prefix+"field", item.Field, resumeFieldMaxLength)
+		if item.StartYear < resumeMinYear || item.StartYear > now.Year() {
+			fields[prefix+"start_year"] = "invalid year"
+		}
+		if item.EndYear != nil && (*item.EndYear < item.StartYear || *item.EndYear > maxYear) {
+			fields[prefix+"end_year"] = "must be between start_year and " + fmt.Sprint(maxYear)
+		}
+		out.Education = append(out.Education, item)
+	}
+	for i, item := range input.Experience {
+		prefix := fmt.Sprintf("experience[%d].", i)
+		item.Kind = profile.ExperienceKind(strings.ToLower(strings.TrimSpace(string(item.Kind))))
+		if item.Kind == "" {
+			item.Kind = profile.ExperienceWork
+		}
+		if item.Kind != profile.ExperienceWork && item.Kind != profile.ExperienceProject {
+			fields[prefix+"kind"] = "must be work or project"
+		}
+		item.Title = requiredText(fields, prefix+"title", item.Title)
+		item.Organization = optionalText(fields, prefix+"organization", item.Organization, resumeFieldMaxLength)
+		item.Description = optionalText(fields, prefix+"description", item.Description, resumeDescriptionMaxLength)
+		item.URL = optionalLink(fields, prefix+"url", item.URL)
+		item.StartMonth = strings.TrimSpace(item.StartMonth)
+		item.EndMonth = strings.TrimSpace(item.EndMonth)
+		start, err := time.Parse(resumeMonthLayout, item.StartMonth)
+		if err != nil {
+			fields[prefix+"start_month"] = "must be YYYY-MM"
+		} else if start.After(now) {
+			fields[prefix+"start_month"] = "must not be in the future"
+		}
+		if item.EndMonth != "" {
+			end, endErr := time.Parse(resumeMonthLayout, item.EndMonth)
+			if endErr != nil {
+				fields[prefix+"end_month"] = "must be YYYY-MM"
+			} else if err == nil && end.Before(start) {
+				fields[prefix+"end_month"] = "must not precede start_month"
+			}
+		}
+		out.Experience = append(out.Experience, item)
+	}
+	for i, item := range input.Links {
+		prefix := fmt.Sprintf("links[%d].", i)
+		item.Kind = profile.LinkKind(strings.ToLower(strings.TrimSpace(string(item.Kind))))
+		if item.Kind == "" {
+			item.Kind = profile.LinkOther
+		}
+		if !linkKinds[item.Kind] {
+			fields[prefix+"kind"] = "must be github, gitlab, portfolio, linkedin, website or other"
+		}
+		item.URL = strings.TrimSpace(item.URL)
+		if item.URL == "" {
+			fields[prefix+"url"] = "url is required"
+		} else {
+			item.URL = optionalLink(fields, prefix+"url", item.URL)
+		}
+		out.Links = append(out.Links, item)
+	}
+	for i, item := range input.Languages {
+		prefix := fmt.Sprintf("languages[%d].", i)
+		item.Name = requiredText(fields, prefix+"name", item.Name)
+		level := strings.TrimSpace(string(item.Level))
+		if strings.EqualFold(level, "native") {
+			item.Level = "native"
+		} else {
+			item.Level = profile.LanguageLevel(strings.ToUpper(level))
+		}
+		if !languageLevels[item.Level] {
+			fields[prefix+"level"] = "must be A1–C2 or native"
+		}
+		out.Languages = append(out.Languages, item)
+	}
+	for i, item := range input.Certificates {
+		prefix := fmt.Sprintf("certificates[%d].", i)
+		item.Name = requiredText(fields, prefix+"name", item.Name)
+		item.Issuer = optionalText(fields, prefix+"issuer", item.Issuer, resumeFieldMaxLength)
+		item.URL = optionalLink(fields, prefix+"url", item.URL)
+		item.IssuedMonth = strings.TrimSpace(item.IssuedMonth)
+		if item.IssuedMonth != "" {
+			if _, err := time.Parse(resumeMonthLayout, item.IssuedMonth); err != nil {
+				fields[prefix+"issued_month"] = "must be YYYY-MM"
+			}
+		}
+		out.Certificates = append(out.Certificates, item)
+	}
+	if len(fields) > 0 {
+		return profile.Resume{}, common.NewValidationError("invalid resume", fields)
+	}
+	return out, nil
+}
+
+func requiredText(fields map[string]string, name, value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		fields[name] = "is required"
+		return value
+	}
+	return optionalText(fields, name, value, resumeFieldMaxLength)
+}
+
+func optionalText(fields map[string]string, name, value string, limit int) string {
+	value = strings.TrimSpace(value)
+	if utf8.RuneCountInString(value) > limit {
+		fields[name] = fmt.Sprintf("must be at most %d characters", limit)
+	}
+	return value
+}
+
+func optionalLink(fields map[string]string, name, value string) string {
+	value = strings.TrimSpace(value)
+	if value != "" && !isHTTPLink(value) {
+		fields[name] = "must be an http(s) link"
+	}
+	return value
+}
@@ -0,0 +1,149 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/application"
+	"profzom/internal/domain/profile"
+	"profzom/internal/domain/vacancy"
+	"profzom/internal/resume"
+)
+
+type ResumeFormat string
+
+const (
+	ResumeFormatPDF  ResumeFormat = "pdf"
+	ResumeFormatHTML ResumeFormat = "html"
+)
+
+// ResumeService собирает экспорт резюме: студент выгружает своё, компания — резюме студента, откликнувшегося на её вакансию.
+type ResumeService struct {
+	students     profile.StudentRepository
+	resumes      profile.ResumeRepository
+	applications application.Repository
+	vacancies    vacancy.Repository
+}
+
+func NewResumeService(students profile.StudentRepository, resumes profile.ResumeRepository, applications application.Repository, vacancies vacancy.Repository) *ResumeService {
+	return &ResumeService{students: students, resumes: resumes, applications: applications, vacancies: vacancies}
+}
+
+func (s *ResumeService) ExportOwn(ctx context.Context, studentID common.UUID, format ResumeFormat) ([]byte, error) {
+	return s.export(ctx, studentID, format)
+}
+
+// ExportForApplication доступен студенту и компании-владельцу вакансии отклика.
+func (s *ResumeService) ExportForApplication(ctx context.Context, applicationID, userID common.UUID, format ResumeFormat) ([]byte, error) {
+	app, err := s.applications.GetByID(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	vac, err := s.vacancies.GetByID(ctx, app.VacancyID)
+	if err != nil {
+		return nil, err
+	}
+	if userID != app.StudentID && userID != vac.CompanyID {
+		return nil, common.NewError(common.CodeForbidden, "user is not allowed to view resume", nil)
+	}
+	return s.export(ctx, app.StudentID, format)
+}
+
+func (s *ResumeService) export(ctx context.Context, studentID common.UUID, format ResumeFormat) ([]byte, error) {
+	if format != ResumeFormatPDF && format != ResumeFormatHTML {
+		return nil, common.NewError(common.CodeValidation, "format must be pdf or html", nil)
+	}
+	student, err := s.students.GetByUserID(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	details, err := s.resumes.GetByStudent(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	doc := resumeDocument(*student, *details, time.Now().UTC())
+	if format == ResumeFormatHTML {
+		rendered, err := resume.RenderHTML(doc)
+		if err != nil {
+			return nil, common.NewError(common.CodeInternal, "failed to render resume", err)
+		}
+		return rendered, nil
+	}
+	return resume.RenderPDF(doc), nil
+}
+
+var linkTitles = map[profile.LinkKind]string{
+	profile.LinkGitHub:    "GitHub",
+	profile.LinkGitLab:    "GitLab",
+	profile.LinkPortfolio: "Portfolio",
+	profile.LinkLinkedIn:  "LinkedIn",
+	profile.LinkWebsite:   "Website",
+	profile.LinkOther:     "Link",
+}
+
+func resumeDocument(p profile.StudentProfile, r profile.Resume, now time.Time) resume.Document {
+	doc := resume.Document{Name: p.Name, Headline: p.Specialty, Skills: p.Skills, About: p.About, GeneratedAt: now}
+	if p.University != "" {
+		doc.Details = append(doc.Details, p.University)
+	}
+	if p.Course > 0 {
+		doc.Details = append(doc.Details, fmt.Sprintf("Course %d", p.Course))
+	}
+	work := resume.Section{Title: "Experience"}
+	projects := resume.Section{Title: "Projects"}
+	for _, item := range r.Experience {
+		entry := resume.Entry{Title: item.Title, Subtitle: item.Organization, Period: resumePeriod(item.StartMonth, item.EndMonth), Description: item.Description, URL: item.URL}
+		if item.Kind == profile.ExperienceProject {
+			projects.Entries = append(projects.Entries, entry)
+		} else {
+			work.Entries = append(work.Entries, entry)
+		}
+	}
+	education := resume.Section{Title: "Education"}
+	for _, item := range r.Education {
+		end := ""
+		if item.EndYear != nil {
+			end = fmt.Sprint(*item.EndYear)
+		}
+		subtitle := strings.Join(nonEmpty(item.Degree, item.Field), ", ")
+		education.Entries = append(education.Entries, resume.Entry{Title: item.Institution, Subtitle: subtitle, Period: resumePeriod(fmt.Sprint(item.StartYear), end)})
+	}
+	certificates := resume.Section{Title: "Certificates"}
+	for _, item := range r.Certificates {
+		certificates.Entries = append(certificates.Entries, resume.Entry{Title: item.Name, Subtitle: item.Issuer, Period: item.IssuedMonth, URL: item.URL})
+	}
+	languages := resume.Section{Title: "Languages"}
+	for _, item := range r.Languages {
+		languages.Entries = append(languages.Entries, resume.Entry{Title: item.Name, Subtitle: string(item.Level)})
+	}
+	links := resume.Section{Title: "Links"}
+	for _, item := range r.Links {
+		links.Entries = append(links.Entries, resume.Entry{Title: linkTitles[item.Kind], URL: item.URL})
+	}
+	for _, section := range []resume.Section{work, projects, education, certificates, languages, links} {
+		if len(section.Entries) > 0 {
+			doc.Sections = append(doc.Sections, section)
+		}
+	}
+	return doc
+}
+
+func resumePeriod(start, end string) string {
+	if end == "" {
+		end = "present"
+	}
+	return start + " – " + end
+}
+
+func nonEmpty(values ...string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,150 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/application"
+	"profzom/internal/domain/profile"
+	"profzom/internal/domain/vacancy"
+)
+
+type fakeResumeRepo struct {
+	resumes map[common.UUID]profile.Resume
+}
+
+func newFakeResumeRepo() *fakeResumeRepo {
+	return &fakeResumeRepo{resumes: map[common.UUID]profile.Resume{}}
+}
+
+func (r *fakeResumeRepo) GetByStudent(ctx context.Context, studentID common.UUID) (*profile.Resume, error) {
+	resume := r.resumes[studentID]
+	return &resume, nil
+}
+
+func (r *fakeResumeRepo) Replace(ctx context.Context, studentID common.UUID, resume profile.Resume) error {
+	r.resumes[studentID] = resume
+	return nil
+}
+
+func TestStudentCompletion_WeighsResumeSections(t *testing.T) {
+	p := completeStudentProfile(common.NewUUID())
+	if got := StudentCompletion(p, profile.Resume{}); got != 60 {
+		t.Fatalf("expected 60 for profile without resume, got %d", got)
+	}
+	if !IsStudentProfileComplete(p) {
+		t.Fatal("expected profile without resume to stay complete for applying")
+	}
+	full := profile.Resume{
+		Education:    []profile.Education{{Institution: "МГУ", StartYear: 2022}},
+		Experience:   []profile.Experience{{Kind: profile.ExperienceProject, Title: "Bot", StartMonth: "2023-01"}},
+		Links:        []profile.Link{{Kind: profile.LinkGitHub, URL: "https://github.com/anna"}},
+		Languages:    []profile.Language{{Name: "English", Level: "B2"}},
+		Certificates: []profile.Certificate{{Name: "Go course"}},
+	}
+	if got := StudentCompletion(p, full); got != 100 {
+		t.Fatalf("expected 100 for full resume, got %d", got)
+	}
+}
+
+func TestNormalizeResume_Validates(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	endYear := 2020
+	_, err := normalizeResume(profile.Resume{
+		Education:  []profile.Education{{Institution: " ", StartYear: 2021, EndYear: &endYear}},
+		Experience: []profile.Experience{{Kind: "job", Title: "Intern", StartMonth: "2024-05", EndMonth: "2024-01"}},
+		Links:      []profile.Link{{Kind: "github", URL: "javascript:alert(1)"}},
+		Languages:  []profile.Language{{Name: "English", Level: "fluent"}},
+	}, now)
+	var appErr *common.AppError
+	if !errors.As(err, &appErr) || appErr.Code != common.CodeValidation {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	for _, field := range []string{"education[0].institution", "education[0].end_year", "experience[0].kind", "experience[0].end_month", "links[0].url", "languages[0].level"} {
+		if _, ok := appErr.Fields[field]; !ok {
+			t.Fatalf("expected error for %s, got %v", field, appErr.Fields)
+		}
+	}
+}
+
+func TestNormalizeResume_NormalizesValues(t *testing.T) {
+	resume, err := normalizeResume(profile.Resume{
+		Experience: []profile.Experience{{Title: " Intern ", StartMonth: "2024-05"}},
+		Links:      []profile.Link{{Kind: "GitHub", URL: " https://github.com/anna "}},
+		Languages:  []profile.Language{{Name: "English", Level: "b2"}, {Name: "Русский", Level: "Native"}},
+	}, time.Now().UTC())
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if resume.Experience[0].Kind != profile.ExperienceWork || resume.Experience[0].Title != "Intern" {
+		t.Fatalf("expected default kind and trimmed title, got %+v", resume.Experience[0])
+	}
+	if resume.Links[0].Kind != profile.LinkGitHub || resume.Links[0].URL != "https://github.com/anna" {
+		t.Fatalf("expected normalized link, got %+v", resume.Links[0])
+	}
+	if resume.Languages[0].Level != "B2" || resume.Languages[1].Level != "native" {
+		t.Fatalf("expected canonical levels, got %+v", resume.Languages)
+	}
+}
+
+func TestProfileServiceUpsertStudent_KeepsResumeWhenOmitted(t *testing.T) {
+	students := &fakeStudentRepo{profiles: map[common.UUID]*profile.StudentProfile{}}
+	resumes := newFakeResumeRepo()
+	service := NewProfileService(students, nil, resumes, noopAnalyticsRepo{}, directUnitOfWork{})
+	studentID := common.NewUUID()
+	input := completeStudentProfile(studentID)
+
+	_, saved, err := service.UpsertStudent(context.Background(), input, &profile.Resume{Languages: []profile.Language{{Name: "English", Level: "C1"}}})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(saved.Languages) != 1 {
+		t.Fatalf("expected saved resume, got %+v", saved)
+	}
+	_, kept, err := service.UpsertStudent(context.Background(), input, nil)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(kept.Languages) != 1 {
+		t.Fatalf("expected resume to be kept when omitted, got %+v", kept)
+	}
+}
+
+func TestResumeServiceExportForApplication_RestrictedToParticipants(t *testing.T) {
+	applications := newFakeApplicationRepo()
+	vacancies := newFakeVacancyRepo()
+	studentID, companyID := common.NewUUID(), common.NewUUID()
+	student := completeStudentProfile(studentID)
+	students := &fakeStudentRepo{profiles: map[common.UUID]*profile.StudentProfile{studentID: &student}}
+	resumes := newFakeResumeRepo()
+	resumes.resumes[studentID] = profile.Resume{Experience: []profile.Experience{{Kind: profile.ExperienceWork, Title: "Intern", Organization: "Яндекс", StartMonth: "2024-06"}}}
+	vac, _ := vacancies.Create(context.Background(), vacancy.Vacancy{CompanyID: companyID, Title: "Go intern", Status: vacancy.StatusPublished})
+	app, _ := applications.Create(context.Background(), application.Application{VacancyID: vac.ID, StudentID: studentID, Status: application.StatusApplied})
+	service := NewResumeService(students, resumes, applications, vacancies)
+
+	pdf, err := service.ExportForApplication(context.Background(), app.ID, companyID, ResumeFormatPDF)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if !bytes.HasPrefix(pdf, []byte("%PDF-")) || !bytes.Contains(pdf, []byte("Yandeks")) {
+		t.Fatal("expected PDF with experience entry")
+	}
+	html, err := service.ExportForApplication(context.Background(), app.ID, studentID, ResumeFormatHTML)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if !strings.Contains(string(html), "Анна Смирнова") {
+		t.Fatal("expected HTML with student name")
+	}
+	if _, err := service.ExportForApplication(context.Background(), app.ID, common.NewUUID(), ResumeFormatPDF); !common.Is(err, common.CodeForbidden) {
+		t.Fatalf("expected forbidden for another company, got %v", err)
+	}
+	if _, err := service.ExportOwn(context.Background(), studentID, "docx"); !common.Is(err, common.CodeValidation) {
+		t.Fatalf("expected validation error for unknown format, got %v", err)
+	}
+}
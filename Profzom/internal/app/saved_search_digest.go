@@ -0,0 +1,48 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// SavedSearchDigestWorker периодически рассылает студентам дайджест новых вакансий по сохранённым поискам.
+type SavedSearchDigestWorker struct {
+	searches *SavedSearchService
+	interval time.Duration
+	logger   Logger
+}
+
+func NewSavedSearchDigestWorker(searches *SavedSearchService, interval time.Duration, logger Logger) *SavedSearchDigestWorker {
+	return &SavedSearchDigestWorker{searches: searches, interval: interval, logger: logger}
+}
+
+func (w *SavedSearchDigestWorker) Run(ctx context.Context) {
+	if w.interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		w.send(ctx)
+	}
+}
+
+func (w *SavedSearchDigestWorker) send(ctx context.Context) {
+	sent, err := w.searches.SendDigests(ctx)
+	if w.logger == nil {
+		return
+	}
+	if err != nil {
+		w.logger.Error(fmt.Sprintf("saved search digest failed: %v", err))
+		return
+	}
+	if sent > 0 {
+		w.logger.Info(fmt.Sprintf("saved search digest sent=%d", sent))
+	}
+}
@@ -0,0 +1,178 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/notification"
+	"profzom/internal/domain/outbox"
+	"profzom/internal/domain/savedsearch"
+	"profzom/internal/domain/vacancy"
+)
+
+const (
+	maxSavedSearchesPerStudent = 10
+	maxSavedSearchNameLength   = 100
+	savedSearchDigestBatch     = 500
+	savedSearchDigestLease     = 5 * time.Minute
+)
+
+type SavedSearchService struct {
+	repo     savedsearch.Repository
+	notifier Notifier
+	tx       UnitOfWork
+}
+
+func NewSavedSearchService(repo savedsearch.Repository, tx UnitOfWork, notifier Notifier) *SavedSearchService {
+	if notifier == nil {
+		notifier = noopNotifier{}
+	}
+	return &SavedSearchService{repo: repo, notifier: notifier, tx: tx}
+}
+
+func (s *SavedSearchService) Create(ctx context.Context, studentID common.UUID, name string, query savedsearch.Query) (*savedsearch.SavedSearch, error) {
+	normalized, err := normalizeSavedSearchQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	if normalized.IsEmpty() {
+		return nil, common.NewValidationError("invalid saved search", map[string]string{"query": "at least one search parameter is required"})
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = savedSearchDefaultName(normalized)
+	}
+	if len([]rune(name)) > maxSavedSearchNameLength {
+		return nil, common.NewValidationError("invalid saved search", map[string]string{"name": "name is too long"})
+	}
+	existing, err := s.repo.ListByStudent(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxSavedSearchesPerStudent {
+		return nil, common.NewError(common.CodeConflict, "saved search limit reached", nil)
+	}
+	return s.repo.Create(ctx, savedsearch.SavedSearch{StudentID: studentID, Name: name, Query: normalized})
+}
+
+func (s *SavedSearchService) List(ctx context.Context, studentID common.UUID) ([]savedsearch.SavedSearch, error) {
+	return s.repo.ListByStudent(ctx, studentID)
+}
+
+func (s *SavedSearchService) Delete(ctx context.Context, studentID, id common.UUID) error {
+	return s.repo.Delete(ctx, id, studentID)
+}
+
+// MatchVacancy ставит в очередь дайджеста совпадения опубликованной вакансии с сохранёнными поисками.
+func (s *SavedSearchService) MatchVacancy(ctx context.Context, vacancyID common.UUID) (int, error) {
+	return s.repo.RecordMatches(ctx, vacancyID)
+}
+
+// SendDigests отправляет каждому студенту одно уведомление со всеми новыми совпадениями и возвращает число дайджестов.
+// Совпадения забираются с lease, поэтому рассылку можно запускать на нескольких экземплярах API одновременно:
+// если дайджест не записался, совпадения вернутся в очередь после истечения lease.
+func (s *SavedSearchService) SendDigests(ctx context.Context) (int, error) {
+	matches, err := s.repo.ClaimMatches(ctx, time.Now().UTC(), savedSearchDigestBatch, savedSearchDigestLease)
+	if err != nil {
+		return 0, err
+	}
+	sent := 0
+	for start := 0; start < len(matches); {
+		end := start
+		for end < len(matches) && matches[end].StudentID == matches[start].StudentID {
+			end++
+		}
+		group := matches[start:end]
+		start = end
+		err := s.tx.Do(ctx, func(ctx context.Context) error {
+			vacancyIDs := make([]common.UUID, 0, len(group))
+			ids := make([]string, 0, len(group))
+			for _, match := range group {
+				vacancyIDs = append(vacancyIDs, match.VacancyID)
+				ids = append(ids, match.VacancyID.String())
+			}
+			if err := s.notifier.Notify(ctx, notification.Notification{
+				UserID:  group[0].StudentID,
+				Type:    notification.TypeSavedSearchDigest,
+				Text:    savedSearchDigestText(group),
+				Payload: map[string]string{"vacancy_ids": strings.Join(ids, ",")},
+			}); err != nil {
+				return err
+			}
+			return s.repo.MarkNotified(ctx, group[0].StudentID, vacancyIDs, time.Now().UTC())
+		})
+		if err != nil {
+			return sent, err
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+func normalizeSavedSearchQuery(query savedsearch.Query) (savedsearch.Query, error) {
+	normalized, err := normalizeSearchQuery(vacancy.SearchQuery{
+		Text:       query.Text,
+		Type:       query.Type,
+		Location:   query.Location,
+		SalaryFrom: query.SalaryFrom,
+		SalaryTo:   query.SalaryTo,
+		Period:     query.Period,
+	})
+	if err != nil {
+		return query, err
+	}
+	return savedsearch.Query{
+		Text:       normalized.Text,
+		Type:       normalized.Type,
+		Location:   normalized.Location,
+		SalaryFrom: normalized.SalaryFrom,
+		SalaryTo:   normalized.SalaryTo,
+		Period:     normalized.Period,
+	}, nil
+}
+
+func savedSearchDefaultName(query savedsearch.Query) string {
+	parts := make([]string, 0, 3)
+	for _, part := range []string{query.Text, query.Type, query.Location} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) == 0 {
+		return "Vacancies by salary"
+	}
+	return previewText(strings.Join(parts, ", "), maxSavedSearchNameLength-1)
+}
+
+// SavedSearchSink подписан на аналитические события outbox и сверяет только что опубликованные вакансии с сохранёнными поисками.
+// RecordMatches идемпотентен, поэтому повторная доставка события безопасна.
+type SavedSearchSink struct {
+	searches *SavedSearchService
+}
+
+func NewSavedSearchSink(searches *SavedSearchService) *SavedSearchSink {
+	return &SavedSearchSink{searches: searches}
+}
+
+func (s *SavedSearchSink) Deliver(ctx context.Context, message outbox.Message) error {
+	var decoded analyticsMessage
+	if err := json.Unmarshal(message.Payload, &decoded); err != nil {
+		return common.NewError(common.CodeInternal, "failed to decode analytics event", err)
+	}
+	switch decoded.Name {
+	case "vacancy.created", "vacancy.published", "vacancy.reopened":
+	default:
+		return nil
+	}
+	var payload struct {
+		VacancyID common.UUID `json:"vacancy_id"`
+	}
+	if err := json.Unmarshal(decoded.Payload, &payload); err != nil || payload.VacancyID == "" {
+		return nil
+	}
+	_, err := s.searches.MatchVacancy(ctx, payload.VacancyID)
+	return err
+}
@@ -0,0 +1,171 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/outbox"
+	"profzom/internal/domain/savedsearch"
+)
+
+type fakeSavedSearchRepo struct {
+	searches []savedsearch.SavedSearch
+	pending  []savedsearch.Match
+	claimed  map[common.UUID]time.Time
+	notified map[common.UUID][]common.UUID
+	matched  []common.UUID
+}
+
+func newFakeSavedSearchRepo() *fakeSavedSearchRepo {
+	return &fakeSavedSearchRepo{notified: make(map[common.UUID][]common.UUID), claimed: make(map[common.UUID]time.Time)}
+}
+
+func (r *fakeSavedSearchRepo) Create(ctx context.Context, search savedsearch.SavedSearch) (*savedsearch.SavedSearch, error) {
+	search.ID = common.NewUUID()
+	r.searches = append(r.searches, search)
+	return &search, nil
+}
+
+func (r *fakeSavedSearchRepo) ListByStudent(ctx context.Context, studentID common.UUID) ([]savedsearch.SavedSearch, error) {
+	var items []savedsearch.SavedSearch
+	for _, search := range r.searches {
+		if search.StudentID == studentID {
+			items = append(items, search)
+		}
+	}
+	return items, nil
+}
+
+func (r *fakeSavedSearchRepo) Delete(ctx context.Context, id, studentID common.UUID) error {
+	return nil
+}
+
+func (r *fakeSavedSearchRepo) RecordMatches(ctx context.Context, vacancyID common.UUID) (int, error) {
+	r.matched = append(r.matched, vacancyID)
+	return 1, nil
+}
+
+func (r *fakeSavedSearchRepo) ClaimMatches(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]savedsearch.Match, error) {
+	var items []savedsearch.Match
+	for _, match := range r.pending {
+		if len(items) == limit {
+			break
+		}
+		if until, ok := r.claimed[match.VacancyID]; ok && until.After(now) {
+			continue
+		}
+		r.claimed[match.VacancyID] = now.Add(lease)
+		items = append(items, match)
+	}
+	return items, nil
+}
+
+func (r *fakeSavedSearchRepo) MarkNotified(ctx context.Context, studentID common.UUID, vacancyIDs []common.UUID, notifiedAt time.Time) error {
+	r.notified[studentID] = append(r.notified[studentID], vacancyIDs...)
+	for _, id := range vacancyIDs {
+		for i, match := range r.pending {
+			if match.StudentID == studentID && match.VacancyID == id {
+				r.pending = append(r.pending[:i], r.pending[i+1:]...)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func TestSavedSearchService_CreateValidation(t *testing.T) {
+	service := NewSavedSearchService(newFakeSavedSearchRepo(), directUnitOfWork{}, nil)
+	studentID := common.NewUUID()
+
+	if _, err := service.Create(context.Background(), studentID, "", savedsearch.Query{}); !common.Is(err, common.CodeValidation) {
+		t.Fatalf("expected validation error for empty query, got %v", err)
+	}
+	created, err := service.Create(context.Background(), studentID, "", savedsearch.Query{Text: " golang ", Location: "Москва"})
+	if err != nil {
+		t.Fatalf("expected create to succeed, got %v", err)
+	}
+	if created.Query.Text != "golang" || created.Name != "golang, Москва" {
+		t.Fatalf("expected normalized query and default name, got %+v", created)
+	}
+}
+
+func TestSavedSearchService_CreateLimit(t *testing.T) {
+	service := NewSavedSearchService(newFakeSavedSearchRepo(), directUnitOfWork{}, nil)
+	studentID := common.NewUUID()
+	for i := 0; i < maxSavedSearchesPerStudent; i++ {
+		if _, err := service.Create(context.Background(), studentID, "", savedsearch.Query{Text: "go"}); err != nil {
+			t.Fatalf("expected create to succeed, got %v", err)
+		}
+	}
+	if _, err := service.Create(context.Background(), studentID, "", savedsearch.Query{Text: "go"}); !common.Is(err, common.CodeConflict) {
+		t.Fatalf("expected conflict after limit, got %v", err)
+	}
+}
+
+func TestSavedSearchService_SendDigestsGroupsByStudent(t *testing.T) {
+	repo := newFakeSavedSearchRepo()
+	notifier := &recordingNotifier{}
+	service := NewSavedSearchService(repo, directUnitOfWork{}, notifier)
+	first, second := common.NewUUID(), common.NewUUID()
+	repo.pending = []savedsearch.Match{
+		{StudentID: first, VacancyID: common.NewUUID(), VacancyTitle: "Go intern", SearchName: "go"},
+		{StudentID: first, VacancyID: common.NewUUID(), VacancyTitle: "Backend junior", SearchName: "go"},
+		{StudentID: second, VacancyID: common.NewUUID(), VacancyTitle: "QA intern", SearchName: "qa"},
+	}
+
+	sent, err := service.SendDigests(context.Background())
+	if err != nil {
+		t.Fatalf("expected digests to be sent, got %v", err)
+	}
+	if sent != 2 || len(notifier.sent) != 2 {
+		t.Fatalf("expected 2 digests, got %d (notifications %d)", sent, len(notifier.sent))
+	}
+	if !strings.Contains(notifier.sent[0].Text, "Go intern") || !strings.Contains(notifier.sent[0].Text, "Backend junior") {
+		t.Fatalf("expected digest to list both vacancies, got %q", notifier.sent[0].Text)
+	}
+	if len(repo.notified[first]) != 2 || len(repo.notified[second]) != 1 {
+		t.Fatalf("expected matches to be marked notified, got %v", repo.notified)
+	}
+}
+
+func TestSavedSearchService_SendDigestsSkipsClaimedMatches(t *testing.T) {
+	repo := newFakeSavedSearchRepo()
+	notifier := &recordingNotifier{}
+	service := NewSavedSearchService(repo, directUnitOfWork{}, notifier)
+	studentID := common.NewUUID()
+	repo.pending = []savedsearch.Match{{StudentID: studentID, VacancyID: common.NewUUID(), VacancyTitle: "Go intern", SearchName: "go"}}
+	// другой экземпляр уже забрал совпадение и ещё не отправил дайджест
+	if _, err := repo.ClaimMatches(context.Background(), time.Now().UTC(), savedSearchDigestBatch, savedSearchDigestLease); err != nil {
+		t.Fatalf("expected claim to succeed, got %v", err)
+	}
+
+	sent, err := service.SendDigests(context.Background())
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if sent != 0 || len(notifier.sent) != 0 {
+		t.Fatalf("expected claimed match to be skipped, got %d digests", sent)
+	}
+}
+
+func TestSavedSearchSink_MatchesPublishedVacancies(t *testing.T) {
+	repo := newFakeSavedSearchRepo()
+	sink := NewSavedSearchSink(NewSavedSearchService(repo, directUnitOfWork{}, nil))
+	vacancyID := common.NewUUID()
+	deliver := func(name string) {
+		payload, _ := json.Marshal(analyticsMessage{Name: name, Payload: analyticsPayload(context.Background(), map[string]string{"vacancy_id": vacancyID.String()})})
+		if err := sink.Deliver(context.Background(), outbox.Message{Topic: outbox.TopicAnalytics, Payload: payload}); err != nil {
+			t.Fatalf("expected delivery to succeed, got %v", err)
+		}
+	}
+
+	deliver("vacancy.closed")
+	deliver("vacancy.published")
+	if len(repo.matched) != 1 || repo.matched[0] != vacancyID {
+		t.Fatalf("expected only published vacancy to be matched, got %v", repo.matched)
+	}
+}
@@ -0,0 +1,23 @@
+package app
+
+import (
+	"strings"
+	"unicode"
+
+	"profzom/internal/domain/profile"
+)
+
+// skillTerms разбивает произвольный текст требования на канонические навыки: слова и пары соседних слов.
+func skillTerms(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '#' && r != '.'
+	})
+	terms := make([]string, 0, len(words)*2)
+	for i, word := range words {
+		terms = append(terms, profile.NormalizeSkill(word))
+		if i+1 < len(words) {
+			terms = append(terms, profile.NormalizeSkill(word+" "+words[i+1]))
+		}
+	}
+	return terms
+}
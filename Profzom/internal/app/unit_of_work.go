@@ -0,0 +1,9 @@
+package app
+
+import "context"
+
+// UnitOfWork выполняет fn в одной транзакции: репозитории, получившие переданный ctx, пишут в неё,
+// а ошибка fn откатывает и доменные записи, и события outbox.
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -12,10 +12,11 @@ import (
 type UserService struct {
     users     user.Repository
     analytics analytics.Repository
+    tx        UnitOfWork
 }
 
-func NewUserService(users user.Repository, analytics analytics.Repository) *UserService {
-    return &UserService{users: users, analytics: analytics}
+func NewUserService(users user.Repository, analytics analytics.Repository, tx UnitOfWork) *UserService {
+    return &UserService{users: users, analytics: analytics, tx: tx}
 }
 
 func (s *UserService) SetRole(ctx context.Context, userID common.UUID, role user.Role) error {
@@ -30,12 +31,32 @@ func (s *UserService) SetRole(ctx context.Context, userID common.UUID, role user
     if err != nil {
         return err
     }
-    if len(roles) > 0 {
-        return common.NewValidationError("role already selected", map[string]string{"role": "role already selected"})
+    // роль admin выдаётся отдельно и не мешает выбрать роль студента или компании
+    for _, existing := range roles {
+        if existing == user.RoleStudent || existing == user.RoleCompany {
+            return common.NewValidationError("role already selected", map[string]string{"role": "role already selected"})
+        }
     }
-    if err := s.users.SetRoles(ctx, userID, []user.Role{normalized}); err != nil {
-        return err
+    return s.tx.Do(ctx, func(ctx context.Context) error {
+        if err := s.users.SetRoles(ctx, userID, append(roles, normalized)); err != nil {
+            return err
+        }
+        return s.analytics.Create(ctx, analytics.Event{Name: "user.role_selected", UserID: &userID, Payload: analyticsPayload(ctx, map[string]string{"role": string(normalized)})})
+    })
+}
+
+// ActiveRoles отклоняет запросы заблокированных и удалённых аккаунтов, чьи access-токены ещё не истекли,
+// и возвращает текущие роли из базы: отозванная роль перестаёт действовать сразу, а не после обновления токена.
+func (s *UserService) ActiveRoles(ctx context.Context, userID common.UUID) ([]user.Role, error) {
+    blocked, err := s.users.IsBlocked(ctx, userID)
+    if err != nil {
+        if common.Is(err, common.CodeNotFound) {
+            return nil, common.NewError(common.CodeUnauthorized, "account not found", nil)
+        }
+        return nil, err
+    }
+    if blocked {
+        return nil, common.NewError(common.CodeForbidden, "account is blocked", nil)
     }
-    _ = s.analytics.Create(ctx, analytics.Event{Name: "user.role_selected", UserID: &userID, Payload: analyticsPayload(ctx, map[string]string{"role": string(normalized)})})
-    return nil
+    return s.users.ListRoles(ctx, userID)
 }
@@ -3,21 +3,56 @@ package app
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strings"
+	"time"
 
 	"profzom/internal/common"
 	"profzom/internal/domain/analytics"
+	"profzom/internal/domain/application"
+	"profzom/internal/domain/moderation"
+	"profzom/internal/domain/notification"
 	"profzom/internal/domain/profile"
 	"profzom/internal/domain/vacancy"
 )
 
+const defaultSalaryCurrency = "RUB"
+
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
 type VacancyService struct {
-	repo      vacancy.Repository
-	companies profile.CompanyRepository
-	analytics analytics.Repository
+	repo         vacancy.Repository
+	companies    profile.CompanyRepository
+	analytics    analytics.Repository
+	tx           UnitOfWork
+	applications application.Repository
+	notifier     Notifier
+	// verifications задан, если публикация разрешена только проверенным компаниям.
+	verifications profile.VerificationRepository
+	// screen задерживает публикацию вакансий, на которых сработала автоматическая модерация.
+	screen contentScreen
+}
+
+func NewVacancyService(repo vacancy.Repository, companies profile.CompanyRepository, analytics analytics.Repository, tx UnitOfWork) *VacancyService {
+	return &VacancyService{repo: repo, companies: companies, analytics: analytics, tx: tx, notifier: noopNotifier{}}
+}
+
+// NewVacancyServiceWithNotifier дополнительно уведомляет откликнувшихся студентов о закрытии вакансии.
+func NewVacancyServiceWithNotifier(repo vacancy.Repository, companies profile.CompanyRepository, analytics analytics.Repository, tx UnitOfWork, applications application.Repository, notifier Notifier) *VacancyService {
+	return &VacancyService{repo: repo, companies: companies, analytics: analytics, tx: tx, applications: applications, notifier: notifier}
 }
 
-func NewVacancyService(repo vacancy.Repository, companies profile.CompanyRepository, analytics analytics.Repository) *VacancyService {
-	return &VacancyService{repo: repo, companies: companies, analytics: analytics}
+// RequireVerification запрещает публиковать вакансии компаниям без подтверждённой проверки реквизитов.
+func (s *VacancyService) RequireVerification(verifications profile.VerificationRepository) *VacancyService {
+	s.verifications = verifications
+	return s
+}
+
+// WithModeration проверяет вакансии при создании, публикации и правке опубликованных;
+// сработавшая проверка переводит вакансию в pending_review и ставит её в очередь модерации.
+func (s *VacancyService) WithModeration(checker moderation.Checker, queue moderation.Repository) *VacancyService {
+	s.screen = contentScreen{checker: checker, queue: queue}
+	return s
 }
 
 func (s *VacancyService) Create(ctx context.Context, v vacancy.Vacancy) (*vacancy.Vacancy, error) {
@@ -36,8 +71,9 @@ func (s *VacancyService) Create(ctx context.Context, v vacancy.Vacancy) (*vacanc
 	if len(v.Conditions) == 0 {
 		return nil, common.NewError(common.CodeValidation, "conditions are required", nil)
 	}
-	if v.Salary == "" {
-		return nil, common.NewError(common.CodeValidation, "salary is required", nil)
+	normalizeSalary(&v)
+	if fields := validateSalary(v, map[string]string{}); len(fields) > 0 {
+		return nil, common.NewValidationError("invalid vacancy", fields)
 	}
 	if v.Location == "" {
 		return nil, common.NewError(common.CodeValidation, "location is required", nil)
@@ -45,24 +81,318 @@ func (s *VacancyService) Create(ctx context.Context, v vacancy.Vacancy) (*vacanc
 	if v.Status == "" {
 		v.Status = vacancy.StatusPublished
 	}
-	created, err := s.repo.Create(ctx, v)
+	if v.Status != vacancy.StatusDraft && v.Status != vacancy.StatusPublished {
+		return nil, common.NewValidationError("invalid vacancy", map[string]string{"status": "status must be draft or published"})
+	}
+	if err := validateVacancyExpiry(v); err != nil {
+		return nil, err
+	}
+	var verdict moderation.Verdict
+	if v.Status == vacancy.StatusPublished {
+		if err := s.ensureVerified(ctx, v.CompanyID); err != nil {
+			return nil, err
+		}
+		var err error
+		if verdict, err = s.screen.check(ctx, moderation.ContentVacancy, v.CompanyID, vacancyText(v)); err != nil {
+			return nil, err
+		}
+		if verdict.Hold {
+			v.Status = vacancy.StatusPendingReview
+		}
+	}
+	var created *vacancy.Vacancy
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		created, err = s.repo.Create(ctx, v)
+		if err != nil {
+			return err
+		}
+		if err := s.analytics.Create(ctx, analytics.Event{Name: "vacancy.created", UserID: &v.CompanyID, Payload: analyticsPayload(ctx, map[string]string{"vacancy_id": created.ID.String()})}); err != nil {
+			return err
+		}
+		if verdict.Hold {
+			return s.screen.enqueue(ctx, moderation.ContentVacancy, created.ID, created.CompanyID, vacancyText(*created), verdict.Reasons)
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	_ = s.analytics.Create(ctx, analytics.Event{Name: "vacancy.created", UserID: &v.CompanyID, Payload: analyticsPayload(ctx, map[string]string{"vacancy_id": created.ID.String()})})
 	return created, nil
 }
 
 func (s *VacancyService) Update(ctx context.Context, v vacancy.Vacancy) (*vacancy.Vacancy, error) {
-	updated, err := s.repo.Update(ctx, v)
+	current, err := s.ownedVacancy(ctx, v.CompanyID, v.ID)
 	if err != nil {
 		return nil, err
 	}
-	_ = s.analytics.Create(ctx, analytics.Event{Name: "vacancy.updated", UserID: &v.CompanyID, Payload: analyticsPayload(ctx, map[string]string{"vacancy_id": updated.ID.String()})})
-	return updated, nil
+	if current.Status == vacancy.StatusArchived {
+		return nil, common.NewError(common.CodeValidation, "archived vacancy cannot be edited", nil)
+	}
+	// уже сохранённый и истёкший expires_at клиент может прислать обратно без изменений
+	if !sameExpiry(v.ExpiresAt, current.ExpiresAt) {
+		if err := validateVacancyExpiry(v); err != nil {
+			return nil, err
+		}
+	}
+	normalizeSalary(&v)
+	if fields := validateSalary(v, map[string]string{}); len(fields) > 0 {
+		return nil, common.NewValidationError("invalid vacancy", fields)
+	}
+	v.Status = current.Status
+	v.CreatedAt = current.CreatedAt
+	if current.Status != vacancy.StatusPublished && current.Status != vacancy.StatusPendingReview {
+		return s.updateWithEvent(ctx, v, "vacancy.updated", v.CompanyID, "", false)
+	}
+	// правка опубликованной вакансии проверяется заново, а ожидающая решения обновляет запись в очереди
+	verdict, err := s.screen.check(ctx, moderation.ContentVacancy, v.CompanyID, vacancyText(v))
+	if err != nil {
+		return nil, err
+	}
+	if !verdict.Hold && current.Status == vacancy.StatusPublished {
+		return s.updateWithEvent(ctx, v, "vacancy.updated", v.CompanyID, "", false)
+	}
+	v.Status = vacancy.StatusPendingReview
+	return s.holdForReview(ctx, v, "vacancy.updated", verdict.Reasons)
 }
 
 func (s *VacancyService) Publish(ctx context.Context, companyID, vacancyID common.UUID) (*vacancy.Vacancy, error) {
+	v, err := s.ownedVacancy(ctx, companyID, vacancyID)
+	if err != nil {
+		return nil, err
+	}
+	switch v.Status {
+	case vacancy.StatusArchived:
+		return nil, common.NewError(common.CodeValidation, "archived vacancy cannot be published", nil)
+	case vacancy.StatusPendingReview:
+		return nil, common.NewError(common.CodeValidation, "vacancy is pending moderation review", nil)
+	}
+	if err := s.ensurePublishable(ctx, companyID, *v); err != nil {
+		return nil, err
+	}
+	return s.publishScreened(ctx, *v, "vacancy.published")
+}
+
+func (s *VacancyService) Close(ctx context.Context, companyID, vacancyID common.UUID) (*vacancy.Vacancy, error) {
+	v, err := s.ownedVacancy(ctx, companyID, vacancyID)
+	if err != nil {
+		return nil, err
+	}
+	if v.Status != vacancy.StatusPublished {
+		return nil, common.NewError(common.CodeValidation, "only published vacancy can be closed", nil)
+	}
+	v.Status = vacancy.StatusClosed
+	return s.updateWithEvent(ctx, *v, "vacancy.closed", companyID, "manual", true)
+}
+
+func (s *VacancyService) Reopen(ctx context.Context, companyID, vacancyID common.UUID) (*vacancy.Vacancy, error) {
+	v, err := s.ownedVacancy(ctx, companyID, vacancyID)
+	if err != nil {
+		return nil, err
+	}
+	if v.Status != vacancy.StatusClosed {
+		return nil, common.NewError(common.CodeValidation, "only closed vacancy can be reopened", nil)
+	}
+	if err := s.ensurePublishable(ctx, companyID, *v); err != nil {
+		return nil, err
+	}
+	return s.publishScreened(ctx, *v, "vacancy.reopened")
+}
+
+// publishScreened публикует вакансию, если её пропустила автоматическая модерация, иначе отправляет в очередь.
+func (s *VacancyService) publishScreened(ctx context.Context, v vacancy.Vacancy, name string) (*vacancy.Vacancy, error) {
+	verdict, err := s.screen.check(ctx, moderation.ContentVacancy, v.CompanyID, vacancyText(v))
+	if err != nil {
+		return nil, err
+	}
+	if !verdict.Hold {
+		v.Status = vacancy.StatusPublished
+		return s.updateWithEvent(ctx, v, name, v.CompanyID, "", false)
+	}
+	v.Status = vacancy.StatusPendingReview
+	return s.holdForReview(ctx, v, "vacancy.held", verdict.Reasons)
+}
+
+func (s *VacancyService) holdForReview(ctx context.Context, v vacancy.Vacancy, name string, reasons []string) (*vacancy.Vacancy, error) {
+	var updated *vacancy.Vacancy
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		updated, err = s.updateWithEvent(ctx, v, name, v.CompanyID, "", false)
+		if err != nil {
+			return err
+		}
+		return s.screen.enqueue(ctx, moderation.ContentVacancy, updated.ID, updated.CompanyID, vacancyText(*updated), reasons)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// ApproveHeld публикует вакансию по решению модератора. Вакансию, которая уже не ждёт проверки
+// (компания её архивировала), решение не меняет. Вызывается в транзакции ModerationService.
+func (s *VacancyService) ApproveHeld(ctx context.Context, moderatorID, vacancyID common.UUID) error {
+	v, err := s.repo.GetByID(ctx, vacancyID)
+	if err != nil || v.Status != vacancy.StatusPendingReview {
+		return err
+	}
+	v.Status = vacancy.StatusPublished
+	// событие vacancy.published нужно и подпискам на поиск: для них вакансия появляется только сейчас
+	updated, err := s.updateWithEvent(ctx, *v, "vacancy.published", moderatorID, "moderation", false)
+	if err != nil {
+		return err
+	}
+	return s.notifier.Notify(ctx, notification.Notification{
+		UserID:  updated.CompanyID,
+		Type:    notification.TypeModerationReviewed,
+		Text:    vacancyModerationText(updated.Title, true, ""),
+		Payload: map[string]string{"vacancy_id": updated.ID.String(), "status": string(moderation.StatusApproved)},
+	})
+}
+
+// RejectHeld возвращает задержанную вакансию в черновик и сообщает компании причину.
+func (s *VacancyService) RejectHeld(ctx context.Context, moderatorID, vacancyID common.UUID, reason string) error {
+	v, err := s.repo.GetByID(ctx, vacancyID)
+	if err != nil || v.Status != vacancy.StatusPendingReview {
+		return err
+	}
+	v.Status = vacancy.StatusDraft
+	updated, err := s.updateWithEvent(ctx, *v, "vacancy.moderation_rejected", moderatorID, "", false)
+	if err != nil {
+		return err
+	}
+	return s.notifier.Notify(ctx, notification.Notification{
+		UserID:  updated.CompanyID,
+		Type:    notification.TypeModerationReviewed,
+		Text:    vacancyModerationText(updated.Title, false, reason),
+		Payload: map[string]string{"vacancy_id": updated.ID.String(), "status": string(moderation.StatusRejected)},
+	})
+}
+
+func (s *VacancyService) Archive(ctx context.Context, companyID, vacancyID common.UUID) (*vacancy.Vacancy, error) {
+	v, err := s.ownedVacancy(ctx, companyID, vacancyID)
+	if err != nil {
+		return nil, err
+	}
+	if v.Status == vacancy.StatusArchived {
+		return nil, common.NewError(common.CodeValidation, "vacancy is already archived", nil)
+	}
+	wasPublished := v.Status == vacancy.StatusPublished
+	v.Status = vacancy.StatusArchived
+	return s.updateWithEvent(ctx, *v, "vacancy.archived", companyID, "", wasPublished)
+}
+
+// Unpublish снимает опубликованную вакансию с публикации по решению модератора: вакансия возвращается в черновик,
+// откликнувшиеся студенты и компания получают уведомления.
+func (s *VacancyService) Unpublish(ctx context.Context, moderatorID, vacancyID common.UUID, reason string) (*vacancy.Vacancy, error) {
+	v, err := s.repo.GetByID(ctx, vacancyID)
+	if err != nil {
+		return nil, err
+	}
+	if v.Status != vacancy.StatusPublished {
+		return nil, common.NewError(common.CodeValidation, "only published vacancy can be unpublished", nil)
+	}
+	v.Status = vacancy.StatusDraft
+	var updated *vacancy.Vacancy
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		updated, err = s.updateWithEvent(ctx, *v, "vacancy.unpublished", moderatorID, "moderation", true)
+		if err != nil {
+			return err
+		}
+		return s.notifier.Notify(ctx, notification.Notification{
+			UserID:  updated.CompanyID,
+			Type:    notification.TypeVacancyUnpublished,
+			Text:    vacancyUnpublishedNotificationText(updated.Title, reason),
+			Payload: map[string]string{"vacancy_id": updated.ID.String()},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// CloseExpired закрывает опубликованные вакансии с истёкшим expires_at и возвращает их количество.
+func (s *VacancyService) CloseExpired(ctx context.Context) (int, error) {
+	var count int
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		closed, err := s.repo.CloseExpired(ctx, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+		for _, v := range closed {
+			companyID := v.CompanyID
+			if err := s.analytics.Create(ctx, analytics.Event{Name: "vacancy.closed", UserID: &companyID, Payload: analyticsPayload(ctx, map[string]string{"vacancy_id": v.ID.String(), "reason": "expired"})}); err != nil {
+				return err
+			}
+			if err := s.notifyApplicants(ctx, v); err != nil {
+				return err
+			}
+		}
+		count = len(closed)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// updateWithEvent сохраняет вакансию и событие аналитики в одной транзакции; reason добавляется в payload, если не пуст.
+// notifyApplicants нужен, когда опубликованная вакансия перестаёт принимать отклики.
+func (s *VacancyService) updateWithEvent(ctx context.Context, v vacancy.Vacancy, name string, userID common.UUID, reason string, notifyApplicants bool) (*vacancy.Vacancy, error) {
+	var updated *vacancy.Vacancy
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		updated, err = s.repo.Update(ctx, v)
+		if err != nil {
+			return err
+		}
+		payload := map[string]string{"vacancy_id": updated.ID.String()}
+		if reason != "" {
+			payload["reason"] = reason
+		}
+		if err := s.analytics.Create(ctx, analytics.Event{Name: name, UserID: &userID, Payload: analyticsPayload(ctx, payload)}); err != nil {
+			return err
+		}
+		if notifyApplicants {
+			return s.notifyApplicants(ctx, *updated)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// notifyApplicants сообщает студентам с незавершёнными откликами, что вакансия больше не принимает отклики.
+func (s *VacancyService) notifyApplicants(ctx context.Context, v vacancy.Vacancy) error {
+	if s.applications == nil {
+		return nil
+	}
+	apps, err := s.applications.ListByVacancy(ctx, v.ID)
+	if err != nil {
+		return err
+	}
+	for _, app := range apps {
+		if isFinalStatus(normalizeApplicationStatus(app.Status)) {
+			continue
+		}
+		if err := s.notifier.Notify(ctx, notification.Notification{
+			UserID:  app.StudentID,
+			Type:    notification.TypeVacancyClosed,
+			Text:    vacancyClosedNotificationText(v.Title),
+			Payload: map[string]string{"vacancy_id": v.ID.String(), "application_id": app.ID.String()},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *VacancyService) ownedVacancy(ctx context.Context, companyID, vacancyID common.UUID) (*vacancy.Vacancy, error) {
 	v, err := s.repo.GetByID(ctx, vacancyID)
 	if err != nil {
 		return nil, err
@@ -70,26 +400,97 @@ func (s *VacancyService) Publish(ctx context.Context, companyID, vacancyID commo
 	if v.CompanyID != companyID {
 		return nil, common.NewError(common.CodeForbidden, "vacancy belongs to another company", nil)
 	}
+	return v, nil
+}
+
+func (s *VacancyService) ensurePublishable(ctx context.Context, companyID common.UUID, v vacancy.Vacancy) error {
 	companyProfile, err := s.companies.GetByUserID(ctx, companyID)
 	if err != nil {
 		if common.Is(err, common.CodeNotFound) {
-			return nil, common.NewError(common.CodeValidation, "company profile is required", nil)
+			return common.NewError(common.CodeValidation, "company profile is required", nil)
 		}
-		return nil, err
+		return err
 	}
 	if !IsCompanyProfileComplete(*companyProfile) {
-		return nil, common.NewError(common.CodeValidation, "company profile is incomplete", nil)
+		return common.NewError(common.CodeValidation, "company profile is incomplete", nil)
 	}
-	if err := validateVacancyForPublish(*v); err != nil {
-		return nil, err
+	if err := s.ensureVerified(ctx, companyID); err != nil {
+		return err
 	}
-	v.Status = vacancy.StatusPublished
-	updated, err := s.repo.Update(ctx, *v)
-	if err != nil {
-		return nil, err
+	if err := validateVacancyForPublish(v); err != nil {
+		return err
 	}
-	_ = s.analytics.Create(ctx, analytics.Event{Name: "vacancy.published", UserID: &companyID, Payload: analyticsPayload(ctx, map[string]string{"vacancy_id": updated.ID.String()})})
-	return updated, nil
+	return validateVacancyExpiry(v)
+}
+
+func (s *VacancyService) ensureVerified(ctx context.Context, companyID common.UUID) error {
+	if s.verifications == nil {
+		return nil
+	}
+	current, err := s.verifications.GetByCompany(ctx, companyID)
+	if err != nil && !common.Is(err, common.CodeNotFound) {
+		return err
+	}
+	if current == nil || current.Status != profile.VerificationVerified {
+		return common.NewError(common.CodeValidation, "company verification is required", nil)
+	}
+	return nil
+}
+
+// vacancyText — текст вакансии для автоматической модерации.
+func vacancyText(v vacancy.Vacancy) string {
+	parts := []string{v.Title, v.Type, v.Description, v.Location}
+	parts = append(parts, v.Requirements...)
+	parts = append(parts, v.Conditions...)
+	return strings.Join(parts, "\n")
+}
+
+func normalizeSalary(v *vacancy.Vacancy) {
+	v.Currency = strings.ToUpper(strings.TrimSpace(v.Currency))
+	if v.Currency == "" && (v.SalaryMin != nil || v.SalaryMax != nil) {
+		v.Currency = defaultSalaryCurrency
+	}
+	v.Period = vacancy.SalaryPeriod(strings.ToLower(strings.TrimSpace(string(v.Period))))
+	if v.Period == "" {
+		v.Period = vacancy.SalaryPeriodMonth
+	}
+}
+
+// validateSalary дописывает ошибки вилки зарплаты в fields и возвращает его же.
+func validateSalary(v vacancy.Vacancy, fields map[string]string) map[string]string {
+	if v.SalaryMin == nil && v.SalaryMax == nil && !v.IsNegotiable {
+		fields["salary"] = "salary range is required unless is_negotiable is set"
+	}
+	if v.SalaryMin != nil && *v.SalaryMin < 0 {
+		fields["salary_min"] = "salary_min must be >= 0"
+	}
+	if v.SalaryMax != nil && *v.SalaryMax < 0 {
+		fields["salary_max"] = "salary_max must be >= 0"
+	}
+	if v.SalaryMin != nil && v.SalaryMax != nil && *v.SalaryMin > *v.SalaryMax {
+		fields["salary_max"] = "salary_max must be >= salary_min"
+	}
+	if v.Currency != "" && !currencyPattern.MatchString(v.Currency) {
+		fields["currency"] = "currency must be a 3-letter ISO code"
+	}
+	if v.Period != vacancy.SalaryPeriodHour && v.Period != vacancy.SalaryPeriodMonth {
+		fields["period"] = "period must be hour or month"
+	}
+	return fields
+}
+
+func validateVacancyExpiry(v vacancy.Vacancy) error {
+	if v.ExpiresAt != nil && !v.ExpiresAt.After(time.Now().UTC()) {
+		return common.NewValidationError("invalid vacancy", map[string]string{"expires_at": "expires_at must be in the future"})
+	}
+	return nil
+}
+
+func sameExpiry(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
 }
 
 func validateVacancyForPublish(v vacancy.Vacancy) error {
@@ -122,9 +523,7 @@ func validateVacancyForPublish(v vacancy.Vacancy) error {
 			fields[fmt.Sprintf("conditions[%d]", i)] = "condition must be at least 2 characters"
 		}
 	}
-	if v.Salary == "" {
-		fields["salary"] = "salary is required"
-	}
+	validateSalary(v, fields)
 	if v.Location == "" {
 		fields["location"] = "location is required"
 	}
@@ -134,14 +533,63 @@ func validateVacancyForPublish(v vacancy.Vacancy) error {
 	return nil
 }
 
-func (s *VacancyService) Get(ctx context.Context, id common.UUID) (*vacancy.Vacancy, error) {
-	return s.repo.GetByID(ctx, id)
+// Get отдаёт вакансию по id. Опубликованные и закрытые вакансии видны всем, черновики, архив и вакансии на модерации —
+// только компании-владельцу и администратору; остальным возвращается CodeNotFound, чтобы не раскрывать, что вакансия есть.
+// viewerID пуст для анонимного запроса.
+func (s *VacancyService) Get(ctx context.Context, id, viewerID common.UUID, admin bool) (*vacancy.Vacancy, error) {
+	v, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case v.Status == vacancy.StatusPublished, v.Status == vacancy.StatusClosed:
+	case admin, viewerID != "" && v.CompanyID == viewerID:
+	default:
+		return nil, common.NewError(common.CodeNotFound, "vacancy not found", nil)
+	}
+	return v, nil
 }
 
-func (s *VacancyService) ListPublished(ctx context.Context, limit, offset int) ([]vacancy.Vacancy, error) {
-	return s.repo.ListPublished(ctx, limit, offset)
+func (s *VacancyService) ListPublished(ctx context.Context, page common.PageRequest) ([]vacancy.Vacancy, *common.Cursor, error) {
+	return s.repo.ListPublished(ctx, normalizePage(page))
+}
+
+func (s *VacancyService) Search(ctx context.Context, query vacancy.SearchQuery) ([]vacancy.Vacancy, *common.Cursor, error) {
+	normalized, err := normalizeSearchQuery(query)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.repo.Search(ctx, normalized)
+}
+
+func normalizeSearchQuery(query vacancy.SearchQuery) (vacancy.SearchQuery, error) {
+	query.Text = strings.TrimSpace(query.Text)
+	query.Type = strings.TrimSpace(query.Type)
+	query.Location = strings.TrimSpace(query.Location)
+	query.Period = vacancy.SalaryPeriod(strings.ToLower(strings.TrimSpace(string(query.Period))))
+	if query.Period != "" && query.Period != vacancy.SalaryPeriodHour && query.Period != vacancy.SalaryPeriodMonth {
+		return query, common.NewValidationError("invalid search query", map[string]string{"period": "period must be hour or month"})
+	}
+	if query.SalaryFrom != nil && query.SalaryTo != nil && *query.SalaryFrom > *query.SalaryTo {
+		return query, common.NewValidationError("invalid search query", map[string]string{"salary_to": "salary_to must be >= salary_from"})
+	}
+	query.Sort = vacancy.SortOrder(strings.ToLower(strings.TrimSpace(string(query.Sort))))
+	switch query.Sort {
+	case "":
+		if query.Text != "" {
+			query.Sort = vacancy.SortRelevance
+		} else {
+			query.Sort = vacancy.SortNewest
+		}
+	case vacancy.SortRelevance, vacancy.SortNewest:
+	default:
+		return query, common.NewValidationError("invalid search query", map[string]string{"sort": "sort must be relevance or newest"})
+	}
+	page := normalizePage(common.PageRequest{Limit: query.Limit, After: query.After})
+	query.Limit = page.Limit
+	return query, nil
 }
 
-func (s *VacancyService) ListByCompany(ctx context.Context, companyID common.UUID) ([]vacancy.Vacancy, error) {
-	return s.repo.ListByCompany(ctx, companyID)
+func (s *VacancyService) ListByCompany(ctx context.Context, companyID common.UUID, page common.PageRequest) ([]vacancy.Vacancy, *common.Cursor, error) {
+	return s.repo.ListByCompany(ctx, companyID, normalizePage(page))
 }
@@ -0,0 +1,189 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/application"
+	"profzom/internal/domain/notification"
+	"profzom/internal/domain/profile"
+	"profzom/internal/domain/vacancy"
+)
+
+func TestNormalizeSearchQuery_Defaults(t *testing.T) {
+	query, err := normalizeSearchQuery(vacancy.SearchQuery{Text: "  golang  ", Limit: 500})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if query.Text != "golang" {
+		t.Fatalf("expected trimmed text, got %q", query.Text)
+	}
+	if query.Sort != vacancy.SortRelevance {
+		t.Fatalf("expected relevance sort for text query, got %q", query.Sort)
+	}
+	if query.Limit != maxPageLimit {
+		t.Fatalf("expected limit %d, got %d", maxPageLimit, query.Limit)
+	}
+
+	query, err = normalizeSearchQuery(vacancy.SearchQuery{})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if query.Sort != vacancy.SortNewest {
+		t.Fatalf("expected newest sort without text, got %q", query.Sort)
+	}
+	if query.Limit != defaultPageLimit {
+		t.Fatalf("expected limit %d, got %d", defaultPageLimit, query.Limit)
+	}
+}
+
+func TestNormalizeSearchQuery_InvalidSort(t *testing.T) {
+	_, err := normalizeSearchQuery(vacancy.SearchQuery{Sort: "salary"})
+	if !common.Is(err, common.CodeValidation) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestVacancyServiceClose_RequiresOwnerAndPublished(t *testing.T) {
+	repo := newFakeVacancyRepo()
+	service := NewVacancyService(repo, &fakeCompanyRepo{profiles: map[common.UUID]*profile.CompanyProfile{}}, noopAnalyticsRepo{}, directUnitOfWork{})
+	companyID := common.NewUUID()
+	draft, _ := repo.Create(context.Background(), vacancy.Vacancy{CompanyID: companyID, Status: vacancy.StatusDraft})
+
+	if _, err := service.Close(context.Background(), common.NewUUID(), draft.ID); !common.Is(err, common.CodeForbidden) {
+		t.Fatalf("expected forbidden error, got %v", err)
+	}
+	if _, err := service.Close(context.Background(), companyID, draft.ID); !common.Is(err, common.CodeValidation) {
+		t.Fatalf("expected validation error for draft, got %v", err)
+	}
+
+	published, _ := repo.Create(context.Background(), vacancy.Vacancy{CompanyID: companyID, Status: vacancy.StatusPublished})
+	closed, err := service.Close(context.Background(), companyID, published.ID)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if closed.Status != vacancy.StatusClosed {
+		t.Fatalf("expected status closed, got %q", closed.Status)
+	}
+	archived, err := service.Archive(context.Background(), companyID, published.ID)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if archived.Status != vacancy.StatusArchived {
+		t.Fatalf("expected status archived, got %q", archived.Status)
+	}
+	if _, err := service.Reopen(context.Background(), companyID, published.ID); !common.Is(err, common.CodeValidation) {
+		t.Fatalf("expected validation error for archived vacancy, got %v", err)
+	}
+}
+
+func TestVacancyServiceClose_NotifiesActiveApplicants(t *testing.T) {
+	repo := newFakeVacancyRepo()
+	applications := newFakeApplicationRepo()
+	notifier := &recordingNotifier{}
+	service := NewVacancyServiceWithNotifier(repo, &fakeCompanyRepo{profiles: map[common.UUID]*profile.CompanyProfile{}}, noopAnalyticsRepo{}, directUnitOfWork{}, applications, notifier)
+	companyID, activeStudent := common.NewUUID(), common.NewUUID()
+	published, _ := repo.Create(context.Background(), vacancy.Vacancy{CompanyID: companyID, Title: "Go intern", Status: vacancy.StatusPublished})
+	_, _ = applications.Create(context.Background(), application.Application{VacancyID: published.ID, StudentID: activeStudent, Status: application.StatusApplied})
+	_, _ = applications.Create(context.Background(), application.Application{VacancyID: published.ID, StudentID: common.NewUUID(), Status: application.StatusRejected})
+
+	if _, err := service.Close(context.Background(), companyID, published.ID); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(notifier.sent) != 1 || notifier.sent[0].UserID != activeStudent || notifier.sent[0].Type != notification.TypeVacancyClosed {
+		t.Fatalf("expected one vacancy.closed notification for active applicant, got %+v", notifier.sent)
+	}
+	if _, err := service.Archive(context.Background(), companyID, published.ID); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(notifier.sent) != 1 {
+		t.Fatalf("expected archiving a closed vacancy not to notify again, got %d notifications", len(notifier.sent))
+	}
+}
+
+func TestVacancyServiceCloseExpired(t *testing.T) {
+	repo := newFakeVacancyRepo()
+	service := NewVacancyService(repo, &fakeCompanyRepo{profiles: map[common.UUID]*profile.CompanyProfile{}}, noopAnalyticsRepo{}, directUnitOfWork{})
+	past := time.Now().Add(-time.Hour).UTC()
+	future := time.Now().Add(time.Hour).UTC()
+	expired, _ := repo.Create(context.Background(), vacancy.Vacancy{CompanyID: common.NewUUID(), Status: vacancy.StatusPublished, ExpiresAt: &past})
+	active, _ := repo.Create(context.Background(), vacancy.Vacancy{CompanyID: common.NewUUID(), Status: vacancy.StatusPublished, ExpiresAt: &future})
+
+	count, err := service.CloseExpired(context.Background())
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("expected 1 closed vacancy, got %d", count)
+	}
+	if repo.items[expired.ID].Status != vacancy.StatusClosed {
+		t.Fatalf("expected expired vacancy to be closed, got %q", repo.items[expired.ID].Status)
+	}
+	if repo.items[active.ID].Status != vacancy.StatusPublished {
+		t.Fatalf("expected active vacancy to stay published, got %q", repo.items[active.ID].Status)
+	}
+}
+
+func TestVacancyServiceUpdate_KeepsStoredPastExpiry(t *testing.T) {
+	repo := newFakeVacancyRepo()
+	service := NewVacancyService(repo, &fakeCompanyRepo{profiles: map[common.UUID]*profile.CompanyProfile{}}, noopAnalyticsRepo{}, directUnitOfWork{})
+	companyID := common.NewUUID()
+	past := time.Now().Add(-time.Hour).UTC()
+	expired, _ := repo.Create(context.Background(), vacancy.Vacancy{CompanyID: companyID, Title: "Go intern", IsNegotiable: true, Status: vacancy.StatusClosed, ExpiresAt: &past})
+
+	edit := *expired
+	edit.Title = "Go backend intern"
+	if _, err := service.Update(context.Background(), edit); err != nil {
+		t.Fatalf("expected unchanged past expires_at to be accepted, got %v", err)
+	}
+	earlier := past.Add(-time.Hour)
+	edit.ExpiresAt = &earlier
+	if _, err := service.Update(context.Background(), edit); !common.Is(err, common.CodeValidation) {
+		t.Fatalf("expected validation error for new past expires_at, got %v", err)
+	}
+}
+
+func TestVacancyServiceGet_HidesUnpublishedFromOthers(t *testing.T) {
+	repo := newFakeVacancyRepo()
+	service := NewVacancyService(repo, &fakeCompanyRepo{profiles: map[common.UUID]*profile.CompanyProfile{}}, noopAnalyticsRepo{}, directUnitOfWork{})
+	companyID := common.NewUUID()
+	draft, _ := repo.Create(context.Background(), vacancy.Vacancy{CompanyID: companyID, Title: "Go intern", Status: vacancy.StatusDraft})
+	closed, _ := repo.Create(context.Background(), vacancy.Vacancy{CompanyID: companyID, Title: "QA intern", Status: vacancy.StatusClosed})
+
+	for name, viewerID := range map[string]common.UUID{"anonymous": "", "stranger": common.NewUUID()} {
+		if _, err := service.Get(context.Background(), draft.ID, viewerID, false); !common.Is(err, common.CodeNotFound) {
+			t.Fatalf("%s: expected not found for draft, got %v", name, err)
+		}
+		if _, err := service.Get(context.Background(), closed.ID, viewerID, false); err != nil {
+			t.Fatalf("%s: expected closed vacancy to be visible, got %v", name, err)
+		}
+	}
+	if _, err := service.Get(context.Background(), draft.ID, companyID, false); err != nil {
+		t.Fatalf("expected owner to see draft, got %v", err)
+	}
+	if _, err := service.Get(context.Background(), draft.ID, common.NewUUID(), true); err != nil {
+		t.Fatalf("expected admin to see draft, got %v", err)
+	}
+}
+
+func TestValidateSalary(t *testing.T) {
+	min, max := 70000, 50000
+	v := vacancy.Vacancy{SalaryMin: &min, SalaryMax: &max, Currency: "rub"}
+	normalizeSalary(&v)
+	if v.Currency != "RUB" || v.Period != vacancy.SalaryPeriodMonth {
+		t.Fatalf("expected normalized RUB/month, got %q/%q", v.Currency, v.Period)
+	}
+	fields := validateSalary(v, map[string]string{})
+	if _, ok := fields["salary_max"]; !ok {
+		t.Fatalf("expected salary_max error, got %v", fields)
+	}
+
+	if fields := validateSalary(vacancy.Vacancy{Period: vacancy.SalaryPeriodMonth}, map[string]string{}); fields["salary"] == "" {
+		t.Fatalf("expected salary error without range, got %v", fields)
+	}
+	if fields := validateSalary(vacancy.Vacancy{Period: vacancy.SalaryPeriodMonth, IsNegotiable: true}, map[string]string{}); len(fields) != 0 {
+		t.Fatalf("expected negotiable salary to be valid, got %v", fields)
+	}
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// VacancyExpirySweeper периодически закрывает вакансии, у которых истёк expires_at.
+type VacancyExpirySweeper struct {
+	vacancies *VacancyService
+	interval  time.Duration
+	logger    Logger
+}
+
+func NewVacancyExpirySweeper(vacancies *VacancyService, interval time.Duration, logger Logger) *VacancyExpirySweeper {
+	return &VacancyExpirySweeper{vacancies: vacancies, interval: interval, logger: logger}
+}
+
+func (s *VacancyExpirySweeper) Run(ctx context.Context) {
+	if s.interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		s.sweep(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *VacancyExpirySweeper) sweep(ctx context.Context) {
+	closed, err := s.vacancies.CloseExpired(ctx)
+	if s.logger == nil {
+		return
+	}
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("vacancy expiry sweep failed: %v", err))
+		return
+	}
+	if closed > 0 {
+		s.logger.Info(fmt.Sprintf("vacancy expiry sweep closed=%d", closed))
+	}
+}
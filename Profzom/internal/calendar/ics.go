@@ -0,0 +1,83 @@
+package calendar
+
+import (
+	"strings"
+	"time"
+)
+
+const icsTimeLayout = "20060102T150405Z"
+
+// Event — минимальный набор полей VEVENT, нужный для экспорта собеседований.
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	StartsAt    time.Time
+	EndsAt      time.Time
+	Stamp       time.Time
+	Cancelled   bool
+}
+
+// RenderICS собирает документ iCalendar (RFC 5545). Время пишется в UTC, поэтому VTIMEZONE не нужен.
+func RenderICS(events ...Event) []byte {
+	var b strings.Builder
+	writeLine(&b, "BEGIN:VCALENDAR")
+	writeLine(&b, "VERSION:2.0")
+	writeLine(&b, "PRODID:-//ProfZoom//Interviews//RU")
+	writeLine(&b, "CALSCALE:GREGORIAN")
+	writeLine(&b, "METHOD:PUBLISH")
+	for _, event := range events {
+		writeLine(&b, "BEGIN:VEVENT")
+		writeLine(&b, "UID:"+escapeText(event.UID))
+		writeLine(&b, "DTSTAMP:"+event.Stamp.UTC().Format(icsTimeLayout))
+		writeLine(&b, "DTSTART:"+event.StartsAt.UTC().Format(icsTimeLayout))
+		writeLine(&b, "DTEND:"+event.EndsAt.UTC().Format(icsTimeLayout))
+		writeLine(&b, "SUMMARY:"+escapeText(event.Summary))
+		if event.Description != "" {
+			writeLine(&b, "DESCRIPTION:"+escapeText(event.Description))
+		}
+		if event.Location != "" {
+			writeLine(&b, "LOCATION:"+escapeText(event.Location))
+		}
+		if event.URL != "" {
+			writeLine(&b, "URL:"+event.URL)
+		}
+		if event.Cancelled {
+			writeLine(&b, "STATUS:CANCELLED")
+		} else {
+			writeLine(&b, "STATUS:CONFIRMED")
+		}
+		writeLine(&b, "END:VEVENT")
+	}
+	writeLine(&b, "END:VCALENDAR")
+	return []byte(b.String())
+}
+
+func escapeText(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return replacer.Replace(value)
+}
+
+// writeLine переносит строки длиннее 75 октетов, не разрывая UTF-8 символы.
+func writeLine(b *strings.Builder, line string) {
+	const limit = 75
+	width := limit
+	for len(line) > width {
+		cut := width
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		width = limit - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+func isRuneStart(c byte) bool {
+	return c&0xC0 != 0x80
+}
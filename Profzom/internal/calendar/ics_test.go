@@ -0,0 +1,34 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderICS_EventInUTC(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	doc := string(RenderICS(Event{
+		UID:      "slot-1@profzoom",
+		Summary:  "Interview: Go, backend",
+		StartsAt: time.Date(2026, 5, 10, 12, 0, 0, 0, moscow),
+		EndsAt:   time.Date(2026, 5, 10, 13, 0, 0, 0, moscow),
+		Stamp:    time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC),
+	}))
+
+	for _, want := range []string{"BEGIN:VCALENDAR\r\n", "DTSTART:20260510T090000Z\r\n", "DTEND:20260510T100000Z\r\n", `SUMMARY:Interview: Go\, backend`, "END:VCALENDAR\r\n"} {
+		if !strings.Contains(doc, want) {
+			t.Fatalf("expected %q in document, got %q", want, doc)
+		}
+	}
+}
+
+func TestRenderICS_FoldsLongLines(t *testing.T) {
+	doc := string(RenderICS(Event{UID: "1", Summary: strings.Repeat("собеседование ", 20)}))
+
+	for _, line := range strings.Split(doc, "\r\n") {
+		if len(line) > 75 {
+			t.Fatalf("expected lines up to 75 octets, got %d", len(line))
+		}
+	}
+}
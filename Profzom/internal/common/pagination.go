@@ -0,0 +1,18 @@
+package common
+
+import "time"
+
+// Cursor указывает на последнюю запись страницы при keyset-пагинации по (created_at, id).
+// Rank заполняется только для выдачи, отсортированной по релевантности.
+// Scope — отпечаток запроса (путь, сортировка и фильтры), для которого выдан курсор: с другим запросом курсор не принимается.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        UUID
+	Rank      float64
+	Scope     string
+}
+
+type PageRequest struct {
+	Limit int
+	After *Cursor
+}
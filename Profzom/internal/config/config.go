@@ -4,6 +4,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -11,6 +12,7 @@ type Config struct {
 	HTTPPort          string
 	PostgresDSN       string
 	JWTSecret         string
+	CursorSecret      string
 	OTPBotBaseURL     string
 	OTPBotInternalKey string
 	AccessTokenTTL    time.Duration
@@ -21,6 +23,27 @@ type Config struct {
 	DBConnMaxIdle     time.Duration
 	DBConnMaxLife     time.Duration
 	RequestTimeout    time.Duration
+	VacancySweepEvery time.Duration
+	OutboxInterval    time.Duration
+	SearchDigestEvery time.Duration
+	OutboxWebhookURL  string
+	OutboxWebhookKey  string
+	StorageDriver     string
+	StorageLocalDir   string
+	S3Endpoint        string
+	S3Bucket          string
+	S3Region          string
+	S3AccessKey       string
+	S3SecretKey       string
+
+	// RequireCompanyVerification разрешает публиковать вакансии только проверенным компаниям.
+	RequireCompanyVerification bool
+
+	// Правила автоматической модерации вакансий и сообщений.
+	ModerationStopWords      []string
+	ModerationPattern        string
+	ModerationMaxLinks       int
+	ModerationBlockedDomains []string
 }
 
 func Load() *Config {
@@ -28,6 +51,7 @@ func Load() *Config {
 		HTTPPort:          getEnv("HTTP_PORT", "8080"),
 		PostgresDSN:       getEnv("DATABASE_URL", ""),
 		JWTSecret:         getEnv("JWT_SECRET", ""),
+		CursorSecret:      getEnv("CURSOR_SECRET", ""),
 		OTPBotBaseURL:     getEnv("OTP_BOT_BASE_URL", ""),
 		OTPBotInternalKey: getEnv("OTP_BOT_INTERNAL_KEY", ""),
 		AccessTokenTTL:    getDuration("ACCESS_TOKEN_TTL", 15*time.Minute),
@@ -38,6 +62,25 @@ func Load() *Config {
 		DBConnMaxIdle:     getDuration("DB_CONN_MAX_IDLE", 5*time.Minute),
 		DBConnMaxLife:     getDuration("DB_CONN_MAX_LIFE", 30*time.Minute),
 		RequestTimeout:    getDuration("REQUEST_TIMEOUT", 10*time.Second),
+		VacancySweepEvery: getDuration("VACANCY_SWEEP_INTERVAL", 5*time.Minute),
+		OutboxInterval:    getDuration("OUTBOX_DISPATCH_INTERVAL", time.Second),
+		SearchDigestEvery: getDuration("SAVED_SEARCH_DIGEST_INTERVAL", time.Hour),
+		OutboxWebhookURL:  getEnv("OUTBOX_WEBHOOK_URL", ""),
+		OutboxWebhookKey:  getEnv("OUTBOX_WEBHOOK_SECRET", ""),
+		StorageDriver:     getEnv("STORAGE_DRIVER", "local"),
+		StorageLocalDir:   getEnv("STORAGE_LOCAL_DIR", "data/attachments"),
+		S3Endpoint:        getEnv("S3_ENDPOINT", ""),
+		S3Bucket:          getEnv("S3_BUCKET", ""),
+		S3Region:          getEnv("S3_REGION", "us-east-1"),
+		S3AccessKey:       getEnv("S3_ACCESS_KEY", ""),
+		S3SecretKey:       getEnv("S3_SECRET_KEY", ""),
+
+		RequireCompanyVerification: getBool("REQUIRE_COMPANY_VERIFICATION", false),
+
+		ModerationStopWords:      getList("MODERATION_STOP_WORDS"),
+		ModerationPattern:        getEnv("MODERATION_PATTERN", ""),
+		ModerationMaxLinks:       getInt("MODERATION_MAX_LINKS", 3),
+		ModerationBlockedDomains: getList("MODERATION_BLOCKED_DOMAINS"),
 	}
 
 	if cfg.PostgresDSN == "" {
@@ -46,12 +89,24 @@ func Load() *Config {
 	if cfg.JWTSecret == "" {
 		log.Fatal("JWT_SECRET is required")
 	}
+	if cfg.CursorSecret == "" {
+		cfg.CursorSecret = cfg.JWTSecret
+	}
 	if cfg.OTPBotBaseURL == "" {
 		log.Fatal("OTP_BOT_BASE_URL is required")
 	}
 	if cfg.OTPBotInternalKey == "" {
 		log.Fatal("OTP_BOT_INTERNAL_KEY is required")
 	}
+	switch cfg.StorageDriver {
+	case "local":
+	case "s3":
+		if cfg.S3Endpoint == "" || cfg.S3Bucket == "" {
+			log.Fatal("S3_ENDPOINT and S3_BUCKET are required for STORAGE_DRIVER=s3")
+		}
+	default:
+		log.Fatal("STORAGE_DRIVER must be local or s3")
+	}
 
 	return cfg
 }
@@ -82,3 +137,28 @@ func getInt(key string, fallback int) int {
 	}
 	return fallback
 }
+
+func getBool(key string, fallback bool) bool {
+	if value, ok := os.LookupEnv(key); ok {
+		parsed, err := strconv.ParseBool(value)
+		if err == nil {
+			return parsed
+		}
+	}
+	return fallback
+}
+
+// getList разбирает список через запятую; nil означает, что переменная не задана.
+func getList(key string) []string {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return nil
+	}
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,33 @@
+package admin
+
+import (
+	"context"
+	"time"
+
+	"profzom/internal/common"
+)
+
+// AuditEntry — запись журнала действий администратора. AdminID пуст для действий из консольной утилиты.
+type AuditEntry struct {
+	ID         common.UUID       `json:"id"`
+	AdminID    *common.UUID      `json:"admin_id,omitempty"`
+	Action     string            `json:"action"`
+	TargetType string            `json:"target_type,omitempty"`
+	TargetID   string            `json:"target_id,omitempty"`
+	Details    map[string]string `json:"details,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+}
+
+// AuditQuery — выборка журнала, новые записи первыми; пустые фильтры означают «все».
+type AuditQuery struct {
+	AdminID  *common.UUID
+	TargetID string
+	Action   string
+	Limit    int
+	After    *common.Cursor
+}
+
+type AuditRepository interface {
+	Create(ctx context.Context, entry AuditEntry) error
+	List(ctx context.Context, query AuditQuery) ([]AuditEntry, *common.Cursor, error)
+}
@@ -0,0 +1,28 @@
+package admin
+
+import (
+	"context"
+	"time"
+
+	"profzom/internal/domain/application"
+	"profzom/internal/domain/user"
+	"profzom/internal/domain/vacancy"
+)
+
+// Stats — сводка для админки: текущие срезы по пользователям, вакансиям и откликам
+// и активность начиная с Since — новые пользователи, отклики и события аналитики по имени.
+type Stats struct {
+	Since                time.Time                  `json:"since"`
+	Users                int                        `json:"users"`
+	NewUsers             int                        `json:"new_users"`
+	BlockedUsers         int                        `json:"blocked_users"`
+	UsersByRole          map[user.Role]int          `json:"users_by_role"`
+	VacanciesByStatus    map[vacancy.Status]int     `json:"vacancies_by_status"`
+	NewApplications      int                        `json:"new_applications"`
+	ApplicationsByStatus map[application.Status]int `json:"applications_by_status"`
+	Events               map[string]int             `json:"events"`
+}
+
+type StatsRepository interface {
+	Stats(ctx context.Context, since time.Time) (*Stats, error)
+}
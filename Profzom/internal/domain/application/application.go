@@ -9,10 +9,11 @@ import (
 type Status string
 
 const (
-	StatusApplied  Status = "applied"
-	StatusInvited  Status = "invited"
-	StatusRejected Status = "rejected"
-	StatusAccepted Status = "accepted"
+	StatusApplied   Status = "applied"
+	StatusInvited   Status = "invited"
+	StatusRejected  Status = "rejected"
+	StatusAccepted  Status = "accepted"
+	StatusWithdrawn Status = "withdrawn"
 )
 
 type Application struct {
@@ -24,3 +25,14 @@ type Application struct {
 	CreatedAt time.Time   `json:"created_at"`
 	UpdatedAt time.Time   `json:"updated_at"`
 }
+
+// StatusEvent фиксирует одну смену статуса отклика: кто, когда и из какого статуса в какой перевёл.
+type StatusEvent struct {
+	ID            common.UUID `json:"id"`
+	ApplicationID common.UUID `json:"application_id"`
+	FromStatus    Status      `json:"from_status,omitempty"`
+	ToStatus      Status      `json:"to_status"`
+	ChangedBy     common.UUID `json:"changed_by"`
+	Feedback      string      `json:"feedback,omitempty"`
+	CreatedAt     time.Time   `json:"created_at"`
+}
@@ -0,0 +1,41 @@
+package application
+
+import (
+	"profzom/internal/common"
+	"profzom/internal/domain/profile"
+	"profzom/internal/domain/vacancy"
+)
+
+type PipelineSort string
+
+const (
+	PipelineSortNewest PipelineSort = "newest"
+	PipelineSortOldest PipelineSort = "oldest"
+)
+
+// PipelineQuery — выборка откликов компании для воронки; пустые VacancyID, StudentID и Statuses означают «все».
+// Пустой CompanyID снимает ограничение по компании — так отклики смотрит администратор.
+type PipelineQuery struct {
+	CompanyID common.UUID
+	VacancyID *common.UUID
+	StudentID *common.UUID
+	Statuses  []Status
+	Sort      PipelineSort
+	Limit     int
+	After     *common.Cursor
+}
+
+type VacancySummary struct {
+	ID     common.UUID    `json:"id"`
+	Title  string         `json:"title"`
+	Status vacancy.Status `json:"status"`
+}
+
+// PipelineItem — отклик вместе с вакансией и профилем студента; Student пуст, если студент удалил профиль.
+type PipelineItem struct {
+	Application
+	Vacancy VacancySummary          `json:"vacancy"`
+	Student *profile.StudentProfile `json:"student,omitempty"`
+	// UnreadMessages — сколько сообщений по отклику компания ещё не прочитала.
+	UnreadMessages int `json:"unread_messages"`
+}
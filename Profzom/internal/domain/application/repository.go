@@ -10,8 +10,13 @@ type Repository interface {
 	Create(ctx context.Context, application Application) (*Application, error)
 	GetByID(ctx context.Context, id common.UUID) (*Application, error)
 	ListByVacancy(ctx context.Context, vacancyID common.UUID) ([]Application, error)
-	ListByStudent(ctx context.Context, studentID common.UUID) ([]Application, error)
-	ListByCompany(ctx context.Context, companyID common.UUID) ([]Application, error)
-	UpdateStatus(ctx context.Context, id common.UUID, status Status, feedback string) (*Application, error)
+	ListByStudent(ctx context.Context, studentID common.UUID, page common.PageRequest) ([]Application, *common.Cursor, error)
+	ListByCompany(ctx context.Context, companyID common.UUID, page common.PageRequest) ([]Application, *common.Cursor, error)
+	ListPipeline(ctx context.Context, query PipelineQuery) ([]PipelineItem, *common.Cursor, error)
+	// CountByStatus считает отклики компании по статусам; vacancyID сужает подсчёт до одной вакансии.
+	CountByStatus(ctx context.Context, companyID common.UUID, vacancyID *common.UUID) (map[Status]int, error)
+	// UpdateStatus меняет статус и пишет событие истории; если текущий статус уже не event.FromStatus, возвращает CodeConflict.
+	UpdateStatus(ctx context.Context, event StatusEvent) (*Application, error)
+	ListStatusEvents(ctx context.Context, applicationID common.UUID) ([]StatusEvent, error)
 	FindByVacancyAndStudent(ctx context.Context, vacancyID, studentID common.UUID) (*Application, error)
 }
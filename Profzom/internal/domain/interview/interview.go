@@ -0,0 +1,58 @@
+package interview
+
+import (
+	"time"
+
+	"profzom/internal/common"
+)
+
+type Status string
+
+const (
+	StatusProposed  Status = "proposed"
+	StatusScheduled Status = "scheduled"
+	StatusCancelled Status = "cancelled"
+)
+
+type Format string
+
+const (
+	FormatOnline  Format = "online"
+	FormatOffline Format = "offline"
+)
+
+// Interview — собеседование по отклику. Одна из сторон предлагает слоты, другая выбирает один из них.
+type Interview struct {
+	ID             common.UUID  `json:"id"`
+	ApplicationID  common.UUID  `json:"application_id"`
+	Status         Status       `json:"status"`
+	Format         Format       `json:"format"`
+	Location       string       `json:"location"`
+	Timezone       string       `json:"timezone"`
+	ProposedBy     common.UUID  `json:"proposed_by"`
+	SelectedSlotID *common.UUID `json:"selected_slot_id,omitempty"`
+	CancelledBy    *common.UUID `json:"cancelled_by,omitempty"`
+	Slots          []Slot       `json:"slots"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+}
+
+type Slot struct {
+	ID       common.UUID `json:"id"`
+	StartsAt time.Time   `json:"starts_at"`
+	EndsAt   time.Time   `json:"ends_at"`
+}
+
+// SelectedSlot возвращает согласованный слот или nil, если слот ещё не выбран.
+func (i Interview) SelectedSlot() *Slot {
+	if i.SelectedSlotID == nil {
+		return nil
+	}
+	for _, slot := range i.Slots {
+		if slot.ID == *i.SelectedSlotID {
+			found := slot
+			return &found
+		}
+	}
+	return nil
+}
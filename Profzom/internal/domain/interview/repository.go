@@ -0,0 +1,14 @@
+package interview
+
+import (
+	"context"
+
+	"profzom/internal/common"
+)
+
+type Repository interface {
+	// Propose создаёт собеседование по отклику или заменяет слоты существующего.
+	Propose(ctx context.Context, interview Interview) (*Interview, error)
+	GetByApplication(ctx context.Context, applicationID common.UUID) (*Interview, error)
+	UpdateState(ctx context.Context, interview Interview) (*Interview, error)
+}
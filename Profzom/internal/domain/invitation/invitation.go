@@ -0,0 +1,31 @@
+package invitation
+
+import (
+	"time"
+
+	"profzom/internal/common"
+)
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusAccepted  Status = "accepted"
+	StatusDeclined  Status = "declined"
+	StatusCancelled Status = "cancelled"
+)
+
+// Invitation — приглашение компании откликнуться на вакансию. При принятии создаётся отклик сразу в статусе invited,
+// его id сохраняется в ApplicationID, а сопроводительный текст становится первым сообщением переписки.
+type Invitation struct {
+	ID            common.UUID  `json:"id"`
+	VacancyID     common.UUID  `json:"vacancy_id"`
+	CompanyID     common.UUID  `json:"company_id"`
+	StudentID     common.UUID  `json:"student_id"`
+	Message       string       `json:"message,omitempty"`
+	Status        Status       `json:"status"`
+	ApplicationID *common.UUID `json:"application_id,omitempty"`
+	RespondedAt   *time.Time   `json:"responded_at,omitempty"`
+	CreatedAt     time.Time    `json:"created_at"`
+	UpdatedAt     time.Time    `json:"updated_at"`
+}
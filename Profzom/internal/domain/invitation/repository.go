@@ -0,0 +1,17 @@
+package invitation
+
+import (
+	"context"
+
+	"profzom/internal/common"
+)
+
+type Repository interface {
+	Create(ctx context.Context, invitation Invitation) (*Invitation, error)
+	GetByID(ctx context.Context, id common.UUID) (*Invitation, error)
+	FindPending(ctx context.Context, vacancyID, studentID common.UUID) (*Invitation, error)
+	ListByStudent(ctx context.Context, studentID common.UUID, page common.PageRequest) ([]Invitation, *common.Cursor, error)
+	ListByCompany(ctx context.Context, companyID common.UUID, page common.PageRequest) ([]Invitation, *common.Cursor, error)
+	// UpdateStatus переводит приглашение из pending; CodeConflict, если на него уже ответили.
+	UpdateStatus(ctx context.Context, invitation Invitation) (*Invitation, error)
+}
@@ -0,0 +1,31 @@
+package message
+
+import (
+	"context"
+	"time"
+
+	"profzom/internal/common"
+)
+
+// Attachment — файл в переписке по отклику. Сначала файл загружается (MessageID пуст), затем прикрепляется к сообщению при отправке.
+// Неотправленное вложение видно только загрузившему его участнику.
+type Attachment struct {
+	ID            common.UUID  `json:"id"`
+	ApplicationID common.UUID  `json:"application_id"`
+	MessageID     *common.UUID `json:"message_id,omitempty"`
+	UploaderID    common.UUID  `json:"uploader_id"`
+	FileName      string       `json:"file_name"`
+	ContentType   string       `json:"content_type"`
+	Size          int64        `json:"size"`
+	StorageKey    string       `json:"-"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
+
+type AttachmentRepository interface {
+	Create(ctx context.Context, attachment Attachment) (*Attachment, error)
+	GetByID(ctx context.Context, id common.UUID) (*Attachment, error)
+	// Attach прикрепляет к сообщению вложения из ids, загруженные uploaderID в тот же отклик и ещё не отправленные.
+	// Возвращает только прикреплённые — сервис сверяет их число с запрошенным.
+	Attach(ctx context.Context, messageID, applicationID, uploaderID common.UUID, ids []common.UUID) ([]Attachment, error)
+	ListByMessages(ctx context.Context, messageIDs []common.UUID) (map[common.UUID][]Attachment, error)
+}
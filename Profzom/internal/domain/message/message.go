@@ -6,10 +6,30 @@ import (
 	"profzom/internal/common"
 )
 
+type Kind string
+
+const (
+	KindUser   Kind = "user"
+	KindSystem Kind = "system"
+)
+
+// ModerationStatus — состояние сообщения, задержанного модерацией; пустое значение означает доставленное сообщение.
+type ModerationStatus string
+
+const (
+	ModerationPending  ModerationStatus = "pending_review"
+	ModerationRejected ModerationStatus = "rejected"
+)
+
+// Message — сообщение в переписке по отклику. У системных сообщений (Kind == KindSystem) нет отправителя.
 type Message struct {
-	ID            common.UUID `json:"id"`
-	ApplicationID common.UUID `json:"application_id"`
-	SenderID      common.UUID `json:"sender_id"`
-	Body          string      `json:"body"`
-	CreatedAt     time.Time   `json:"created_at"`
+	ID            common.UUID  `json:"id"`
+	ApplicationID common.UUID  `json:"application_id"`
+	SenderID      common.UUID  `json:"sender_id,omitempty"`
+	Kind          Kind         `json:"kind"`
+	Body          string       `json:"body"`
+	Attachments   []Attachment `json:"attachments,omitempty"`
+	// Moderation задан только у задержанных сообщений: их видит лишь отправитель.
+	Moderation ModerationStatus `json:"moderation_status,omitempty"`
+	CreatedAt  time.Time        `json:"created_at"`
 }
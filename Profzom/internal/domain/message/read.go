@@ -0,0 +1,25 @@
+package message
+
+import (
+	"time"
+
+	"profzom/internal/common"
+)
+
+// ReadState — до какого сообщения участник прочитал переписку по отклику. Отметка двигается только вперёд.
+type ReadState struct {
+	ApplicationID     common.UUID `json:"application_id"`
+	UserID            common.UUID `json:"user_id"`
+	LastReadMessageID common.UUID `json:"last_read_message_id"`
+	// LastReadCreatedAt — время создания последнего прочитанного сообщения, по нему сравниваются сообщения ленты.
+	LastReadCreatedAt time.Time `json:"-"`
+	ReadAt            time.Time `json:"read_at"`
+}
+
+// Covers сообщает, прочитано ли сообщение по этой отметке.
+func (s ReadState) Covers(msg Message) bool {
+	if msg.CreatedAt.Equal(s.LastReadCreatedAt) {
+		return msg.ID <= s.LastReadMessageID
+	}
+	return msg.CreatedAt.Before(s.LastReadCreatedAt)
+}
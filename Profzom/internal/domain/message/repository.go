@@ -8,6 +8,14 @@ import (
 
 type Repository interface {
 	Create(ctx context.Context, message Message) (*Message, error)
-	ListByApplication(ctx context.Context, applicationID common.UUID, limit, offset int) ([]Message, error)
+	GetByID(ctx context.Context, id common.UUID) (*Message, error)
+	// ListByApplication отдаёт доставленные сообщения и задержанные модерацией сообщения viewerID.
+	ListByApplication(ctx context.Context, applicationID, viewerID common.UUID, page common.PageRequest) ([]Message, *common.Cursor, error)
 	LatestByApplication(ctx context.Context, applicationID common.UUID) (*Message, error)
+	// MarkRead сдвигает отметку участника до messageID; более старое сообщение отметку не откатывает.
+	MarkRead(ctx context.Context, applicationID, userID, messageID common.UUID) (*ReadState, error)
+	ReadStates(ctx context.Context, applicationID common.UUID) ([]ReadState, error)
+	SetModeration(ctx context.Context, id common.UUID, status ModerationStatus) error
+	// CountUnread считает по каждому отклику доставленные сообщения, которые userID не отправлял и ещё не прочитал.
+	CountUnread(ctx context.Context, userID common.UUID, applicationIDs []common.UUID) (map[common.UUID]int, error)
 }
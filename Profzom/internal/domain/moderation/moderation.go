@@ -0,0 +1,70 @@
+package moderation
+
+import (
+	"context"
+	"time"
+
+	"profzom/internal/common"
+)
+
+type ContentType string
+
+const (
+	ContentVacancy ContentType = "vacancy"
+	ContentMessage ContentType = "message"
+)
+
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusApproved Status = "approved"
+	StatusRejected Status = "rejected"
+)
+
+// Content — текст, который автор собирается опубликовать.
+type Content struct {
+	Type     ContentType
+	AuthorID common.UUID
+	Text     string
+}
+
+// Verdict — результат автоматической проверки. Hold задерживает контент до решения модератора,
+// Reasons объясняют модератору, что именно сработало.
+type Verdict struct {
+	Hold    bool
+	Reasons []string
+}
+
+// Checker проверяет контент перед публикацией. Реализации не должны зависеть от БД:
+// проверка выполняется до транзакции сервиса.
+type Checker interface {
+	Check(ctx context.Context, content Content) (Verdict, error)
+}
+
+// Item — запись очереди модерации. На один объект в очереди бывает не больше одной записи pending.
+type Item struct {
+	ID           common.UUID  `json:"id"`
+	ContentType  ContentType  `json:"content_type"`
+	ContentID    common.UUID  `json:"content_id"`
+	AuthorID     common.UUID  `json:"author_id"`
+	Text         string       `json:"text"`
+	Reasons      []string     `json:"reasons"`
+	Status       Status       `json:"status"`
+	ReviewReason string       `json:"review_reason,omitempty"`
+	ReviewedBy   *common.UUID `json:"reviewed_by,omitempty"`
+	ReviewedAt   *time.Time   `json:"reviewed_at,omitempty"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+}
+
+type Repository interface {
+	// Enqueue ставит объект в очередь; если он уже ждёт проверки, обновляет текст и причины существующей записи.
+	Enqueue(ctx context.Context, item Item) (*Item, error)
+	GetByID(ctx context.Context, id common.UUID) (*Item, error)
+	// Save закрывает запись pending при условии, что её updated_at всё ещё равен item.UpdatedAt;
+	// иначе — CodeConflict.
+	Save(ctx context.Context, item Item) (*Item, error)
+	// ListByStatus отдаёт записи в порядке поступления, старые первыми; пустой contentType означает «все».
+	ListByStatus(ctx context.Context, status Status, contentType ContentType, page common.PageRequest) ([]Item, *common.Cursor, error)
+}
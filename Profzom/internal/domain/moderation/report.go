@@ -0,0 +1,72 @@
+package moderation
+
+import (
+	"context"
+	"time"
+
+	"profzom/internal/common"
+)
+
+type ReportTarget string
+
+const (
+	ReportVacancy ReportTarget = "vacancy"
+	ReportCompany ReportTarget = "company"
+	ReportMessage ReportTarget = "message"
+)
+
+type ReportReason string
+
+const (
+	ReasonSpam           ReportReason = "spam"
+	ReasonFraud          ReportReason = "fraud"
+	ReasonHarassment     ReportReason = "harassment"
+	ReasonDiscrimination ReportReason = "discrimination"
+	ReasonInappropriate  ReportReason = "inappropriate"
+	ReasonOther          ReportReason = "other"
+)
+
+type ReportStatus string
+
+const (
+	ReportOpen      ReportStatus = "open"
+	ReportResolved  ReportStatus = "resolved"
+	ReportDismissed ReportStatus = "dismissed"
+)
+
+// Report — жалоба пользователя. TargetUserID — автор контента или сама компания, Text — снимок
+// содержимого на момент жалобы, чтобы модератор видел его и после правок или удаления.
+type Report struct {
+	ID           common.UUID  `json:"id"`
+	ReporterID   common.UUID  `json:"reporter_id"`
+	TargetType   ReportTarget `json:"target_type"`
+	TargetID     common.UUID  `json:"target_id"`
+	TargetUserID common.UUID  `json:"target_user_id"`
+	Reason       ReportReason `json:"reason"`
+	Comment      string       `json:"comment,omitempty"`
+	Text         string       `json:"text"`
+	Status       ReportStatus `json:"status"`
+	Resolution   string       `json:"resolution,omitempty"`
+	ResolvedBy   *common.UUID `json:"resolved_by,omitempty"`
+	ResolvedAt   *time.Time   `json:"resolved_at,omitempty"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+}
+
+// ReportQuery — фильтры списка жалоб; пустые поля не ограничивают выборку.
+type ReportQuery struct {
+	Status       ReportStatus
+	TargetType   ReportTarget
+	TargetUserID *common.UUID
+	Limit        int
+	After        *common.Cursor
+}
+
+type ReportRepository interface {
+	// Create возвращает CodeConflict, если у пользователя уже есть открытая жалоба на этот объект.
+	Create(ctx context.Context, report Report) (*Report, error)
+	GetByID(ctx context.Context, id common.UUID) (*Report, error)
+	Save(ctx context.Context, report Report) (*Report, error)
+	// List отдаёт жалобы в порядке поступления, старые первыми.
+	List(ctx context.Context, query ReportQuery) ([]Report, *common.Cursor, error)
+}
@@ -0,0 +1,46 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"profzom/internal/common"
+)
+
+type Type string
+
+const (
+	TypeApplicationReceived  Type = "application.received"
+	TypeApplicationStatus    Type = "application.status_changed"
+	TypeApplicationWithdrawn Type = "application.withdrawn"
+	TypeMessageReceived      Type = "message.received"
+	TypeInvitationReceived   Type = "invitation.received"
+	TypeInvitationAccepted   Type = "invitation.accepted"
+	TypeInvitationDeclined   Type = "invitation.declined"
+	TypeVacancyClosed        Type = "vacancy.closed"
+	TypeVacancyUnpublished   Type = "vacancy.unpublished"
+	TypeSavedSearchDigest    Type = "saved_search.digest"
+	TypeCompanyVerification  Type = "company.verification_reviewed"
+	TypeModerationReviewed   Type = "moderation.reviewed"
+)
+
+// Notification — запись ленты уведомлений пользователя. Text дублирует то, что уходит в Telegram,
+// а Payload содержит идентификаторы связанных сущностей для перехода в клиенте.
+type Notification struct {
+	ID        common.UUID       `json:"id"`
+	UserID    common.UUID       `json:"user_id"`
+	Type      Type              `json:"type"`
+	Text      string            `json:"text"`
+	Payload   map[string]string `json:"payload,omitempty"`
+	ReadAt    *time.Time        `json:"read_at,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+type Repository interface {
+	// Create идемпотентен по ID: повторная доставка из outbox не дублирует запись.
+	Create(ctx context.Context, n Notification) error
+	List(ctx context.Context, userID common.UUID, unreadOnly bool, page common.PageRequest) ([]Notification, *common.Cursor, error)
+	MarkRead(ctx context.Context, userID, id common.UUID, readAt time.Time) error
+	MarkAllRead(ctx context.Context, userID common.UUID, readAt time.Time) (int, error)
+	CountUnread(ctx context.Context, userID common.UUID) (int, error)
+}
@@ -0,0 +1,40 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"profzom/internal/common"
+)
+
+type Topic string
+
+const (
+	TopicAnalytics    Topic = "analytics"
+	TopicNotification Topic = "notification"
+)
+
+// Message — запись outbox: сохраняется в одной транзакции с доменной записью и доставляется диспетчером позже.
+type Message struct {
+	ID        common.UUID
+	Topic     Topic
+	Payload   []byte
+	Attempts  int
+	LastError string
+	// DeliveredSinks — имена sink'ов, которые уже приняли запись; при повторной попытке они пропускаются.
+	DeliveredSinks []string
+	AvailableAt    time.Time
+	CreatedAt      time.Time
+}
+
+type Repository interface {
+	Enqueue(ctx context.Context, message Message) error
+	// Claim забирает готовые к доставке записи и откладывает их на lease, чтобы их не взял другой экземпляр диспетчера.
+	Claim(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]Message, error)
+	// MarkSinkDelivered запоминает, что sink принял запись, чтобы повторная попытка не доставляла её ему снова.
+	MarkSinkDelivered(ctx context.Context, id common.UUID, sink string) error
+	MarkDelivered(ctx context.Context, id common.UUID, deliveredAt time.Time) error
+	Reschedule(ctx context.Context, id common.UUID, attempts int, availableAt time.Time, lastError string) error
+	MarkFailed(ctx context.Context, id common.UUID, attempts int, failedAt time.Time, lastError string) error
+	PurgeDelivered(ctx context.Context, before time.Time) (int64, error)
+}
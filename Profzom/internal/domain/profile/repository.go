@@ -9,6 +9,8 @@ import (
 type StudentRepository interface {
 	GetByUserID(ctx context.Context, userID common.UUID) (*StudentProfile, error)
 	Upsert(ctx context.Context, profile StudentProfile) (*StudentProfile, error)
+	// Search ищет только профили с VisibleToCompanies.
+	Search(ctx context.Context, query CandidateQuery) ([]StudentProfile, *common.Cursor, error)
 }
 
 type CompanyRepository interface {
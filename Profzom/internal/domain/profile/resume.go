@@ -0,0 +1,81 @@
+package profile
+
+import (
+	"context"
+
+	"profzom/internal/common"
+)
+
+type ExperienceKind string
+
+const (
+	ExperienceWork    ExperienceKind = "work"
+	ExperienceProject ExperienceKind = "project"
+)
+
+type LinkKind string
+
+const (
+	LinkGitHub    LinkKind = "github"
+	LinkGitLab    LinkKind = "gitlab"
+	LinkPortfolio LinkKind = "portfolio"
+	LinkLinkedIn  LinkKind = "linkedin"
+	LinkWebsite   LinkKind = "website"
+	LinkOther     LinkKind = "other"
+)
+
+// LanguageLevel — уровень по шкале CEFR (A1–C2) или native для родного языка.
+type LanguageLevel string
+
+// Resume — структурированное резюме студента. Разделы хранятся отдельными коллекциями и сохраняются целиком, порядок элементов задаёт студент.
+type Resume struct {
+	Education    []Education   `json:"education"`
+	Experience   []Experience  `json:"experience"`
+	Links        []Link        `json:"links"`
+	Languages    []Language    `json:"languages"`
+	Certificates []Certificate `json:"certificates"`
+}
+
+type Education struct {
+	Institution string `json:"institution"`
+	Degree      string `json:"degree"`
+	Field       string `json:"field"`
+	StartYear   int    `json:"start_year"`
+	// EndYear пуст, пока студент учится.
+	EndYear *int `json:"end_year,omitempty"`
+}
+
+// Experience — место работы или проект. Месяцы в формате YYYY-MM; пустой EndMonth означает «по настоящее время».
+type Experience struct {
+	Kind         ExperienceKind `json:"kind"`
+	Title        string         `json:"title"`
+	Organization string         `json:"organization"`
+	StartMonth   string         `json:"start_month"`
+	EndMonth     string         `json:"end_month,omitempty"`
+	Description  string         `json:"description"`
+	URL          string         `json:"url,omitempty"`
+}
+
+type Link struct {
+	Kind LinkKind `json:"kind"`
+	URL  string   `json:"url"`
+}
+
+type Language struct {
+	Name  string        `json:"name"`
+	Level LanguageLevel `json:"level"`
+}
+
+type Certificate struct {
+	Name        string `json:"name"`
+	Issuer      string `json:"issuer"`
+	IssuedMonth string `json:"issued_month,omitempty"`
+	URL         string `json:"url,omitempty"`
+}
+
+type ResumeRepository interface {
+	// GetByStudent возвращает пустое резюме, если студент его ещё не заполнял.
+	GetByStudent(ctx context.Context, studentID common.UUID) (*Resume, error)
+	// Replace заменяет все разделы резюме; вызывается внутри UnitOfWork вместе с сохранением профиля.
+	Replace(ctx context.Context, studentID common.UUID, resume Resume) error
+}
@@ -0,0 +1,24 @@
+package profile
+
+import "profzom/internal/common"
+
+type SkillsMatch string
+
+const (
+	SkillsMatchAny SkillsMatch = "any"
+	SkillsMatchAll SkillsMatch = "all"
+)
+
+// CandidateQuery — фильтры поиска студентов компанией. Skills уже нормализованы через NormalizeSkills;
+// при непустом Text выдача сортируется по релевантности текста «о себе».
+type CandidateQuery struct {
+	Text       string
+	Skills     []string
+	SkillsMode SkillsMatch
+	University string
+	Specialty  string
+	CourseMin  *int
+	CourseMax  *int
+	Limit      int
+	After      *common.Cursor
+}
@@ -0,0 +1,60 @@
+package profile
+
+import "strings"
+
+// skillSynonyms сводит распространённые написания навыка к одному каноническому виду.
+var skillSynonyms = map[string]string{
+	"golang":            "go",
+	"js":                "javascript",
+	"ecmascript":        "javascript",
+	"ts":                "typescript",
+	"py":                "python",
+	"python3":           "python",
+	"postgres":          "postgresql",
+	"postgre":           "postgresql",
+	"psql":              "postgresql",
+	"pg":                "postgresql",
+	"k8s":               "kubernetes",
+	"csharp":            "c#",
+	"c sharp":           "c#",
+	"cpp":               "c++",
+	"react.js":          "react",
+	"reactjs":           "react",
+	"vue.js":            "vue",
+	"vuejs":             "vue",
+	"node":              "node.js",
+	"nodejs":            "node.js",
+	"ml":                "machine learning",
+	"машинное обучение": "machine learning",
+	"ms sql":            "sql server",
+	"mssql":             "sql server",
+	"гит":               "git",
+	"докер":             "docker",
+	"питон":             "python",
+	"линукс":            "linux",
+}
+
+// NormalizeSkill приводит навык к каноническому виду: нижний регистр, одиночные пробелы, синонимы.
+func NormalizeSkill(value string) string {
+	normalized := strings.Join(strings.Fields(strings.ToLower(value)), " ")
+	normalized = strings.Trim(normalized, ".,;:!?()[]\"'")
+	if canonical, ok := skillSynonyms[normalized]; ok {
+		return canonical
+	}
+	return normalized
+}
+
+// NormalizeSkills нормализует список навыков, убирая пустые значения и дубликаты.
+func NormalizeSkills(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	normalized := make([]string, 0, len(values))
+	for _, value := range values {
+		skill := NormalizeSkill(value)
+		if skill == "" || seen[skill] {
+			continue
+		}
+		seen[skill] = true
+		normalized = append(normalized, skill)
+	}
+	return normalized
+}
@@ -14,6 +14,8 @@ type StudentProfile struct {
 	Specialty  string      `json:"specialty"`
 	Skills     []string    `json:"skills"`
 	About      string      `json:"about"`
-	CreatedAt  time.Time   `json:"created_at"`
-	UpdatedAt  time.Time   `json:"updated_at"`
+	// VisibleToCompanies — студент согласен, чтобы компании находили его в поиске кандидатов.
+	VisibleToCompanies bool      `json:"visible_to_companies"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
 }
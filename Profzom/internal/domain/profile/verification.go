@@ -0,0 +1,45 @@
+package profile
+
+import (
+	"context"
+	"time"
+
+	"profzom/internal/common"
+)
+
+type VerificationStatus string
+
+const (
+	VerificationUnverified VerificationStatus = "unverified"
+	VerificationPending    VerificationStatus = "pending"
+	VerificationVerified   VerificationStatus = "verified"
+	VerificationRejected   VerificationStatus = "rejected"
+)
+
+// CompanyVerification — заявка компании на подтверждение юрлица: реквизиты и решение модератора.
+// Компания без заявки считается unverified.
+type CompanyVerification struct {
+	CompanyID       common.UUID        `json:"company_id"`
+	CompanyName     string             `json:"company_name,omitempty"`
+	Status          VerificationStatus `json:"status"`
+	LegalName       string             `json:"legal_name,omitempty"`
+	INN             string             `json:"inn,omitempty"`
+	OGRN            string             `json:"ogrn,omitempty"`
+	RejectionReason string             `json:"rejection_reason,omitempty"`
+	SubmittedAt     *time.Time         `json:"submitted_at,omitempty"`
+	ReviewedBy      *common.UUID       `json:"reviewed_by,omitempty"`
+	ReviewedAt      *time.Time         `json:"reviewed_at,omitempty"`
+	UpdatedAt       time.Time          `json:"updated_at"`
+}
+
+type VerificationRepository interface {
+	// GetByCompany возвращает CodeNotFound, если компания заявку не подавала.
+	GetByCompany(ctx context.Context, companyID common.UUID) (*CompanyVerification, error)
+	// Save создаёт или заменяет заявку; подтверждённую заявку не трогает и возвращает CodeConflict.
+	Save(ctx context.Context, verification CompanyVerification) (*CompanyVerification, error)
+	// Review записывает решение модератора, только если заявка всё ещё ждёт проверки и её updated_at
+	// равен verification.UpdatedAt; если её уже рассмотрели или исправили, возвращает CodeConflict.
+	Review(ctx context.Context, verification CompanyVerification) (*CompanyVerification, error)
+	// ListByStatus отдаёт заявки в порядке подачи, старые первыми; CompanyName берётся из профиля компании.
+	ListByStatus(ctx context.Context, status VerificationStatus, page common.PageRequest) ([]CompanyVerification, *common.Cursor, error)
+}
@@ -0,0 +1,55 @@
+package savedsearch
+
+import (
+	"context"
+	"time"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/vacancy"
+)
+
+// Query — подмножество параметров GET /vacancies, на которое можно подписаться.
+type Query struct {
+	Text       string               `json:"q,omitempty"`
+	Type       string               `json:"type,omitempty"`
+	Location   string               `json:"location,omitempty"`
+	SalaryFrom *int                 `json:"salary_from,omitempty"`
+	SalaryTo   *int                 `json:"salary_to,omitempty"`
+	Period     vacancy.SalaryPeriod `json:"period,omitempty"`
+}
+
+func (q Query) IsEmpty() bool {
+	return q.Text == "" && q.Type == "" && q.Location == "" && q.SalaryFrom == nil && q.SalaryTo == nil && q.Period == ""
+}
+
+type SavedSearch struct {
+	ID        common.UUID `json:"id"`
+	StudentID common.UUID `json:"student_id"`
+	Name      string      `json:"name"`
+	Query     Query       `json:"query"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// Match — опубликованная вакансия, подошедшая под сохранённый поиск и ожидающая отправки в дайджесте.
+type Match struct {
+	SearchID     common.UUID
+	SearchName   string
+	StudentID    common.UUID
+	VacancyID    common.UUID
+	VacancyTitle string
+	CreatedAt    time.Time
+}
+
+type Repository interface {
+	Create(ctx context.Context, search SavedSearch) (*SavedSearch, error)
+	ListByStudent(ctx context.Context, studentID common.UUID) ([]SavedSearch, error)
+	Delete(ctx context.Context, id, studentID common.UUID) error
+	// RecordMatches сверяет опубликованную вакансию со всеми сохранёнными поисками и ставит совпадения в очередь дайджеста.
+	// Повторный вызов для той же вакансии дублей не создаёт.
+	RecordMatches(ctx context.Context, vacancyID common.UUID) (int, error)
+	// ClaimMatches забирает неотправленные совпадения по опубликованным вакансиям и откладывает их на lease,
+	// чтобы их не взял другой экземпляр рассылки. Результат упорядочен по студенту и времени.
+	ClaimMatches(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]Match, error)
+	MarkNotified(ctx context.Context, studentID common.UUID, vacancyIDs []common.UUID, notifiedAt time.Time) error
+}
@@ -0,0 +1,25 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"profzom/internal/common"
+)
+
+// BlockedUser — запись личного чёрного списка: пользователь BlockerID не хочет контактировать с BlockedID.
+// Не путать с блокировкой аккаунта администратором (User.BlockedAt).
+type BlockedUser struct {
+	BlockerID common.UUID `json:"blocker_id"`
+	BlockedID common.UUID `json:"blocked_id"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+type BlockListRepository interface {
+	// Add вносит пользователя в чёрный список; повторный вызов возвращает существующую запись.
+	Add(ctx context.Context, blockerID, blockedID common.UUID) (*BlockedUser, error)
+	Remove(ctx context.Context, blockerID, blockedID common.UUID) error
+	List(ctx context.Context, blockerID common.UUID, page common.PageRequest) ([]BlockedUser, *common.Cursor, error)
+	// Between сообщает, внёс ли кто-то из двух пользователей другого в чёрный список.
+	Between(ctx context.Context, a, b common.UUID) (bool, error)
+}
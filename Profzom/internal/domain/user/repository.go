@@ -2,6 +2,7 @@ package user
 
 import (
 	"context"
+	"time"
 
 	"profzom/internal/common"
 )
@@ -12,4 +13,10 @@ type Repository interface {
 	Create(ctx context.Context, phone string) (*User, error)
 	SetRoles(ctx context.Context, userID common.UUID, roles []Role) error
 	ListRoles(ctx context.Context, userID common.UUID) ([]Role, error)
+	AddRole(ctx context.Context, userID common.UUID, role Role) error
+	RemoveRole(ctx context.Context, userID common.UUID, role Role) error
+	// SetBlocked блокирует аккаунт при blockedAt != nil и снимает блокировку при nil.
+	SetBlocked(ctx context.Context, userID common.UUID, blockedAt *time.Time, reason string) error
+	IsBlocked(ctx context.Context, userID common.UUID) (bool, error)
+	Search(ctx context.Context, query SearchQuery) ([]Account, *common.Cursor, error)
 }
@@ -11,6 +11,8 @@ type Role string
 const (
 	RoleStudent Role = "student"
 	RoleCompany Role = "company"
+	// RoleAdmin выдаётся только вручную и не выбирается через PATCH /users/role.
+	RoleAdmin Role = "admin"
 )
 
 type User struct {
@@ -19,4 +21,27 @@ type User struct {
 	Roles     []Role
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	// BlockedAt задан, если администратор заблокировал аккаунт: вход и API для него закрыты.
+	BlockedAt   *time.Time
+	BlockReason string
+}
+
+// Account — карточка пользователя в админке; Name берётся из профиля студента или компании.
+type Account struct {
+	ID          common.UUID `json:"id"`
+	Phone       string      `json:"phone,omitempty"`
+	Roles       []Role      `json:"roles"`
+	Name        string      `json:"name,omitempty"`
+	BlockedAt   *time.Time  `json:"blocked_at,omitempty"`
+	BlockReason string      `json:"block_reason,omitempty"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
+// SearchQuery — поиск аккаунтов: Text сравнивается с id целиком, с телефоном и именем — по подстроке.
+type SearchQuery struct {
+	Text    string
+	Role    Role
+	Blocked *bool
+	Limit   int
+	After   *common.Cursor
 }
@@ -0,0 +1,33 @@
+package vacancy
+
+import (
+	"context"
+	"time"
+
+	"profzom/internal/common"
+)
+
+// Bookmark — вакансия, сохранённая студентом. VacancyUpdatedAt фиксирует версию вакансии на момент сохранения.
+type Bookmark struct {
+	StudentID        common.UUID `json:"student_id"`
+	VacancyID        common.UUID `json:"vacancy_id"`
+	VacancyUpdatedAt time.Time   `json:"vacancy_updated_at"`
+	SavedAt          time.Time   `json:"saved_at"`
+}
+
+type SavedVacancy struct {
+	Vacancy Vacancy   `json:"vacancy"`
+	SavedAt time.Time `json:"saved_at"`
+	// Changed — вакансию редактировали или меняли её статус после сохранения.
+	Changed bool `json:"changed"`
+	// Closed — вакансия больше не принимает отклики.
+	Closed bool `json:"closed"`
+}
+
+type BookmarkRepository interface {
+	// Save добавляет закладку или обновляет сохранённую версию вакансии у существующей.
+	Save(ctx context.Context, studentID, vacancyID common.UUID) (*Bookmark, error)
+	Delete(ctx context.Context, studentID, vacancyID common.UUID) error
+	List(ctx context.Context, studentID common.UUID, page common.PageRequest) ([]SavedVacancy, *common.Cursor, error)
+	SavedIDs(ctx context.Context, studentID common.UUID, vacancyIDs []common.UUID) (map[common.UUID]bool, error)
+}
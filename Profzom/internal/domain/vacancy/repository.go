@@ -2,6 +2,7 @@ package vacancy
 
 import (
 	"context"
+	"time"
 
 	"profzom/internal/common"
 )
@@ -10,6 +11,8 @@ type Repository interface {
 	Create(ctx context.Context, vacancy Vacancy) (*Vacancy, error)
 	Update(ctx context.Context, vacancy Vacancy) (*Vacancy, error)
 	GetByID(ctx context.Context, id common.UUID) (*Vacancy, error)
-	ListPublished(ctx context.Context, limit, offset int) ([]Vacancy, error)
-	ListByCompany(ctx context.Context, companyID common.UUID) ([]Vacancy, error)
+	ListPublished(ctx context.Context, page common.PageRequest) ([]Vacancy, *common.Cursor, error)
+	Search(ctx context.Context, query SearchQuery) ([]Vacancy, *common.Cursor, error)
+	ListByCompany(ctx context.Context, companyID common.UUID, page common.PageRequest) ([]Vacancy, *common.Cursor, error)
+	CloseExpired(ctx context.Context, now time.Time) ([]Vacancy, error)
 }
@@ -0,0 +1,23 @@
+package vacancy
+
+import "profzom/internal/common"
+
+type SortOrder string
+
+const (
+	SortRelevance SortOrder = "relevance"
+	SortNewest    SortOrder = "newest"
+)
+
+type SearchQuery struct {
+	Text     string
+	Type     string
+	Location string
+	// SalaryFrom и SalaryTo отбирают вакансии, чья вилка пересекается с заданным диапазоном.
+	SalaryFrom *int
+	SalaryTo   *int
+	Period     SalaryPeriod
+	Sort       SortOrder
+	Limit      int
+	After      *common.Cursor
+}
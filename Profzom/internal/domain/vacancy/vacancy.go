@@ -11,19 +11,38 @@ type Status string
 const (
 	StatusDraft     Status = "draft"
 	StatusPublished Status = "published"
+	StatusClosed    Status = "closed"
+	StatusArchived  Status = "archived"
+	// StatusPendingReview — публикация задержана автоматической модерацией до решения администратора.
+	StatusPendingReview Status = "pending_review"
+)
+
+type SalaryPeriod string
+
+const (
+	SalaryPeriodHour  SalaryPeriod = "hour"
+	SalaryPeriodMonth SalaryPeriod = "month"
 )
 
 type Vacancy struct {
-	ID           common.UUID `json:"id"`
-	CompanyID    common.UUID `json:"company_id"`
-	Title        string      `json:"title"`
-	Type         string      `json:"type"`
-	Description  string      `json:"description"`
-	Requirements []string    `json:"requirements"`
-	Conditions   []string    `json:"conditions"`
-	Salary       string      `json:"salary"`
-	Location     string      `json:"location"`
-	Status       Status      `json:"status"`
-	CreatedAt    time.Time   `json:"created_at"`
-	UpdatedAt    time.Time   `json:"updated_at"`
+	ID           common.UUID  `json:"id"`
+	CompanyID    common.UUID  `json:"company_id"`
+	Title        string       `json:"title"`
+	Type         string       `json:"type"`
+	Description  string       `json:"description"`
+	Requirements []string     `json:"requirements"`
+	Conditions   []string     `json:"conditions"`
+	SalaryMin    *int         `json:"salary_min,omitempty"`
+	SalaryMax    *int         `json:"salary_max,omitempty"`
+	Currency     string       `json:"currency,omitempty"`
+	Period       SalaryPeriod `json:"period"`
+	IsNegotiable bool         `json:"is_negotiable"`
+	Location     string       `json:"location"`
+	Status       Status       `json:"status"`
+	ExpiresAt    *time.Time   `json:"expires_at,omitempty"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+
+	// CompanyVerified — бейдж: компания-владелец прошла проверку реквизитов.
+	CompanyVerified bool `json:"company_verified"`
 }
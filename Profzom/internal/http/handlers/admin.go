@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"profzom/internal/app"
+	"profzom/internal/common"
+	"profzom/internal/domain/admin"
+	"profzom/internal/domain/application"
+	"profzom/internal/domain/user"
+	"profzom/internal/http/middleware"
+	"profzom/internal/http/response"
+	"profzom/internal/security"
+)
+
+type AdminHandler struct {
+	admin   *app.AdminService
+	cursors *security.CursorSigner
+}
+
+func NewAdminHandler(admin *app.AdminService, cursors *security.CursorSigner) *AdminHandler {
+	return &AdminHandler{admin: admin, cursors: cursors}
+}
+
+type adminReasonRequest struct {
+	Reason string `json:"reason"`
+}
+
+func (h *AdminHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	page, err := pageFromRequest(r, h.cursors)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	query := user.SearchQuery{
+		Text:  r.URL.Query().Get("q"),
+		Role:  user.Role(r.URL.Query().Get("role")),
+		Limit: page.Limit,
+		After: page.After,
+	}
+	if value := strings.TrimSpace(r.URL.Query().Get("blocked")); value != "" {
+		blocked, err := strconv.ParseBool(value)
+		if err != nil {
+			response.Error(w, common.NewValidationError("invalid blocked", map[string]string{"blocked": "blocked must be true or false"}))
+			return
+		}
+		query.Blocked = &blocked
+	}
+	items, next, err := h.admin.SearchUsers(r.Context(), query)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	writePage(w, r, h.cursors, items, next)
+}
+
+func (h *AdminHandler) BlockUser(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	userID, err := idFromPath(r, 2)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	var req adminReasonRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
+		return
+	}
+	account, err := h.admin.BlockUser(r.Context(), adminID, userID, req.Reason)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, accountFromUser(account))
+}
+
+func (h *AdminHandler) UnblockUser(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	userID, err := idFromPath(r, 2)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	account, err := h.admin.UnblockUser(r.Context(), adminID, userID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, accountFromUser(account))
+}
+
+func (h *AdminHandler) UnpublishVacancy(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	vacancyID, err := idFromPath(r, 2)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	var req adminReasonRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
+		return
+	}
+	updated, err := h.admin.UnpublishVacancy(r.Context(), adminID, vacancyID, req.Reason)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, updated)
+}
+
+func (h *AdminHandler) ListApplications(w http.ResponseWriter, r *http.Request) {
+	page, err := pageFromRequest(r, h.cursors)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	query := application.PipelineQuery{Sort: application.PipelineSort(r.URL.Query().Get("sort")), Statuses: statusesFromQuery(r), Limit: page.Limit, After: page.After}
+	companyID, err := optionalUUIDQuery(r, "company_id")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	if companyID != nil {
+		query.CompanyID = *companyID
+	}
+	if query.VacancyID, err = optionalUUIDQuery(r, "vacancy_id"); err != nil {
+		response.Error(w, err)
+		return
+	}
+	if query.StudentID, err = optionalUUIDQuery(r, "student_id"); err != nil {
+		response.Error(w, err)
+		return
+	}
+	items, next, err := h.admin.ListApplications(r.Context(), query)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	writePage(w, r, h.cursors, items, next)
+}
+
+func (h *AdminHandler) Stats(w http.ResponseWriter, r *http.Request) {
+	var since time.Time
+	if value := strings.TrimSpace(r.URL.Query().Get("since")); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			response.Error(w, common.NewValidationError("invalid since", map[string]string{"since": "since must be an RFC 3339 timestamp"}))
+			return
+		}
+		since = parsed
+	}
+	stats, err := h.admin.Stats(r.Context(), since)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, stats)
+}
+
+func (h *AdminHandler) AuditLog(w http.ResponseWriter, r *http.Request) {
+	page, err := pageFromRequest(r, h.cursors)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	query := admin.AuditQuery{
+		TargetID: r.URL.Query().Get("target_id"),
+		Action:   r.URL.Query().Get("action"),
+		Limit:    page.Limit,
+		After:    page.After,
+	}
+	if query.AdminID, err = optionalUUIDQuery(r, "admin_id"); err != nil {
+		response.Error(w, err)
+		return
+	}
+	items, next, err := h.admin.AuditLog(r.Context(), query)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	writePage(w, r, h.cursors, items, next)
+}
+
+func accountFromUser(account *user.User) user.Account {
+	return user.Account{
+		ID:          account.ID,
+		Phone:       account.Phone,
+		Roles:       account.Roles,
+		BlockedAt:   account.BlockedAt,
+		BlockReason: account.BlockReason,
+		CreatedAt:   account.CreatedAt,
+	}
+}
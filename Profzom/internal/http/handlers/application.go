@@ -10,15 +10,18 @@ import (
 	"profzom/internal/domain/application"
 	"profzom/internal/http/middleware"
 	"profzom/internal/http/response"
+	"profzom/internal/security"
 )
 
 type ApplicationHandler struct {
 	applications *app.ApplicationService
+	interviews   *app.InterviewService
 	limiter      *middleware.RateLimiter
+	cursors      *security.CursorSigner
 }
 
-func NewApplicationHandler(applications *app.ApplicationService, limiter *middleware.RateLimiter) *ApplicationHandler {
-	return &ApplicationHandler{applications: applications, limiter: limiter}
+func NewApplicationHandler(applications *app.ApplicationService, interviews *app.InterviewService, limiter *middleware.RateLimiter, cursors *security.CursorSigner) *ApplicationHandler {
+	return &ApplicationHandler{applications: applications, interviews: interviews, limiter: limiter, cursors: cursors}
 }
 
 type applyRequest struct {
@@ -57,12 +60,17 @@ func (h *ApplicationHandler) ListStudent(w http.ResponseWriter, r *http.Request)
 		response.Error(w, errUnauthorized())
 		return
 	}
-	items, err := h.applications.ListByStudent(r.Context(), studentID)
+	page, err := pageFromRequest(r, h.cursors)
 	if err != nil {
 		response.Error(w, err)
 		return
 	}
-	response.JSON(w, http.StatusOK, items)
+	items, next, err := h.applications.ListByStudent(r.Context(), studentID, page)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	writePage(w, r, h.cursors, items, next)
 }
 
 func (h *ApplicationHandler) ListCompany(w http.ResponseWriter, r *http.Request) {
@@ -82,17 +90,64 @@ func (h *ApplicationHandler) ListCompany(w http.ResponseWriter, r *http.Request)
 			return
 		}
 	}
-	items, err := h.applications.ListByCompany(r.Context(), companyID)
+	page, err := pageFromRequest(r, h.cursors)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	query := application.PipelineQuery{CompanyID: companyID, Sort: application.PipelineSort(r.URL.Query().Get("sort")), Limit: page.Limit, After: page.After}
+	if value := strings.TrimSpace(r.URL.Query().Get("vacancy_id")); value != "" {
+		vacancyID, err := common.ParseUUID(value)
+		if err != nil {
+			response.Error(w, common.NewValidationError("invalid vacancy_id", map[string]string{"vacancy_id": "invalid uuid"}))
+			return
+		}
+		query.VacancyID = &vacancyID
+	}
+	query.Statuses = statusesFromQuery(r)
+	result, err := h.applications.Pipeline(r.Context(), query)
 	if err != nil {
 		response.Error(w, err)
 		return
 	}
-	response.JSON(w, http.StatusOK, items)
+	payload := pipelineResponse{Items: result.Items, Counts: result.Counts}
+	if payload.Items == nil {
+		payload.Items = []application.PipelineItem{}
+	}
+	if result.Next != nil {
+		encoded, err := encodeCursor(r, h.cursors, *result.Next)
+		if err != nil {
+			response.Error(w, common.NewError(common.CodeInternal, "failed to encode cursor", err))
+			return
+		}
+		payload.NextCursor = encoded
+	}
+	response.JSON(w, http.StatusOK, payload)
+}
+
+// statusesFromQuery собирает фильтр status: его можно передать несколько раз или списком через запятую.
+func statusesFromQuery(r *http.Request) []application.Status {
+	var statuses []application.Status
+	for _, value := range r.URL.Query()["status"] {
+		for _, status := range strings.Split(value, ",") {
+			if status = strings.TrimSpace(status); status != "" {
+				statuses = append(statuses, application.Status(status))
+			}
+		}
+	}
+	return statuses
+}
+
+type pipelineResponse struct {
+	Items      []application.PipelineItem `json:"items"`
+	NextCursor string                     `json:"next_cursor,omitempty"`
+	Counts     map[application.Status]int `json:"counts"`
 }
 
 type updateStatusRequest struct {
-	Status   string `json:"status"`
-	Feedback string `json:"feedback"`
+	Status    string                    `json:"status"`
+	Feedback  string                    `json:"feedback"`
+	Interview *interviewProposalRequest `json:"interview"`
 }
 
 func (h *ApplicationHandler) UpdateStatus(w http.ResponseWriter, r *http.Request) {
@@ -115,6 +170,22 @@ func (h *ApplicationHandler) UpdateStatus(w http.ResponseWriter, r *http.Request
 		response.Error(w, common.NewError(common.CodeValidation, "status is required", nil))
 		return
 	}
+	if req.Interview != nil {
+		if application.Status(strings.ToLower(strings.TrimSpace(req.Status))) != application.StatusInvited {
+			response.Error(w, common.NewValidationError("interview is allowed only when inviting", map[string]string{"interview": "status must be invited"}))
+			return
+		}
+		updated, scheduled, err := h.interviews.Invite(r.Context(), applicationID, companyID, req.Feedback, req.Interview.toProposal())
+		if err != nil {
+			response.Error(w, err)
+			return
+		}
+		response.JSON(w, http.StatusOK, map[string]interface{}{
+			"application": updated,
+			"interview":   scheduled,
+		})
+		return
+	}
 	updated, err := h.applications.UpdateStatus(r.Context(), applicationID, application.Status(req.Status), req.Feedback, companyID)
 	if err != nil {
 		response.Error(w, err)
@@ -123,6 +194,72 @@ func (h *ApplicationHandler) UpdateStatus(w http.ResponseWriter, r *http.Request
 	response.JSON(w, http.StatusOK, updated)
 }
 
+func (h *ApplicationHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	applicationID, err := idFromPath(r, 1)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	item, history, err := h.applications.GetForParticipant(r.Context(), applicationID, userID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	if history == nil {
+		history = []application.StatusEvent{}
+	}
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"application": item,
+		"history":     history,
+	})
+}
+
+func (h *ApplicationHandler) Withdraw(w http.ResponseWriter, r *http.Request) {
+	studentID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	applicationID, err := idFromPath(r, 2)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	updated, err := h.applications.Withdraw(r.Context(), applicationID, studentID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, updated)
+}
+
+func (h *ApplicationHandler) History(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	applicationID, err := idFromPath(r, 2)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	history, err := h.applications.History(r.Context(), applicationID, userID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	if history == nil {
+		history = []application.StatusEvent{}
+	}
+	response.JSON(w, http.StatusOK, history)
+}
+
 func vacancyIDFromRequest(r *http.Request) (common.UUID, error) {
 	var req applyRequest
 	if err := decodeJSON(r, &req); err != nil {
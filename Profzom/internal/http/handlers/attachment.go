@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"profzom/internal/app"
+	"profzom/internal/common"
+	"profzom/internal/http/middleware"
+	"profzom/internal/http/response"
+)
+
+const (
+	// MaxUploadBodyBytes — лимит тела запроса загрузки: файл плюс запас на заголовки multipart.
+	MaxUploadBodyBytes = app.AttachmentMaxSize + 64<<10
+	transferTimeout    = 2 * time.Minute
+)
+
+// Upload принимает multipart/form-data с файлом в поле file и возвращает метаданные вложения для attachment_ids.
+func (h *MessageHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	applicationID, err := idFromPath(r, 2)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	extendTransferDeadlines(w)
+	reader, err := r.MultipartReader()
+	if err != nil {
+		response.Error(w, common.NewError(common.CodeValidation, "multipart/form-data body is required", err))
+		return
+	}
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			response.Error(w, common.NewValidationError("invalid attachment", map[string]string{"file": "file is required"}))
+			return
+		}
+		if err != nil {
+			response.Error(w, uploadReadError(err))
+			return
+		}
+		if part.FormName() != "file" {
+			part.Close()
+			continue
+		}
+		// читаем на байт больше лимита, чтобы сервис отличил слишком большой файл от файла ровно на лимит
+		data, err := io.ReadAll(io.LimitReader(part, app.AttachmentMaxSize+1))
+		part.Close()
+		if err != nil {
+			response.Error(w, uploadReadError(err))
+			return
+		}
+		created, err := h.messages.Upload(r.Context(), applicationID, userID, part.FileName(), data)
+		if err != nil {
+			response.Error(w, err)
+			return
+		}
+		response.JSON(w, http.StatusCreated, created)
+		return
+	}
+}
+
+// Download отдаёт файл вложения участнику отклика.
+func (h *MessageHandler) Download(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	attachmentID, err := idFromPath(r, 1)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	attachment, body, err := h.messages.OpenAttachment(r.Context(), attachmentID, userID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	defer body.Close()
+	extendTransferDeadlines(w)
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.Copy(w, body)
+}
+
+// extendTransferDeadlines продлевает дедлайны соединения сверх ReadTimeout/WriteTimeout сервера, рассчитанных на обычные запросы.
+func extendTransferDeadlines(w http.ResponseWriter) {
+	controller := http.NewResponseController(w)
+	deadline := time.Now().Add(transferTimeout)
+	_ = controller.SetReadDeadline(deadline)
+	_ = controller.SetWriteDeadline(deadline)
+}
+
+func uploadReadError(err error) error {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return common.NewValidationError("invalid attachment", map[string]string{"file": "file is too large"})
+	}
+	return common.NewError(common.CodeValidation, "invalid multipart body", err)
+}
@@ -38,8 +38,9 @@ type verifyOTPRequest struct {
 }
 
 type verifyResponse struct {
-	Token     string `json:"token"`
-	IsNewUser bool   `json:"is_new_user"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	IsNewUser    bool   `json:"is_new_user"`
 }
 
 type refreshRequest struct {
@@ -171,7 +172,7 @@ func (h *AuthHandler) VerifyOTP(w http.ResponseWriter, r *http.Request) {
 		response.Error(w, err)
 		return
 	}
-	response.JSON(w, http.StatusOK, verifyResponse{Token: pair.AccessToken, IsNewUser: isNewUser})
+	response.JSON(w, http.StatusOK, verifyResponse{Token: pair.AccessToken, RefreshToken: pair.RefreshToken, IsNewUser: isNewUser})
 }
 
 func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
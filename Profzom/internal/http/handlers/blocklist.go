@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"net/http"
+
+	"profzom/internal/app"
+	"profzom/internal/http/middleware"
+	"profzom/internal/http/response"
+	"profzom/internal/security"
+)
+
+type BlockListHandler struct {
+	blocks  *app.BlockListService
+	cursors *security.CursorSigner
+}
+
+func NewBlockListHandler(blocks *app.BlockListService, cursors *security.CursorSigner) *BlockListHandler {
+	return &BlockListHandler{blocks: blocks, cursors: cursors}
+}
+
+func (h *BlockListHandler) Block(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	blockedID, err := idFromPath(r, 1)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	blocked, err := h.blocks.Block(r.Context(), userID, blockedID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, blocked)
+}
+
+func (h *BlockListHandler) Unblock(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	blockedID, err := idFromPath(r, 1)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	if err := h.blocks.Unblock(r.Context(), userID, blockedID); err != nil {
+		response.Error(w, err)
+		return
+	}
+	response.JSON(w, http.StatusNoContent, nil)
+}
+
+func (h *BlockListHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	page, err := pageFromRequest(r, h.cursors)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	items, next, err := h.blocks.List(r.Context(), userID, page)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	writePage(w, r, h.cursors, items, next)
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"net/http"
+
+	"profzom/internal/app"
+	"profzom/internal/http/middleware"
+	"profzom/internal/http/response"
+	"profzom/internal/security"
+)
+
+type BookmarkHandler struct {
+	bookmarks *app.BookmarkService
+	cursors   *security.CursorSigner
+}
+
+func NewBookmarkHandler(bookmarks *app.BookmarkService, cursors *security.CursorSigner) *BookmarkHandler {
+	return &BookmarkHandler{bookmarks: bookmarks, cursors: cursors}
+}
+
+func (h *BookmarkHandler) Save(w http.ResponseWriter, r *http.Request) {
+	studentID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	vacancyID, err := idFromPath(r, 1)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	bookmark, err := h.bookmarks.Save(r.Context(), studentID, vacancyID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, bookmark)
+}
+
+func (h *BookmarkHandler) Remove(w http.ResponseWriter, r *http.Request) {
+	studentID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	vacancyID, err := idFromPath(r, 1)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	if err := h.bookmarks.Remove(r.Context(), studentID, vacancyID); err != nil {
+		response.Error(w, err)
+		return
+	}
+	response.JSON(w, http.StatusNoContent, nil)
+}
+
+func (h *BookmarkHandler) List(w http.ResponseWriter, r *http.Request) {
+	studentID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	page, err := pageFromRequest(r, h.cursors)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	items, next, err := h.bookmarks.List(r.Context(), studentID, page)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	writePage(w, r, h.cursors, items, next)
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"profzom/internal/app"
+	"profzom/internal/domain/profile"
+	"profzom/internal/http/response"
+	"profzom/internal/security"
+)
+
+type CandidateHandler struct {
+	profiles *app.ProfileService
+	cursors  *security.CursorSigner
+}
+
+func NewCandidateHandler(profiles *app.ProfileService, cursors *security.CursorSigner) *CandidateHandler {
+	return &CandidateHandler{profiles: profiles, cursors: cursors}
+}
+
+func (h *CandidateHandler) Search(w http.ResponseWriter, r *http.Request) {
+	page, err := pageFromRequest(r, h.cursors)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	courseMin, err := optionalIntQuery(r, "course_min")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	courseMax, err := optionalIntQuery(r, "course_max")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	query := r.URL.Query()
+	var skills []string
+	for _, value := range query["skills"] {
+		skills = append(skills, strings.Split(value, ",")...)
+	}
+	items, next, err := h.profiles.SearchCandidates(r.Context(), profile.CandidateQuery{
+		Text:       query.Get("q"),
+		Skills:     skills,
+		SkillsMode: profile.SkillsMatch(query.Get("skills_mode")),
+		University: query.Get("university"),
+		Specialty:  query.Get("specialty"),
+		CourseMin:  courseMin,
+		CourseMax:  courseMax,
+		Limit:      page.Limit,
+		After:      page.After,
+	})
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	writePage(w, r, h.cursors, items, next)
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"profzom/internal/common"
@@ -31,3 +32,27 @@ func decodeJSON(r *http.Request, dst interface{}) error {
 	}
 	return nil
 }
+
+func optionalIntQuery(r *http.Request, name string) (*int, error) {
+	value := strings.TrimSpace(r.URL.Query().Get(name))
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < 0 {
+		return nil, common.NewValidationError("invalid "+name, map[string]string{name: name + " must be a non-negative integer"})
+	}
+	return &parsed, nil
+}
+
+func optionalUUIDQuery(r *http.Request, name string) (*common.UUID, error) {
+	value := strings.TrimSpace(r.URL.Query().Get(name))
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := common.ParseUUID(value)
+	if err != nil {
+		return nil, common.NewValidationError("invalid "+name, map[string]string{name: "invalid uuid"})
+	}
+	return &parsed, nil
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"profzom/internal/app"
+	"profzom/internal/common"
+	"profzom/internal/domain/interview"
+	"profzom/internal/http/middleware"
+	"profzom/internal/http/response"
+)
+
+type InterviewHandler struct {
+	interviews *app.InterviewService
+}
+
+func NewInterviewHandler(interviews *app.InterviewService) *InterviewHandler {
+	return &InterviewHandler{interviews: interviews}
+}
+
+type interviewSlotRequest struct {
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+}
+
+type interviewProposalRequest struct {
+	Slots    []interviewSlotRequest `json:"slots"`
+	Format   string                 `json:"format"`
+	Location string                 `json:"location"`
+	Timezone string                 `json:"timezone"`
+}
+
+func (req interviewProposalRequest) toProposal() app.InterviewProposal {
+	slots := make([]interview.Slot, 0, len(req.Slots))
+	for _, slot := range req.Slots {
+		slots = append(slots, interview.Slot{StartsAt: slot.StartsAt, EndsAt: slot.EndsAt})
+	}
+	return app.InterviewProposal{Slots: slots, Format: interview.Format(req.Format), Location: req.Location, Timezone: req.Timezone}
+}
+
+type selectSlotRequest struct {
+	SlotID string `json:"slot_id"`
+}
+
+func (h *InterviewHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	applicationID, err := idFromPath(r, 2)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	item, err := h.interviews.Get(r.Context(), applicationID, userID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, item)
+}
+
+// Propose предлагает слоты или переносит уже согласованное собеседование.
+func (h *InterviewHandler) Propose(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	applicationID, err := idFromPath(r, 2)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	var req interviewProposalRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
+		return
+	}
+	item, err := h.interviews.Propose(r.Context(), applicationID, userID, req.toProposal())
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, item)
+}
+
+func (h *InterviewHandler) Select(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	applicationID, err := idFromPath(r, 3)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	var req selectSlotRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
+		return
+	}
+	slotID, err := common.ParseUUID(req.SlotID)
+	if err != nil {
+		response.Error(w, common.NewValidationError("invalid slot_id", map[string]string{"slot_id": "invalid uuid"}))
+		return
+	}
+	item, err := h.interviews.Select(r.Context(), applicationID, userID, slotID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, item)
+}
+
+func (h *InterviewHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	applicationID, err := idFromPath(r, 3)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	item, err := h.interviews.Cancel(r.Context(), applicationID, userID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, item)
+}
+
+func (h *InterviewHandler) Calendar(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	applicationID, err := idFromPath(r, 2)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	doc, err := h.interviews.Calendar(r.Context(), applicationID, userID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="interview.ics"`)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(doc)
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"profzom/internal/app"
+	"profzom/internal/common"
+	"profzom/internal/domain/invitation"
+	"profzom/internal/http/middleware"
+	"profzom/internal/http/response"
+	"profzom/internal/security"
+)
+
+type InvitationHandler struct {
+	invitations *app.InvitationService
+	limiter     *middleware.RateLimiter
+	cursors     *security.CursorSigner
+}
+
+func NewInvitationHandler(invitations *app.InvitationService, limiter *middleware.RateLimiter, cursors *security.CursorSigner) *InvitationHandler {
+	return &InvitationHandler{invitations: invitations, limiter: limiter, cursors: cursors}
+}
+
+type inviteRequest struct {
+	VacancyID string `json:"vacancy_id"`
+	StudentID string `json:"student_id"`
+	Message   string `json:"message"`
+}
+
+func (h *InvitationHandler) Invite(w http.ResponseWriter, r *http.Request) {
+	companyID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	var req inviteRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
+		return
+	}
+	fields := map[string]string{}
+	vacancyID, err := common.ParseUUID(strings.TrimSpace(req.VacancyID))
+	if err != nil {
+		fields["vacancy_id"] = "invalid uuid"
+	}
+	studentID, err := common.ParseUUID(strings.TrimSpace(req.StudentID))
+	if err != nil {
+		fields["student_id"] = "invalid uuid"
+	}
+	if len(fields) > 0 {
+		response.Error(w, common.NewValidationError("invalid request", fields))
+		return
+	}
+	if h.limiter != nil {
+		if !h.limiter.Allow("invite:"+companyID.String(), 30, time.Hour) {
+			response.Error(w, common.NewError(common.CodeRateLimited, "invitation rate limit exceeded", nil))
+			return
+		}
+	}
+	created, err := h.invitations.Invite(r.Context(), companyID, vacancyID, studentID, req.Message)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, created)
+}
+
+func (h *InvitationHandler) ListStudent(w http.ResponseWriter, r *http.Request) {
+	h.list(w, r, h.invitations.ListByStudent)
+}
+
+func (h *InvitationHandler) ListCompany(w http.ResponseWriter, r *http.Request) {
+	h.list(w, r, h.invitations.ListByCompany)
+}
+
+func (h *InvitationHandler) list(w http.ResponseWriter, r *http.Request, load func(ctx context.Context, userID common.UUID, page common.PageRequest) ([]invitation.Invitation, *common.Cursor, error)) {
+	userID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	page, err := pageFromRequest(r, h.cursors)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	items, next, err := load(r.Context(), userID, page)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	writePage(w, r, h.cursors, items, next)
+}
+
+func (h *InvitationHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	studentID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	invitationID, err := idFromPath(r, 2)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	accepted, created, err := h.invitations.Accept(r.Context(), invitationID, studentID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"invitation":  accepted,
+		"application": created,
+	})
+}
+
+func (h *InvitationHandler) Decline(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, h.invitations.Decline)
+}
+
+func (h *InvitationHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, h.invitations.Cancel)
+}
+
+func (h *InvitationHandler) respond(w http.ResponseWriter, r *http.Request, apply func(ctx context.Context, invitationID, userID common.UUID) (*invitation.Invitation, error)) {
+	userID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	invitationID, err := idFromPath(r, 2)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	updated, err := apply(r.Context(), invitationID, userID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, updated)
+}
@@ -1,27 +1,32 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
-	"strconv"
 	"time"
 
 	"profzom/internal/app"
 	"profzom/internal/common"
 	"profzom/internal/http/middleware"
 	"profzom/internal/http/response"
+	"profzom/internal/security"
 )
 
 type MessageHandler struct {
 	messages *app.MessageService
 	limiter  *middleware.RateLimiter
+	cursors  *security.CursorSigner
 }
 
-func NewMessageHandler(messages *app.MessageService, limiter *middleware.RateLimiter) *MessageHandler {
-	return &MessageHandler{messages: messages, limiter: limiter}
+func NewMessageHandler(messages *app.MessageService, limiter *middleware.RateLimiter, cursors *security.CursorSigner) *MessageHandler {
+	return &MessageHandler{messages: messages, limiter: limiter, cursors: cursors}
 }
 
 type messageRequest struct {
-	Body string `json:"body"`
+	Body          string   `json:"body"`
+	AttachmentIDs []string `json:"attachment_ids"`
 }
 
 func (h *MessageHandler) Send(w http.ResponseWriter, r *http.Request) {
@@ -40,6 +45,15 @@ func (h *MessageHandler) Send(w http.ResponseWriter, r *http.Request) {
 		response.Error(w, err)
 		return
 	}
+	attachmentIDs := make([]common.UUID, 0, len(req.AttachmentIDs))
+	for _, value := range req.AttachmentIDs {
+		id, err := common.ParseUUID(value)
+		if err != nil {
+			response.Error(w, common.NewValidationError("invalid attachment_ids", map[string]string{"attachment_ids": "invalid uuid"}))
+			return
+		}
+		attachmentIDs = append(attachmentIDs, id)
+	}
 	if h.limiter != nil {
 		key := "msg:" + applicationID.String() + ":" + userID.String()
 		if !h.limiter.Allow(key, 1, 2*time.Second) {
@@ -47,7 +61,7 @@ func (h *MessageHandler) Send(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
-	created, err := h.messages.Send(r.Context(), applicationID, userID, req.Body)
+	created, err := h.messages.SendWithAttachments(r.Context(), applicationID, userID, req.Body, attachmentIDs)
 	if err != nil {
 		response.Error(w, err)
 		return
@@ -66,20 +80,52 @@ func (h *MessageHandler) List(w http.ResponseWriter, r *http.Request) {
 		response.Error(w, err)
 		return
 	}
-	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
-	if err != nil || limit <= 0 {
-		response.Error(w, common.NewValidationError("limit is required", map[string]string{"limit": "limit must be > 0"}))
+	page, err := pageFromRequest(r, h.cursors)
+	if err != nil {
+		response.Error(w, err)
 		return
 	}
-	offset, err := strconv.Atoi(r.URL.Query().Get("offset"))
-	if err != nil || offset < 0 {
-		response.Error(w, common.NewValidationError("offset is required", map[string]string{"offset": "offset must be >= 0"}))
+	items, next, err := h.messages.List(r.Context(), applicationID, userID, page)
+	if err != nil {
+		response.Error(w, err)
 		return
 	}
-	items, err := h.messages.List(r.Context(), applicationID, userID, limit, offset)
+	writePage(w, r, h.cursors, items, next)
+}
+
+type markReadRequest struct {
+	MessageID string `json:"message_id"`
+}
+
+// MarkRead принимает необязательный message_id; без тела переписка отмечается прочитанной целиком.
+func (h *MessageHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	applicationID, err := idFromPath(r, 3)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	var req markReadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.Error(w, common.NewError(common.CodeValidation, "invalid request body", err))
+		return
+	}
+	var messageID common.UUID
+	if req.MessageID != "" {
+		messageID, err = common.ParseUUID(req.MessageID)
+		if err != nil {
+			response.Error(w, common.NewValidationError("invalid message_id", map[string]string{"message_id": "invalid uuid"}))
+			return
+		}
+	}
+	state, err := h.messages.MarkRead(r.Context(), applicationID, userID, messageID)
 	if err != nil {
 		response.Error(w, err)
 		return
 	}
-	response.JSON(w, http.StatusOK, items)
+	response.JSON(w, http.StatusOK, state)
 }
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"profzom/internal/app"
+	"profzom/internal/common"
+	"profzom/internal/domain/moderation"
+	"profzom/internal/http/middleware"
+	"profzom/internal/http/response"
+	"profzom/internal/security"
+)
+
+// moderationReviewRequest — решение модератора. UpdatedAt — updated_at записи из очереди, которую он просматривал.
+type moderationReviewRequest struct {
+	Reason    string    `json:"reason"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ModerationHandler struct {
+	moderation *app.ModerationService
+	cursors    *security.CursorSigner
+}
+
+func NewModerationHandler(moderation *app.ModerationService, cursors *security.CursorSigner) *ModerationHandler {
+	return &ModerationHandler{moderation: moderation, cursors: cursors}
+}
+
+func (h *ModerationHandler) List(w http.ResponseWriter, r *http.Request) {
+	page, err := pageFromRequest(r, h.cursors)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	status := moderation.Status(strings.ToLower(strings.TrimSpace(r.URL.Query().Get("status"))))
+	contentType := moderation.ContentType(strings.ToLower(strings.TrimSpace(r.URL.Query().Get("type"))))
+	items, next, err := h.moderation.ListQueue(r.Context(), status, contentType, page)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	writePage(w, r, h.cursors, items, next)
+}
+
+func (h *ModerationHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	itemID, err := idFromPath(r, 2)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	var req moderationReviewRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
+		return
+	}
+	reviewed, err := h.moderation.Approve(r.Context(), adminID, itemID, req.UpdatedAt)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, reviewed)
+}
+
+func (h *ModerationHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	itemID, err := idFromPath(r, 2)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	var req moderationReviewRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
+		return
+	}
+	reviewed, err := h.moderation.Reject(r.Context(), adminID, itemID, req.UpdatedAt, req.Reason)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, reviewed)
+}
+
+func (h *ModerationHandler) ListReports(w http.ResponseWriter, r *http.Request) {
+	page, err := pageFromRequest(r, h.cursors)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	query := moderation.ReportQuery{
+		Status:     moderation.ReportStatus(strings.ToLower(strings.TrimSpace(r.URL.Query().Get("status")))),
+		TargetType: moderation.ReportTarget(strings.ToLower(strings.TrimSpace(r.URL.Query().Get("target_type")))),
+		Limit:      page.Limit,
+		After:      page.After,
+	}
+	if query.TargetUserID, err = optionalUUIDQuery(r, "target_user_id"); err != nil {
+		response.Error(w, err)
+		return
+	}
+	items, next, err := h.moderation.ListReports(r.Context(), query)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	writePage(w, r, h.cursors, items, next)
+}
+
+func (h *ModerationHandler) ResolveReport(w http.ResponseWriter, r *http.Request) {
+	h.closeReport(w, r, h.moderation.ResolveReport)
+}
+
+func (h *ModerationHandler) DismissReport(w http.ResponseWriter, r *http.Request) {
+	h.closeReport(w, r, h.moderation.DismissReport)
+}
+
+func (h *ModerationHandler) closeReport(w http.ResponseWriter, r *http.Request, action func(ctx context.Context, adminID, reportID common.UUID, resolution string) (*moderation.Report, error)) {
+	adminID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	reportID, err := idFromPath(r, 2)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	var req adminReasonRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
+		return
+	}
+	closed, err := action(r.Context(), adminID, reportID, req.Reason)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, closed)
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"profzom/internal/app"
+	"profzom/internal/common"
+	"profzom/internal/http/middleware"
+	"profzom/internal/http/response"
+	"profzom/internal/security"
+)
+
+type NotificationHandler struct {
+	notifications *app.NotificationService
+	cursors       *security.CursorSigner
+}
+
+func NewNotificationHandler(notifications *app.NotificationService, cursors *security.CursorSigner) *NotificationHandler {
+	return &NotificationHandler{notifications: notifications, cursors: cursors}
+}
+
+func (h *NotificationHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	page, err := pageFromRequest(r, h.cursors)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	unreadOnly := false
+	if value := r.URL.Query().Get("unread"); value != "" {
+		unreadOnly, err = strconv.ParseBool(value)
+		if err != nil {
+			response.Error(w, common.NewValidationError("invalid unread", map[string]string{"unread": "unread must be true or false"}))
+			return
+		}
+	}
+	items, next, err := h.notifications.List(r.Context(), userID, unreadOnly, page)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	writePage(w, r, h.cursors, items, next)
+}
+
+func (h *NotificationHandler) UnreadCount(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	count, err := h.notifications.UnreadCount(r.Context(), userID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]int{"unread": count})
+}
+
+func (h *NotificationHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	notificationID, err := idFromPath(r, 2)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	if err := h.notifications.MarkRead(r.Context(), userID, notificationID); err != nil {
+		response.Error(w, err)
+		return
+	}
+	response.JSON(w, http.StatusNoContent, nil)
+}
+
+func (h *NotificationHandler) MarkAllRead(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	marked, err := h.notifications.MarkAllRead(r.Context(), userID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]int{"marked": marked})
+}
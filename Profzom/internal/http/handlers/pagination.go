@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"profzom/internal/common"
+	"profzom/internal/http/response"
+	"profzom/internal/security"
+)
+
+type pageResponse[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+func pageFromRequest(r *http.Request, cursors *security.CursorSigner) (common.PageRequest, error) {
+	var page common.PageRequest
+	query := r.URL.Query()
+	if value := strings.TrimSpace(query.Get("limit")); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			return page, common.NewValidationError("invalid limit", map[string]string{"limit": "limit must be > 0"})
+		}
+		page.Limit = limit
+	}
+	if value := strings.TrimSpace(query.Get("cursor")); value != "" {
+		cursor, err := cursors.Decode(value)
+		if err != nil {
+			return page, common.NewValidationError("invalid cursor", map[string]string{"cursor": "invalid cursor"})
+		}
+		if cursor.Scope != cursorScope(r) {
+			return page, common.NewValidationError("invalid cursor", map[string]string{"cursor": "cursor was issued for another query"})
+		}
+		page.After = cursor
+	}
+	return page, nil
+}
+
+// cursorScope — отпечаток пути и параметров запроса без limit и cursor. Курсор, выданный для одной
+// сортировки или одного набора фильтров, при повторе с другими пропустил бы или повторил записи.
+func cursorScope(r *http.Request) string {
+	params := url.Values{}
+	for key, values := range r.URL.Query() {
+		switch key {
+		case "limit", "cursor", "access_token":
+			continue
+		}
+		params[key] = values
+	}
+	sum := sha256.Sum256([]byte(r.URL.Path + "?" + params.Encode()))
+	return hex.EncodeToString(sum[:16])
+}
+
+// encodeCursor подписывает курсор следующей страницы, привязывая его к текущему запросу.
+func encodeCursor(r *http.Request, cursors *security.CursorSigner, next common.Cursor) (string, error) {
+	next.Scope = cursorScope(r)
+	return cursors.Encode(next)
+}
+
+func writePage[T any](w http.ResponseWriter, r *http.Request, cursors *security.CursorSigner, items []T, next *common.Cursor) {
+	payload := pageResponse[T]{Items: items}
+	if payload.Items == nil {
+		payload.Items = []T{}
+	}
+	if next != nil {
+		encoded, err := encodeCursor(r, cursors, *next)
+		if err != nil {
+			response.Error(w, common.NewError(common.CodeInternal, "failed to encode cursor", err))
+			return
+		}
+		payload.NextCursor = encoded
+	}
+	response.JSON(w, http.StatusOK, payload)
+}
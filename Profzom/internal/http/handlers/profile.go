@@ -28,6 +28,10 @@ type studentProfileRequest struct {
     Specialty  string   `json:"specialty"`
     Skills     []string `json:"skills"`
     About      string   `json:"about"`
+    // если visible_to_companies не передан, сохраняется текущее значение
+    VisibleToCompanies *bool `json:"visible_to_companies"`
+    // resume заменяет резюме целиком; если поле не передано, резюме не меняется
+    Resume *profile.Resume `json:"resume"`
 }
 
 type companyProfileRequest struct {
@@ -50,9 +54,15 @@ func (h *ProfileHandler) GetStudent(w http.ResponseWriter, r *http.Request) {
         response.Error(w, err)
         return
     }
+    resume, err := h.profiles.GetResume(r.Context(), userID)
+    if err != nil {
+        response.Error(w, err)
+        return
+    }
     response.JSON(w, http.StatusOK, map[string]interface{}{
         "profile":    profile,
-        "completion": app.StudentCompletion(*profile),
+        "resume":     resume,
+        "completion": app.StudentCompletion(*profile, *resume),
     })
 }
 
@@ -71,22 +81,33 @@ func (h *ProfileHandler) UpsertStudent(w http.ResponseWriter, r *http.Request) {
         response.Error(w, err)
         return
     }
-    updated, err := h.profiles.UpsertStudent(r.Context(), profile.StudentProfile{
-        UserID:     userID,
-        Name:       req.Name,
-        University: req.University,
-        Course:     req.Course,
-        Specialty:  req.Specialty,
-        Skills:     req.Skills,
-        About:      req.About,
-    })
+    visible := false
+    if req.VisibleToCompanies != nil {
+        visible = *req.VisibleToCompanies
+    } else if current, err := h.profiles.GetStudent(r.Context(), userID); err == nil {
+        visible = current.VisibleToCompanies
+    } else if !common.Is(err, common.CodeNotFound) {
+        response.Error(w, err)
+        return
+    }
+    updated, resume, err := h.profiles.UpsertStudent(r.Context(), profile.StudentProfile{
+        UserID:             userID,
+        Name:               req.Name,
+        University:         req.University,
+        Course:             req.Course,
+        Specialty:          req.Specialty,
+        Skills:             req.Skills,
+        About:              req.About,
+        VisibleToCompanies: visible,
+    }, req.Resume)
     if err != nil {
         response.Error(w, err)
         return
     }
     response.JSON(w, http.StatusOK, map[string]interface{}{
         "profile":    updated,
-        "completion": app.StudentCompletion(*updated),
+        "resume":     resume,
+        "completion": app.StudentCompletion(*updated, *resume),
     })
 }
 
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"net/http"
+
+	"profzom/internal/app"
+	"profzom/internal/http/middleware"
+	"profzom/internal/http/response"
+)
+
+type RecommendationHandler struct {
+	recommendations *app.RecommendationService
+}
+
+func NewRecommendationHandler(recommendations *app.RecommendationService) *RecommendationHandler {
+	return &RecommendationHandler{recommendations: recommendations}
+}
+
+func (h *RecommendationHandler) ListStudent(w http.ResponseWriter, r *http.Request) {
+	studentID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	limit, err := optionalIntQuery(r, "limit")
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	if limit == nil {
+		limit = new(int)
+	}
+	items, err := h.recommendations.ForStudent(r.Context(), studentID, *limit)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]interface{}{"items": items})
+}
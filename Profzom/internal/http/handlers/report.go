@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"profzom/internal/app"
+	"profzom/internal/common"
+	"profzom/internal/domain/moderation"
+	"profzom/internal/http/middleware"
+	"profzom/internal/http/response"
+)
+
+type ReportHandler struct {
+	reports *app.ReportService
+	limiter *middleware.RateLimiter
+}
+
+func NewReportHandler(reports *app.ReportService, limiter *middleware.RateLimiter) *ReportHandler {
+	return &ReportHandler{reports: reports, limiter: limiter}
+}
+
+type reportRequest struct {
+	TargetType string `json:"target_type"`
+	TargetID   string `json:"target_id"`
+	Reason     string `json:"reason"`
+	Comment    string `json:"comment"`
+}
+
+func (h *ReportHandler) Create(w http.ResponseWriter, r *http.Request) {
+	reporterID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	var req reportRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
+		return
+	}
+	targetID, err := common.ParseUUID(strings.TrimSpace(req.TargetID))
+	if err != nil {
+		response.Error(w, common.NewValidationError("invalid request", map[string]string{"target_id": "invalid uuid"}))
+		return
+	}
+	if h.limiter != nil {
+		if !h.limiter.Allow("report:"+reporterID.String(), 20, time.Hour) {
+			response.Error(w, common.NewError(common.CodeRateLimited, "report rate limit exceeded", nil))
+			return
+		}
+	}
+	targetType := moderation.ReportTarget(strings.ToLower(strings.TrimSpace(req.TargetType)))
+	reason := moderation.ReportReason(strings.ToLower(strings.TrimSpace(req.Reason)))
+	created, err := h.reports.Create(r.Context(), reporterID, targetType, targetID, reason, req.Comment)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, created)
+}
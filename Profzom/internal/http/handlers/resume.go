@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"profzom/internal/app"
+	"profzom/internal/common"
+	"profzom/internal/http/middleware"
+	"profzom/internal/http/response"
+)
+
+type ResumeHandler struct {
+	resumes *app.ResumeService
+}
+
+func NewResumeHandler(resumes *app.ResumeService) *ResumeHandler {
+	return &ResumeHandler{resumes: resumes}
+}
+
+// ExportOwn отдаёт студенту его резюме: /students/profile/resume.pdf или .html.
+func (h *ResumeHandler) ExportOwn(w http.ResponseWriter, r *http.Request) {
+	studentID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	format := resumeFormatFromPath(r)
+	doc, err := h.resumes.ExportOwn(r.Context(), studentID, format)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	writeResume(w, format, doc)
+}
+
+// ExportForApplication отдаёт резюме студента по отклику: /applications/{id}/resume.pdf или .html.
+func (h *ResumeHandler) ExportForApplication(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	applicationID, err := idFromPath(r, 2)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	format := resumeFormatFromPath(r)
+	doc, err := h.resumes.ExportForApplication(r.Context(), applicationID, userID, format)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	writeResume(w, format, doc)
+}
+
+func resumeFormatFromPath(r *http.Request) app.ResumeFormat {
+	path := strings.TrimRight(r.URL.Path, "/")
+	return app.ResumeFormat(path[strings.LastIndex(path, ".")+1:])
+}
+
+func writeResume(w http.ResponseWriter, format app.ResumeFormat, doc []byte) {
+	switch format {
+	case app.ResumeFormatPDF:
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Disposition", `attachment; filename="resume.pdf"`)
+	case app.ResumeFormatHTML:
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		// HTML открывается во вкладке для печати; скрипты в нём не нужны, поэтому запрещаем их политикой
+		w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'")
+	default:
+		response.Error(w, common.NewError(common.CodeValidation, "format must be pdf or html", nil))
+		return
+	}
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(doc)
+}
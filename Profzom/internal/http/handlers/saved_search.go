@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"net/http"
+
+	"profzom/internal/app"
+	"profzom/internal/domain/savedsearch"
+	"profzom/internal/domain/vacancy"
+	"profzom/internal/http/middleware"
+	"profzom/internal/http/response"
+)
+
+type SavedSearchHandler struct {
+	searches *app.SavedSearchService
+}
+
+func NewSavedSearchHandler(searches *app.SavedSearchService) *SavedSearchHandler {
+	return &SavedSearchHandler{searches: searches}
+}
+
+type savedSearchRequest struct {
+	Name       string `json:"name"`
+	Text       string `json:"q"`
+	Type       string `json:"type"`
+	Location   string `json:"location"`
+	SalaryFrom *int   `json:"salary_from"`
+	SalaryTo   *int   `json:"salary_to"`
+	Period     string `json:"period"`
+}
+
+func (h *SavedSearchHandler) Create(w http.ResponseWriter, r *http.Request) {
+	studentID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	var req savedSearchRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
+		return
+	}
+	created, err := h.searches.Create(r.Context(), studentID, req.Name, savedsearch.Query{
+		Text:       req.Text,
+		Type:       req.Type,
+		Location:   req.Location,
+		SalaryFrom: req.SalaryFrom,
+		SalaryTo:   req.SalaryTo,
+		Period:     vacancy.SalaryPeriod(req.Period),
+	})
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, created)
+}
+
+func (h *SavedSearchHandler) List(w http.ResponseWriter, r *http.Request) {
+	studentID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	items, err := h.searches.List(r.Context(), studentID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	if items == nil {
+		items = []savedsearch.SavedSearch{}
+	}
+	response.JSON(w, http.StatusOK, map[string]interface{}{"items": items})
+}
+
+func (h *SavedSearchHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	studentID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	searchID, err := idFromPath(r, 1)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	if err := h.searches.Delete(r.Context(), studentID, searchID); err != nil {
+		response.Error(w, err)
+		return
+	}
+	response.JSON(w, http.StatusNoContent, nil)
+}
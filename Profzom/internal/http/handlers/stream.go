@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"profzom/internal/http/middleware"
+	"profzom/internal/http/response"
+	"profzom/internal/realtime"
+)
+
+const streamHeartbeat = 25 * time.Second
+
+// StreamHandler отдаёт события пользователя через Server-Sent Events.
+type StreamHandler struct {
+	hub *realtime.Hub
+}
+
+func NewStreamHandler(hub *realtime.Hub) *StreamHandler {
+	return &StreamHandler{hub: hub}
+}
+
+func (h *StreamHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	controller := http.NewResponseController(w)
+	// Соединение живёт дольше WriteTimeout сервера, поэтому дедлайн записи снимается только для этого запроса.
+	if err := controller.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		response.Error(w, err)
+		return
+	}
+	events, cancel := h.hub.Subscribe(userID)
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if _, err := fmt.Fprint(w, "retry: 3000\n\n"); err != nil {
+		return
+	}
+	if err := controller.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			data := event.Data
+			if len(data) == 0 {
+				data = []byte("{}")
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+				return
+			}
+		}
+		if err := controller.Flush(); err != nil {
+			return
+		}
+	}
+}
@@ -1,33 +1,49 @@
 package handlers
 
 import (
+    "context"
     "net/http"
-    "strconv"
+    "time"
 
     "profzom/internal/app"
     "profzom/internal/common"
+    "profzom/internal/domain/user"
     "profzom/internal/domain/vacancy"
     "profzom/internal/http/middleware"
     "profzom/internal/http/response"
+    "profzom/internal/security"
 )
 
 type VacancyHandler struct {
     vacancies *app.VacancyService
+    bookmarks *app.BookmarkService
+    cursors   *security.CursorSigner
 }
 
-func NewVacancyHandler(vacancies *app.VacancyService) *VacancyHandler {
-    return &VacancyHandler{vacancies: vacancies}
+func NewVacancyHandler(vacancies *app.VacancyService, bookmarks *app.BookmarkService, cursors *security.CursorSigner) *VacancyHandler {
+    return &VacancyHandler{vacancies: vacancies, bookmarks: bookmarks, cursors: cursors}
+}
+
+// vacancyResponse дополняет вакансию флагом is_saved; он заполняется только для авторизованного студента.
+type vacancyResponse struct {
+    vacancy.Vacancy
+    IsSaved *bool `json:"is_saved,omitempty"`
 }
 
 type vacancyRequest struct {
-    Title        string   `json:"title"`
-    Type         string   `json:"type"`
-    Description  string   `json:"description"`
-    Requirements []string `json:"requirements"`
-    Conditions   []string `json:"conditions"`
-    Salary       string   `json:"salary"`
-    Location     string   `json:"location"`
-    Status       string   `json:"status"`
+    Title        string     `json:"title"`
+    Type         string     `json:"type"`
+    Description  string     `json:"description"`
+    Requirements []string   `json:"requirements"`
+    Conditions   []string   `json:"conditions"`
+    SalaryMin    *int       `json:"salary_min"`
+    SalaryMax    *int       `json:"salary_max"`
+    Currency     string     `json:"currency"`
+    Period       string     `json:"period"`
+    IsNegotiable bool       `json:"is_negotiable"`
+    Location     string     `json:"location"`
+    Status       string     `json:"status"`
+    ExpiresAt    *time.Time `json:"expires_at"`
 }
 
 func (h *VacancyHandler) Create(w http.ResponseWriter, r *http.Request) {
@@ -52,9 +68,14 @@ func (h *VacancyHandler) Create(w http.ResponseWriter, r *http.Request) {
         Description:  req.Description,
         Requirements: req.Requirements,
         Conditions:   req.Conditions,
-        Salary:       req.Salary,
+        SalaryMin:    req.SalaryMin,
+        SalaryMax:    req.SalaryMax,
+        Currency:     req.Currency,
+        Period:       vacancy.SalaryPeriod(req.Period),
+        IsNegotiable: req.IsNegotiable,
         Location:     req.Location,
         Status:       vacancy.Status(req.Status),
+        ExpiresAt:    req.ExpiresAt,
     })
     if err != nil {
         response.Error(w, err)
@@ -91,9 +112,13 @@ func (h *VacancyHandler) Update(w http.ResponseWriter, r *http.Request) {
         Description:  req.Description,
         Requirements: req.Requirements,
         Conditions:   req.Conditions,
-        Salary:       req.Salary,
+        SalaryMin:    req.SalaryMin,
+        SalaryMax:    req.SalaryMax,
+        Currency:     req.Currency,
+        Period:       vacancy.SalaryPeriod(req.Period),
+        IsNegotiable: req.IsNegotiable,
         Location:     req.Location,
-        Status:       vacancy.Status(req.Status),
+        ExpiresAt:    req.ExpiresAt,
     })
     if err != nil {
         response.Error(w, err)
@@ -103,6 +128,22 @@ func (h *VacancyHandler) Update(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *VacancyHandler) Publish(w http.ResponseWriter, r *http.Request) {
+    h.transition(w, r, h.vacancies.Publish)
+}
+
+func (h *VacancyHandler) Close(w http.ResponseWriter, r *http.Request) {
+    h.transition(w, r, h.vacancies.Close)
+}
+
+func (h *VacancyHandler) Reopen(w http.ResponseWriter, r *http.Request) {
+    h.transition(w, r, h.vacancies.Reopen)
+}
+
+func (h *VacancyHandler) Archive(w http.ResponseWriter, r *http.Request) {
+    h.transition(w, r, h.vacancies.Archive)
+}
+
+func (h *VacancyHandler) transition(w http.ResponseWriter, r *http.Request, apply func(ctx context.Context, companyID, vacancyID common.UUID) (*vacancy.Vacancy, error)) {
     userID, ok := middleware.UserIDFromContext(r.Context())
     if !ok {
         response.Error(w, errUnauthorized())
@@ -113,7 +154,7 @@ func (h *VacancyHandler) Publish(w http.ResponseWriter, r *http.Request) {
         response.Error(w, err)
         return
     }
-    updated, err := h.vacancies.Publish(r.Context(), userID, vacancyID)
+    updated, err := apply(r.Context(), userID, vacancyID)
     if err != nil {
         response.Error(w, err)
         return
@@ -122,24 +163,43 @@ func (h *VacancyHandler) Publish(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *VacancyHandler) ListPublished(w http.ResponseWriter, r *http.Request) {
-    limit := 20
-    offset := 0
-    if value := r.URL.Query().Get("limit"); value != "" {
-        if parsed, err := strconv.Atoi(value); err == nil {
-            limit = parsed
-        }
-    }
-    if value := r.URL.Query().Get("offset"); value != "" {
-        if parsed, err := strconv.Atoi(value); err == nil {
-            offset = parsed
-        }
-    }
-    items, err := h.vacancies.ListPublished(r.Context(), limit, offset)
+    page, err := pageFromRequest(r, h.cursors)
+    if err != nil {
+        response.Error(w, err)
+        return
+    }
+    query := r.URL.Query()
+    salaryFrom, err := optionalIntQuery(r, "salary_from")
+    if err != nil {
+        response.Error(w, err)
+        return
+    }
+    salaryTo, err := optionalIntQuery(r, "salary_to")
+    if err != nil {
+        response.Error(w, err)
+        return
+    }
+    items, next, err := h.vacancies.Search(r.Context(), vacancy.SearchQuery{
+        Text:       query.Get("q"),
+        Type:       query.Get("type"),
+        Location:   query.Get("location"),
+        SalaryFrom: salaryFrom,
+        SalaryTo:   salaryTo,
+        Period:     vacancy.SalaryPeriod(query.Get("period")),
+        Sort:       vacancy.SortOrder(query.Get("sort")),
+        Limit:      page.Limit,
+        After:      page.After,
+    })
+    if err != nil {
+        response.Error(w, err)
+        return
+    }
+    views, err := h.withSavedFlags(r, items)
     if err != nil {
         response.Error(w, err)
         return
     }
-    response.JSON(w, http.StatusOK, items)
+    writePage(w, r, h.cursors, views, next)
 }
 
 func (h *VacancyHandler) Get(w http.ResponseWriter, r *http.Request) {
@@ -148,10 +208,40 @@ func (h *VacancyHandler) Get(w http.ResponseWriter, r *http.Request) {
         response.Error(w, err)
         return
     }
-    item, err := h.vacancies.Get(r.Context(), vacancyID)
+    viewerID, _ := middleware.UserIDFromContext(r.Context())
+    item, err := h.vacancies.Get(r.Context(), vacancyID, viewerID, middleware.HasRole(r.Context(), user.RoleAdmin))
+    if err != nil {
+        response.Error(w, err)
+        return
+    }
+    views, err := h.withSavedFlags(r, []vacancy.Vacancy{*item})
     if err != nil {
         response.Error(w, err)
         return
     }
-    response.JSON(w, http.StatusOK, item)
+    response.JSON(w, http.StatusOK, views[0])
+}
+
+func (h *VacancyHandler) withSavedFlags(r *http.Request, items []vacancy.Vacancy) ([]vacancyResponse, error) {
+    views := make([]vacancyResponse, 0, len(items))
+    for _, item := range items {
+        views = append(views, vacancyResponse{Vacancy: item})
+    }
+    studentID, ok := middleware.UserIDFromContext(r.Context())
+    if !ok || !middleware.HasRole(r.Context(), user.RoleStudent) || len(items) == 0 {
+        return views, nil
+    }
+    ids := make([]common.UUID, 0, len(items))
+    for _, item := range items {
+        ids = append(ids, item.ID)
+    }
+    saved, err := h.bookmarks.SavedIDs(r.Context(), studentID, ids)
+    if err != nil {
+        return nil, err
+    }
+    for i := range views {
+        isSaved := saved[views[i].ID]
+        views[i].IsSaved = &isSaved
+    }
+    return views, nil
 }
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"profzom/internal/app"
+	"profzom/internal/domain/profile"
+	"profzom/internal/http/middleware"
+	"profzom/internal/http/response"
+	"profzom/internal/security"
+)
+
+type VerificationHandler struct {
+	verifications *app.CompanyVerificationService
+	cursors       *security.CursorSigner
+}
+
+func NewVerificationHandler(verifications *app.CompanyVerificationService, cursors *security.CursorSigner) *VerificationHandler {
+	return &VerificationHandler{verifications: verifications, cursors: cursors}
+}
+
+type verificationRequest struct {
+	LegalName string `json:"legal_name"`
+	INN       string `json:"inn"`
+	OGRN      string `json:"ogrn"`
+}
+
+// verificationReviewRequest — решение по заявке. UpdatedAt — updated_at заявки, которую просматривал модератор.
+type verificationReviewRequest struct {
+	Reason    string    `json:"reason"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (h *VerificationHandler) Get(w http.ResponseWriter, r *http.Request) {
+	companyID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	current, err := h.verifications.Get(r.Context(), companyID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, current)
+}
+
+func (h *VerificationHandler) Submit(w http.ResponseWriter, r *http.Request) {
+	companyID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	var req verificationRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
+		return
+	}
+	submitted, err := h.verifications.Submit(r.Context(), companyID, app.VerificationRequest{LegalName: req.LegalName, INN: req.INN, OGRN: req.OGRN})
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, submitted)
+}
+
+func (h *VerificationHandler) List(w http.ResponseWriter, r *http.Request) {
+	page, err := pageFromRequest(r, h.cursors)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	status := profile.VerificationStatus(strings.ToLower(strings.TrimSpace(r.URL.Query().Get("status"))))
+	items, next, err := h.verifications.ListForReview(r.Context(), status, page)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	writePage(w, r, h.cursors, items, next)
+}
+
+func (h *VerificationHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	reviewerID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	companyID, err := idFromPath(r, 2)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	var req verificationReviewRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
+		return
+	}
+	reviewed, err := h.verifications.Approve(r.Context(), reviewerID, companyID, req.UpdatedAt)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, reviewed)
+}
+
+func (h *VerificationHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	reviewerID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.Error(w, errUnauthorized())
+		return
+	}
+	companyID, err := idFromPath(r, 2)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	var req verificationReviewRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, err)
+		return
+	}
+	reviewed, err := h.verifications.Reject(r.Context(), reviewerID, companyID, req.UpdatedAt, req.Reason)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, reviewed)
+}
@@ -19,13 +19,22 @@ const (
 )
 
 type AuthMiddleware struct {
-	jwt *security.JWTProvider
+	jwt          *security.JWTProvider
+	accountCheck func(ctx context.Context, userID common.UUID) ([]user.Role, error)
 }
 
 func NewAuthMiddleware(jwt *security.JWTProvider) *AuthMiddleware {
 	return &AuthMiddleware{jwt: jwt}
 }
 
+// WithAccountCheck добавляет проверку аккаунта после разбора токена: заблокированный пользователь
+// теряет доступ сразу, не дожидаясь истечения access-токена. Роли в контексте берутся из результата check,
+// а не из claims, поэтому отзыв роли тоже действует сразу.
+func (m *AuthMiddleware) WithAccountCheck(check func(ctx context.Context, userID common.UUID) ([]user.Role, error)) *AuthMiddleware {
+	m.accountCheck = check
+	return m
+}
+
 func (m *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		authHeader := r.Header.Get("Authorization")
@@ -33,44 +42,85 @@ func (m *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
 			response.Error(w, common.NewError(common.CodeUnauthorized, "missing authorization header", nil))
 			return
 		}
-		parts := strings.SplitN(authHeader, " ", 2)
-		if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
-			response.Error(w, common.NewError(common.CodeUnauthorized, "invalid authorization header", nil))
+		ctx, err := m.authenticate(r.Context(), authHeader)
+		if err != nil {
+			response.Error(w, err)
 			return
 		}
-		claims, err := m.jwt.Parse(parts[1])
-		if err != nil {
-			response.Error(w, common.NewError(common.CodeUnauthorized, "invalid token", err))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Optional пропускает анонимные запросы, а при валидном токене кладёт пользователя в контекст.
+// Невалидный токен тоже не блокирует публичный эндпоинт — запрос обрабатывается как анонимный.
+func (m *AuthMiddleware) Optional(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeader := r.Header.Get("Authorization")
+		if authHeader == "" {
+			next.ServeHTTP(w, r)
 			return
 		}
-		userID, err := common.ParseUUID(claims.UserID)
+		ctx, err := m.authenticate(r.Context(), authHeader)
 		if err != nil {
-			response.Error(w, common.NewError(common.CodeUnauthorized, "invalid user id", err))
+			next.ServeHTTP(w, r)
 			return
 		}
-		roles := make([]user.Role, 0, len(claims.Roles))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// TokenFromQuery переносит токен из query-параметра в заголовок Authorization. Нужен только для SSE:
+// браузерный EventSource не умеет передавать заголовки.
+func TokenFromQuery(param string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token := r.URL.Query().Get(param); token != "" && r.Header.Get("Authorization") == "" {
+				r.Header.Set("Authorization", "Bearer "+token)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func (m *AuthMiddleware) authenticate(ctx context.Context, authHeader string) (context.Context, error) {
+	parts := strings.SplitN(authHeader, " ", 2)
+	if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+		return nil, common.NewError(common.CodeUnauthorized, "invalid authorization header", nil)
+	}
+	claims, err := m.jwt.Parse(parts[1])
+	if err != nil {
+		return nil, common.NewError(common.CodeUnauthorized, "invalid token", err)
+	}
+	userID, err := common.ParseUUID(claims.UserID)
+	if err != nil {
+		return nil, common.NewError(common.CodeUnauthorized, "invalid user id", err)
+	}
+	var roles []user.Role
+	if m.accountCheck != nil {
+		roles, err = m.accountCheck(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		roles = make([]user.Role, 0, len(claims.Roles))
 		for _, role := range claims.Roles {
 			roles = append(roles, user.Role(role))
 		}
-		ctx := context.WithValue(r.Context(), ContextUserIDKey, userID)
-		ctx = context.WithValue(ctx, ContextRolesKey, roles)
-		next.ServeHTTP(w, r.WithContext(ctx))
-	})
+	}
+	ctx = context.WithValue(ctx, ContextUserIDKey, userID)
+	return context.WithValue(ctx, ContextRolesKey, roles), nil
 }
 
 func RequireRole(role user.Role) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			roles, ok := r.Context().Value(ContextRolesKey).([]user.Role)
-			if !ok {
+			if _, ok := r.Context().Value(ContextRolesKey).([]user.Role); !ok {
 				response.Error(w, common.NewError(common.CodeForbidden, "roles not found", nil))
 				return
 			}
-			for _, userRole := range roles {
-				if userRole == role {
-					next.ServeHTTP(w, r)
-					return
-				}
+			if HasRole(r.Context(), role) {
+				next.ServeHTTP(w, r)
+				return
 			}
 			response.Error(w, common.NewError(common.CodeForbidden, "insufficient role", nil))
 		})
@@ -81,3 +131,13 @@ func UserIDFromContext(ctx context.Context) (common.UUID, bool) {
 	id, ok := ctx.Value(ContextUserIDKey).(common.UUID)
 	return id, ok
 }
+
+func HasRole(ctx context.Context, role user.Role) bool {
+	roles, _ := ctx.Value(ContextRolesKey).([]user.Role)
+	for _, userRole := range roles {
+		if userRole == role {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,7 @@ package http
 
 import (
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
@@ -12,109 +13,258 @@ import (
 )
 
 type RouterDependencies struct {
-	AuthHandler        *handlers.AuthHandler
-	UserHandler        *handlers.UserHandler
-	ProfileHandler     *handlers.ProfileHandler
-	VacancyHandler     *handlers.VacancyHandler
-	ApplicationHandler *handlers.ApplicationHandler
-	MessageHandler     *handlers.MessageHandler
-	MetricsHandler     *handlers.MetricsHandler
-	AuthMiddleware     *httpmw.AuthMiddleware
-	Metrics            *metrics.Collector
-	RequestTimeout     time.Duration
+	AuthHandler           *handlers.AuthHandler
+	UserHandler           *handlers.UserHandler
+	ProfileHandler        *handlers.ProfileHandler
+	ResumeHandler         *handlers.ResumeHandler
+	VacancyHandler        *handlers.VacancyHandler
+	BookmarkHandler       *handlers.BookmarkHandler
+	SavedSearchHandler    *handlers.SavedSearchHandler
+	ApplicationHandler    *handlers.ApplicationHandler
+	MessageHandler        *handlers.MessageHandler
+	InterviewHandler      *handlers.InterviewHandler
+	RecommendationHandler *handlers.RecommendationHandler
+	CandidateHandler      *handlers.CandidateHandler
+	InvitationHandler     *handlers.InvitationHandler
+	VerificationHandler   *handlers.VerificationHandler
+	AdminHandler          *handlers.AdminHandler
+	ModerationHandler     *handlers.ModerationHandler
+	ReportHandler         *handlers.ReportHandler
+	BlockListHandler      *handlers.BlockListHandler
+	NotificationHandler   *handlers.NotificationHandler
+	StreamHandler         *handlers.StreamHandler
+	MetricsHandler        *handlers.MetricsHandler
+	AuthMiddleware        *httpmw.AuthMiddleware
+	Metrics               *metrics.Collector
+	RequestTimeout        time.Duration
+}
+
+// Route описывает один эндпоинт: метод, шаблон пути с параметрами вида {id},
+// требования к авторизации и дополнительные middleware конкретного маршрута.
+// OptionalAuth разбирает токен, если он передан, но пропускает и анонимные запросы.
+// Stream отключает таймаут запроса для долгоживущих потоковых ответов.
+// Transfer — передача файлов: общий таймаут тоже не применяется, handler сам продлевает дедлайны соединения.
+// MaxBody заменяет общий лимит тела запроса maxBodyBytes.
+type Route struct {
+	Method       string
+	Pattern      string
+	Handler      http.HandlerFunc
+	Auth         bool
+	OptionalAuth bool
+	Stream       bool
+	Transfer     bool
+	MaxBody      int64
+	Role         user.Role
+	Middleware   []func(http.Handler) http.Handler
+}
+
+type compiledRoute struct {
+	method   string
+	segments []string
+	handler  http.Handler
 }
 
 type Router struct {
-	deps RouterDependencies
+	deps    RouterDependencies
+	routes  []compiledRoute
+	handler http.Handler
 }
 
 const maxBodyBytes = 1 << 20
 
 func NewRouter(deps RouterDependencies) http.Handler {
-	return &Router{deps: deps}
+	router := &Router{deps: deps}
+	for _, route := range router.table() {
+		router.routes = append(router.routes, router.compile(route))
+	}
+	router.handler = httpmw.Chain(http.HandlerFunc(router.dispatch), httpmw.RequestID, httpmw.Logging, httpmw.Recover, httpmw.Metrics(deps.Metrics))
+	return router
 }
 
 func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	handler := httpmw.Chain(r.baseHandler(), httpmw.RequestID, httpmw.Logging, httpmw.BodyLimit(maxBodyBytes), httpmw.Recover, httpmw.Metrics(r.deps.Metrics), httpmw.Timeout(r.deps.RequestTimeout))
-	handler.ServeHTTP(w, req)
+	r.handler.ServeHTTP(w, req)
 }
 
-func (r *Router) baseHandler() http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-		path := req.URL.Path
+func (r *Router) table() []Route {
+	d := r.deps
+	return []Route{
+		{Method: http.MethodGet, Pattern: "/health", Handler: health},
+		{Method: http.MethodGet, Pattern: "/metrics", Handler: d.MetricsHandler.Get},
 
-		switch {
-		case req.Method == http.MethodGet && path == "/health":
-			w.WriteHeader(http.StatusOK)
-			_, _ = w.Write([]byte("ok"))
-			return
-		case req.Method == http.MethodGet && path == "/metrics":
-			r.deps.MetricsHandler.Get(w, req)
-			return
-		case req.Method == http.MethodPost && path == "/auth/request-code":
-			r.deps.AuthHandler.RequestOTPByTelegram(w, req)
-			return
-		case req.Method == http.MethodPost && path == "/auth/register":
-			r.deps.AuthHandler.Register(w, req)
-			return
-		case req.Method == http.MethodPost && path == "/auth/verify-code":
-			r.deps.AuthHandler.VerifyOTP(w, req)
-			return
-		case req.Method == http.MethodGet && path == "/vacancies":
-			r.deps.VacancyHandler.ListPublished(w, req)
-			return
-		case req.Method == http.MethodGet && strings.HasPrefix(path, "/vacancies/"):
-			r.deps.VacancyHandler.Get(w, req)
-			return
-		}
+		{Method: http.MethodPost, Pattern: "/auth/request-code", Handler: d.AuthHandler.RequestOTPByTelegram},
+		{Method: http.MethodPost, Pattern: "/auth/register", Handler: d.AuthHandler.Register},
+		{Method: http.MethodPost, Pattern: "/auth/verify-code", Handler: d.AuthHandler.VerifyOTP},
+		{Method: http.MethodPost, Pattern: "/auth/refresh", Handler: d.AuthHandler.Refresh},
+		{Method: http.MethodPost, Pattern: "/auth/logout", Handler: d.AuthHandler.Logout},
 
-		if strings.HasPrefix(path, "/companies") || strings.HasPrefix(path, "/students") || strings.HasPrefix(path, "/users") || strings.HasPrefix(path, "/vacancies") || strings.HasPrefix(path, "/applications") {
-			protected := r.deps.AuthMiddleware.Authenticate(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-				r.handleProtected(w, req)
-			}))
-			protected.ServeHTTP(w, req)
-			return
-		}
+		{Method: http.MethodPatch, Pattern: "/users/role", Handler: d.UserHandler.SetRole, Auth: true},
+		{Method: http.MethodGet, Pattern: "/users/blocked", Handler: d.BlockListHandler.List, Auth: true},
+		{Method: http.MethodPut, Pattern: "/users/blocked/{id}", Handler: d.BlockListHandler.Block, Auth: true},
+		{Method: http.MethodDelete, Pattern: "/users/blocked/{id}", Handler: d.BlockListHandler.Unblock, Auth: true},
 
-		http.NotFound(w, req)
-	})
-}
+		{Method: http.MethodPost, Pattern: "/reports", Handler: d.ReportHandler.Create, Auth: true},
+
+		{Method: http.MethodGet, Pattern: "/events/stream", Handler: d.StreamHandler.Stream, Auth: true, Stream: true},
+
+		{Method: http.MethodGet, Pattern: "/notifications", Handler: d.NotificationHandler.List, Auth: true},
+		{Method: http.MethodGet, Pattern: "/notifications/unread-count", Handler: d.NotificationHandler.UnreadCount, Auth: true},
+		{Method: http.MethodPost, Pattern: "/notifications/read-all", Handler: d.NotificationHandler.MarkAllRead, Auth: true},
+		{Method: http.MethodPost, Pattern: "/notifications/{id}/read", Handler: d.NotificationHandler.MarkRead, Auth: true},
+
+		{Method: http.MethodGet, Pattern: "/students/profile", Handler: d.ProfileHandler.GetStudent, Auth: true, Role: user.RoleStudent},
+		{Method: http.MethodPost, Pattern: "/students/profile", Handler: d.ProfileHandler.UpsertStudent, Auth: true, Role: user.RoleStudent},
+		{Method: http.MethodPut, Pattern: "/students/profile", Handler: d.ProfileHandler.UpsertStudent, Auth: true, Role: user.RoleStudent},
+		{Method: http.MethodGet, Pattern: "/students/profile/resume.pdf", Handler: d.ResumeHandler.ExportOwn, Auth: true, Role: user.RoleStudent},
+		{Method: http.MethodGet, Pattern: "/students/profile/resume.html", Handler: d.ResumeHandler.ExportOwn, Auth: true, Role: user.RoleStudent},
+		{Method: http.MethodGet, Pattern: "/students/applications", Handler: d.ApplicationHandler.ListStudent, Auth: true, Role: user.RoleStudent},
+		{Method: http.MethodGet, Pattern: "/students/invitations", Handler: d.InvitationHandler.ListStudent, Auth: true, Role: user.RoleStudent},
+		{Method: http.MethodGet, Pattern: "/students/saved-vacancies", Handler: d.BookmarkHandler.List, Auth: true, Role: user.RoleStudent},
+		{Method: http.MethodPut, Pattern: "/students/saved-vacancies/{id}", Handler: d.BookmarkHandler.Save, Auth: true, Role: user.RoleStudent},
+		{Method: http.MethodDelete, Pattern: "/students/saved-vacancies/{id}", Handler: d.BookmarkHandler.Remove, Auth: true, Role: user.RoleStudent},
+		{Method: http.MethodGet, Pattern: "/students/saved-searches", Handler: d.SavedSearchHandler.List, Auth: true, Role: user.RoleStudent},
+		{Method: http.MethodPost, Pattern: "/students/saved-searches", Handler: d.SavedSearchHandler.Create, Auth: true, Role: user.RoleStudent},
+		{Method: http.MethodDelete, Pattern: "/students/saved-searches/{id}", Handler: d.SavedSearchHandler.Delete, Auth: true, Role: user.RoleStudent},
+		{Method: http.MethodGet, Pattern: "/students/recommendations", Handler: d.RecommendationHandler.ListStudent, Auth: true, Role: user.RoleStudent},
+
+		{Method: http.MethodGet, Pattern: "/companies/profile", Handler: d.ProfileHandler.GetCompany, Auth: true, Role: user.RoleCompany},
+		{Method: http.MethodPost, Pattern: "/companies/profile", Handler: d.ProfileHandler.UpsertCompany, Auth: true, Role: user.RoleCompany},
+		{Method: http.MethodPut, Pattern: "/companies/profile", Handler: d.ProfileHandler.UpsertCompany, Auth: true, Role: user.RoleCompany},
+		{Method: http.MethodGet, Pattern: "/companies/applications", Handler: d.ApplicationHandler.ListCompany, Auth: true, Role: user.RoleCompany},
+		{Method: http.MethodGet, Pattern: "/companies/candidates", Handler: d.CandidateHandler.Search, Auth: true, Role: user.RoleCompany},
+		{Method: http.MethodGet, Pattern: "/companies/invitations", Handler: d.InvitationHandler.ListCompany, Auth: true, Role: user.RoleCompany},
+		{Method: http.MethodGet, Pattern: "/companies/verification", Handler: d.VerificationHandler.Get, Auth: true, Role: user.RoleCompany},
+		{Method: http.MethodPost, Pattern: "/companies/verification", Handler: d.VerificationHandler.Submit, Auth: true, Role: user.RoleCompany},
+
+		{Method: http.MethodGet, Pattern: "/admin/company-verifications", Handler: d.VerificationHandler.List, Auth: true, Role: user.RoleAdmin},
+		{Method: http.MethodPost, Pattern: "/admin/company-verifications/{id}/approve", Handler: d.VerificationHandler.Approve, Auth: true, Role: user.RoleAdmin},
+		{Method: http.MethodPost, Pattern: "/admin/company-verifications/{id}/reject", Handler: d.VerificationHandler.Reject, Auth: true, Role: user.RoleAdmin},
+		{Method: http.MethodGet, Pattern: "/admin/users", Handler: d.AdminHandler.ListUsers, Auth: true, Role: user.RoleAdmin},
+		{Method: http.MethodPost, Pattern: "/admin/users/{id}/block", Handler: d.AdminHandler.BlockUser, Auth: true, Role: user.RoleAdmin},
+		{Method: http.MethodPost, Pattern: "/admin/users/{id}/unblock", Handler: d.AdminHandler.UnblockUser, Auth: true, Role: user.RoleAdmin},
+		{Method: http.MethodPost, Pattern: "/admin/vacancies/{id}/unpublish", Handler: d.AdminHandler.UnpublishVacancy, Auth: true, Role: user.RoleAdmin},
+		{Method: http.MethodGet, Pattern: "/admin/applications", Handler: d.AdminHandler.ListApplications, Auth: true, Role: user.RoleAdmin},
+		{Method: http.MethodGet, Pattern: "/admin/stats", Handler: d.AdminHandler.Stats, Auth: true, Role: user.RoleAdmin},
+		{Method: http.MethodGet, Pattern: "/admin/audit-log", Handler: d.AdminHandler.AuditLog, Auth: true, Role: user.RoleAdmin},
+		{Method: http.MethodGet, Pattern: "/admin/moderation", Handler: d.ModerationHandler.List, Auth: true, Role: user.RoleAdmin},
+		{Method: http.MethodPost, Pattern: "/admin/moderation/{id}/approve", Handler: d.ModerationHandler.Approve, Auth: true, Role: user.RoleAdmin},
+		{Method: http.MethodPost, Pattern: "/admin/moderation/{id}/reject", Handler: d.ModerationHandler.Reject, Auth: true, Role: user.RoleAdmin},
+		{Method: http.MethodGet, Pattern: "/admin/moderation/reports", Handler: d.ModerationHandler.ListReports, Auth: true, Role: user.RoleAdmin},
+		{Method: http.MethodPost, Pattern: "/admin/moderation/reports/{id}/resolve", Handler: d.ModerationHandler.ResolveReport, Auth: true, Role: user.RoleAdmin},
+		{Method: http.MethodPost, Pattern: "/admin/moderation/reports/{id}/dismiss", Handler: d.ModerationHandler.DismissReport, Auth: true, Role: user.RoleAdmin},
+
+		{Method: http.MethodGet, Pattern: "/vacancies", Handler: d.VacancyHandler.ListPublished, OptionalAuth: true},
+		{Method: http.MethodPost, Pattern: "/vacancies", Handler: d.VacancyHandler.Create, Auth: true, Role: user.RoleCompany},
+		{Method: http.MethodGet, Pattern: "/vacancies/{id}", Handler: d.VacancyHandler.Get, OptionalAuth: true},
+		{Method: http.MethodPut, Pattern: "/vacancies/{id}", Handler: d.VacancyHandler.Update, Auth: true, Role: user.RoleCompany},
+		{Method: http.MethodPost, Pattern: "/vacancies/{id}/publish", Handler: d.VacancyHandler.Publish, Auth: true, Role: user.RoleCompany},
+		{Method: http.MethodPost, Pattern: "/vacancies/{id}/close", Handler: d.VacancyHandler.Close, Auth: true, Role: user.RoleCompany},
+		{Method: http.MethodPost, Pattern: "/vacancies/{id}/reopen", Handler: d.VacancyHandler.Reopen, Auth: true, Role: user.RoleCompany},
+		{Method: http.MethodPost, Pattern: "/vacancies/{id}/archive", Handler: d.VacancyHandler.Archive, Auth: true, Role: user.RoleCompany},
 
-func (r *Router) handleProtected(w http.ResponseWriter, req *http.Request) {
-	path := req.URL.Path
+		{Method: http.MethodPost, Pattern: "/invitations", Handler: d.InvitationHandler.Invite, Auth: true, Role: user.RoleCompany},
+		{Method: http.MethodPost, Pattern: "/invitations/{id}/accept", Handler: d.InvitationHandler.Accept, Auth: true, Role: user.RoleStudent},
+		{Method: http.MethodPost, Pattern: "/invitations/{id}/decline", Handler: d.InvitationHandler.Decline, Auth: true, Role: user.RoleStudent},
+		{Method: http.MethodPost, Pattern: "/invitations/{id}/cancel", Handler: d.InvitationHandler.Cancel, Auth: true, Role: user.RoleCompany},
 
+		{Method: http.MethodPost, Pattern: "/applications", Handler: d.ApplicationHandler.Apply, Auth: true, Role: user.RoleStudent},
+		{Method: http.MethodGet, Pattern: "/applications/{id}", Handler: d.ApplicationHandler.Get, Auth: true},
+		{Method: http.MethodGet, Pattern: "/applications/{id}/history", Handler: d.ApplicationHandler.History, Auth: true},
+		{Method: http.MethodPatch, Pattern: "/applications/{id}/status", Handler: d.ApplicationHandler.UpdateStatus, Auth: true, Role: user.RoleCompany},
+		{Method: http.MethodGet, Pattern: "/applications/{id}/interview", Handler: d.InterviewHandler.Get, Auth: true},
+		{Method: http.MethodPut, Pattern: "/applications/{id}/interview", Handler: d.InterviewHandler.Propose, Auth: true},
+		{Method: http.MethodPost, Pattern: "/applications/{id}/interview/select", Handler: d.InterviewHandler.Select, Auth: true},
+		{Method: http.MethodPost, Pattern: "/applications/{id}/interview/cancel", Handler: d.InterviewHandler.Cancel, Auth: true},
+		{Method: http.MethodGet, Pattern: "/applications/{id}/interview.ics", Handler: d.InterviewHandler.Calendar, Auth: true},
+		{Method: http.MethodGet, Pattern: "/applications/{id}/resume.pdf", Handler: d.ResumeHandler.ExportForApplication, Auth: true},
+		{Method: http.MethodGet, Pattern: "/applications/{id}/resume.html", Handler: d.ResumeHandler.ExportForApplication, Auth: true},
+		{Method: http.MethodPost, Pattern: "/applications/{id}/withdraw", Handler: d.ApplicationHandler.Withdraw, Auth: true, Role: user.RoleStudent},
+		{Method: http.MethodGet, Pattern: "/applications/{id}/messages", Handler: d.MessageHandler.List, Auth: true},
+		{Method: http.MethodPost, Pattern: "/applications/{id}/messages", Handler: d.MessageHandler.Send, Auth: true},
+		{Method: http.MethodPost, Pattern: "/applications/{id}/messages/read", Handler: d.MessageHandler.MarkRead, Auth: true},
+		{Method: http.MethodPost, Pattern: "/applications/{id}/attachments", Handler: d.MessageHandler.Upload, Auth: true, Transfer: true, MaxBody: handlers.MaxUploadBodyBytes},
+		{Method: http.MethodGet, Pattern: "/attachments/{id}", Handler: d.MessageHandler.Download, Auth: true, Transfer: true},
+	}
+}
+
+func (r *Router) compile(route Route) compiledRoute {
+	middlewares := make([]func(http.Handler) http.Handler, 0, len(route.Middleware)+5)
+	bodyLimit := int64(maxBodyBytes)
+	if route.MaxBody > 0 {
+		bodyLimit = route.MaxBody
+	}
+	middlewares = append(middlewares, httpmw.BodyLimit(bodyLimit))
 	switch {
-	case req.Method == http.MethodPatch && path == "/users/role":
-		r.deps.UserHandler.SetRole(w, req)
-		return
-	case req.Method == http.MethodPost && path == "/students/profile":
-		httpmw.RequireRole(user.RoleStudent)(http.HandlerFunc(r.deps.ProfileHandler.UpsertStudent)).ServeHTTP(w, req)
-		return
-	case req.Method == http.MethodPut && path == "/students/profile":
-		httpmw.RequireRole(user.RoleStudent)(http.HandlerFunc(r.deps.ProfileHandler.UpsertStudent)).ServeHTTP(w, req)
-		return
-	case req.Method == http.MethodPost && path == "/companies/profile":
-		httpmw.RequireRole(user.RoleCompany)(http.HandlerFunc(r.deps.ProfileHandler.UpsertCompany)).ServeHTTP(w, req)
-		return
-	case req.Method == http.MethodPut && path == "/companies/profile":
-		httpmw.RequireRole(user.RoleCompany)(http.HandlerFunc(r.deps.ProfileHandler.UpsertCompany)).ServeHTTP(w, req)
-		return
-	case req.Method == http.MethodPost && path == "/vacancies":
-		httpmw.RequireRole(user.RoleCompany)(http.HandlerFunc(r.deps.VacancyHandler.Create)).ServeHTTP(w, req)
-		return
-	case req.Method == http.MethodPatch && strings.HasPrefix(path, "/applications/") && strings.HasSuffix(path, "/status"):
-		httpmw.RequireRole(user.RoleCompany)(http.HandlerFunc(r.deps.ApplicationHandler.UpdateStatus)).ServeHTTP(w, req)
-		return
-	case req.Method == http.MethodPost && path == "/applications":
-		httpmw.RequireRole(user.RoleStudent)(http.HandlerFunc(r.deps.ApplicationHandler.Apply)).ServeHTTP(w, req)
-		return
-	case req.Method == http.MethodPost && strings.HasSuffix(path, "/messages") && strings.HasPrefix(path, "/applications/"):
-		r.deps.MessageHandler.Send(w, req)
-		return
-	case req.Method == http.MethodGet && strings.HasSuffix(path, "/messages") && strings.HasPrefix(path, "/applications/"):
-		r.deps.MessageHandler.List(w, req)
-		return
+	case route.Stream:
+		middlewares = append(middlewares, httpmw.TokenFromQuery("access_token"))
+	case route.Transfer:
+	default:
+		middlewares = append(middlewares, httpmw.Timeout(r.deps.RequestTimeout))
 	}
+	if route.Auth {
+		middlewares = append(middlewares, r.deps.AuthMiddleware.Authenticate)
+	} else if route.OptionalAuth {
+		middlewares = append(middlewares, r.deps.AuthMiddleware.Optional)
+	}
+	if route.Role != "" {
+		middlewares = append(middlewares, httpmw.RequireRole(route.Role))
+	}
+	middlewares = append(middlewares, route.Middleware...)
+	return compiledRoute{
+		method:   route.Method,
+		segments: splitPath(route.Pattern),
+		handler:  httpmw.Chain(route.Handler, middlewares...),
+	}
+}
 
+func (r *Router) dispatch(w http.ResponseWriter, req *http.Request) {
+	segments := splitPath(req.URL.Path)
+	var allowed []string
+	for _, route := range r.routes {
+		if !matchSegments(route.segments, segments) {
+			continue
+		}
+		if route.method == req.Method {
+			route.handler.ServeHTTP(w, req)
+			return
+		}
+		allowed = append(allowed, route.method)
+	}
+	if len(allowed) > 0 {
+		sort.Strings(allowed)
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
 	http.NotFound(w, req)
 }
+
+func splitPath(path string) []string {
+	trimmed := strings.Trim(path, "/")
+	if trimmed == "" {
+		return nil
+	}
+	return strings.Split(trimmed, "/")
+}
+
+func matchSegments(pattern, path []string) bool {
+	if len(pattern) != len(path) {
+		return false
+	}
+	for i, segment := range pattern {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			if path[i] == "" {
+				return false
+			}
+			continue
+		}
+		if segment != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func health(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok"))
+}
@@ -0,0 +1,148 @@
+package http
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"profzom/internal/common"
+	"profzom/internal/http/handlers"
+	"profzom/internal/http/metrics"
+	httpmw "profzom/internal/http/middleware"
+	"profzom/internal/realtime"
+	"profzom/internal/security"
+)
+
+func newTestRouter() http.Handler {
+	return newTestRouterWithHub(realtime.NewHub())
+}
+
+func newTestRouterWithHub(hub *realtime.Hub) http.Handler {
+	return NewRouter(RouterDependencies{
+		AuthHandler:           handlers.NewAuthHandler(nil, nil, ""),
+		UserHandler:           handlers.NewUserHandler(nil),
+		ProfileHandler:        handlers.NewProfileHandler(nil),
+		ResumeHandler:         handlers.NewResumeHandler(nil),
+		VacancyHandler:        handlers.NewVacancyHandler(nil, nil, nil),
+		BookmarkHandler:       handlers.NewBookmarkHandler(nil, nil),
+		SavedSearchHandler:    handlers.NewSavedSearchHandler(nil),
+		ApplicationHandler:    handlers.NewApplicationHandler(nil, nil, nil, nil),
+		MessageHandler:        handlers.NewMessageHandler(nil, nil, nil),
+		InterviewHandler:      handlers.NewInterviewHandler(nil),
+		RecommendationHandler: handlers.NewRecommendationHandler(nil),
+		CandidateHandler:      handlers.NewCandidateHandler(nil, nil),
+		InvitationHandler:     handlers.NewInvitationHandler(nil, nil, nil),
+		VerificationHandler:   handlers.NewVerificationHandler(nil, nil),
+		AdminHandler:          handlers.NewAdminHandler(nil, nil),
+		ModerationHandler:     handlers.NewModerationHandler(nil, nil),
+		ReportHandler:         handlers.NewReportHandler(nil, nil),
+		BlockListHandler:      handlers.NewBlockListHandler(nil, nil),
+		NotificationHandler:   handlers.NewNotificationHandler(nil, nil),
+		StreamHandler:         handlers.NewStreamHandler(hub),
+		MetricsHandler:        handlers.NewMetricsHandler(metrics.NewCollector()),
+		AuthMiddleware:        httpmw.NewAuthMiddleware(security.NewJWTProvider("secret")),
+		Metrics:               metrics.NewCollector(),
+		RequestTimeout:        time.Second,
+	})
+}
+
+func TestRouter_Health(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newTestRouter().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+}
+
+func TestRouter_MethodNotAllowed(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newTestRouter().ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/vacancies", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected status 405, got %d", rec.Code)
+	}
+	if allow := rec.Header().Get("Allow"); allow != "GET, POST" {
+		t.Fatalf("expected Allow header %q, got %q", "GET, POST", allow)
+	}
+}
+
+func TestRouter_NotFound(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newTestRouter().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/vacancies/abc/unknown", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", rec.Code)
+	}
+}
+
+func TestRouter_ProtectedRouteRequiresToken(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newTestRouter().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/vacancies/4b0d8c7e-5d8f-4a55-9a4e-0c0d1f4a2b11/publish", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected status 401, got %d", rec.Code)
+	}
+}
+
+func TestMatchSegments(t *testing.T) {
+	pattern := splitPath("/applications/{id}/messages")
+	if !matchSegments(pattern, splitPath("/applications/123/messages")) {
+		t.Fatal("expected pattern to match")
+	}
+	if matchSegments(pattern, splitPath("/applications/123/status")) {
+		t.Fatal("expected pattern not to match other suffix")
+	}
+	if matchSegments(pattern, splitPath("/applications/123")) {
+		t.Fatal("expected pattern not to match shorter path")
+	}
+}
+
+func TestRouter_StreamAcceptsQueryToken(t *testing.T) {
+	hub := realtime.NewHub()
+	userID := common.NewUUID()
+	token, _, err := security.NewJWTProvider("secret").Generate(userID, []string{"student"}, time.Minute)
+	if err != nil {
+		t.Fatalf("expected token, got %v", err)
+	}
+	server := httptest.NewServer(newTestRouterWithHub(hub))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/events/stream?access_token=" + token)
+	if err != nil {
+		t.Fatalf("expected stream to open, got %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("expected 200 text/event-stream, got %d %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	for deadline := time.Now().Add(time.Second); hub.Subscribers() == 0; {
+		if time.Now().After(deadline) {
+			t.Fatal("expected stream to subscribe to hub")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	hub.Dispatch(realtime.Event{Type: realtime.TypeMessageCreated, UserIDs: []common.UUID{userID}, Data: []byte(`{"id":"m1"}`)})
+
+	reader := bufio.NewReader(resp.Body)
+	var lines []string
+	for len(lines) < 2 {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("expected event line, got %v", err)
+		}
+		if strings.HasPrefix(line, "event:") || strings.HasPrefix(line, "data:") {
+			lines = append(lines, strings.TrimSpace(line))
+		}
+	}
+	if lines[0] != "event: message.created" || lines[1] != `data: {"id":"m1"}` {
+		t.Fatalf("unexpected event %q", lines)
+	}
+}
+
+func TestRouter_StreamRequiresToken(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newTestRouter().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/events/stream", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected status 401, got %d", rec.Code)
+	}
+}
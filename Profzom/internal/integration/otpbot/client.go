@@ -15,6 +15,7 @@ type Client interface {
 	GetTelegramStatus(ctx context.Context, phone string) (Status, error)
 	RegisterLinkToken(ctx context.Context, userID, token string) error
 	SendOTP(ctx context.Context, phone, otpCode string) error
+	Notify(ctx context.Context, userID, text string) error
 }
 
 type HTTPClient struct {
@@ -171,6 +172,71 @@ func (c *HTTPClient) SendOTP(ctx context.Context, phone, otpCode string) error {
 	}
 }
 
+// Notify отправляет уведомление в привязанный чат пользователя.
+// Если Telegram не привязан или пользователь отключил уведомления, возвращает ErrNotLinked или ErrOptedOut.
+func (c *HTTPClient) Notify(ctx context.Context, userID, text string) error {
+	if userID == "" {
+		return fmt.Errorf("%w: user_id is required", ErrDeliveryFailed)
+	}
+	if text == "" {
+		return fmt.Errorf("%w: text is required", ErrDeliveryFailed)
+	}
+	payload := struct {
+		UserID string `json:"user_id"`
+		Text   string `json:"text"`
+	}{
+		UserID: userID,
+		Text:   text,
+	}
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(payload); err != nil {
+		return fmt.Errorf("encode notify request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/notify", &buf)
+	if err != nil {
+		return fmt.Errorf("create notify request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.internalKey == "" {
+		return ErrUnauthorized
+	}
+	req.Header.Set("X-Internal-Key", c.internalKey)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("send notify request: %w", err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// см. ниже
+	case http.StatusBadRequest:
+		body := readBodySnippet(resp.Body)
+		return fmt.Errorf("%w: status=%d body=%s", ErrBadRequest, resp.StatusCode, body)
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	default:
+		body := readBodySnippet(resp.Body)
+		return fmt.Errorf("%w: status=%d body=%s", ErrDeliveryFailed, resp.StatusCode, body)
+	}
+	var result NotifyResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("%w: decode notify response: %v", ErrDeliveryFailed, err)
+	}
+	if result.Sent {
+		return nil
+	}
+	switch result.Reason {
+	case "not_linked":
+		return ErrNotLinked
+	case "opted_out":
+		return ErrOptedOut
+	default:
+		return fmt.Errorf("%w: notification skipped: %s", ErrDeliveryFailed, result.Reason)
+	}
+}
+
 func readBodySnippet(r io.Reader) string {
 	body, err := io.ReadAll(io.LimitReader(r, 4096))
 	if err != nil {
@@ -8,4 +8,5 @@ var (
 	ErrUnauthorized   = errors.New("otp bot unauthorized")
 	ErrRateLimited    = errors.New("otp bot rate limited")
 	ErrDeliveryFailed = errors.New("otp delivery failed")
+	ErrOptedOut       = errors.New("telegram notifications disabled")
 )
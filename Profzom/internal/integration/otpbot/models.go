@@ -3,3 +3,8 @@ package otpbot
 type Status struct {
 	Linked bool `json:"linked"`
 }
+
+type NotifyResult struct {
+	Sent   bool   `json:"sent"`
+	Reason string `json:"reason,omitempty"`
+}
@@ -0,0 +1,72 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"profzom/internal/domain/outbox"
+)
+
+// Sink пересылает записи outbox во внешний webhook. Тело подписывается HMAC-SHA256 в заголовке X-Profzom-Signature,
+// а X-Profzom-Delivery несёт id записи, по которому получатель отбрасывает повторы.
+type Sink struct {
+	url        string
+	secret     []byte
+	httpClient *http.Client
+}
+
+func NewSink(url, secret string, httpClient *http.Client) *Sink {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 5 * time.Second}
+	}
+	return &Sink{url: url, secret: []byte(secret), httpClient: httpClient}
+}
+
+type delivery struct {
+	ID        string          `json:"id"`
+	Topic     string          `json:"topic"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+func (s *Sink) Deliver(ctx context.Context, message outbox.Message) error {
+	body, err := json.Marshal(delivery{ID: message.ID.String(), Topic: string(message.Topic), Payload: message.Payload, CreatedAt: message.CreatedAt})
+	if err != nil {
+		return fmt.Errorf("encode webhook body: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Profzom-Delivery", message.ID.String())
+	req.Header.Set("X-Profzom-Topic", string(message.Topic))
+	if len(s.secret) > 0 {
+		req.Header.Set("X-Profzom-Signature", "sha256="+Sign(s.secret, body))
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook responded status=%d body=%s", resp.StatusCode, snippet)
+	}
+	return nil
+}
+
+// Sign возвращает hex HMAC-SHA256 тела; получатель сверяет его со значением заголовка без префикса "sha256=".
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
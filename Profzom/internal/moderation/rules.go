@@ -0,0 +1,118 @@
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"profzom/internal/domain/moderation"
+)
+
+// defaultBlockedDomains — сокращатели ссылок: по ним не видно, куда ведёт ссылка.
+var defaultBlockedDomains = []string{"bit.ly", "tinyurl.com", "goo.gl", "clck.ru", "cutt.ly", "is.gd", "t.co"}
+
+var linkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"']+`)
+
+// RuleConfig — правила RuleChecker. Стоп-слова сравниваются без учёта регистра с целыми словами и фразами,
+// Patterns — регулярные выражения в синтаксисе Go. MaxLinks ограничивает число ссылок в тексте (0 — без ограничения).
+type RuleConfig struct {
+	StopWords      []string
+	Patterns       []string
+	MaxLinks       int
+	BlockedDomains []string
+}
+
+// RuleChecker — проверка по правилам: стоп-слова, регулярные выражения и подозрительные ссылки.
+// Сработавшее правило не отклоняет контент, а отправляет его в очередь модерации.
+type RuleChecker struct {
+	stopWords      []string
+	patterns       []*regexp.Regexp
+	maxLinks       int
+	blockedDomains []string
+}
+
+func NewRuleChecker(cfg RuleConfig) (*RuleChecker, error) {
+	checker := &RuleChecker{maxLinks: cfg.MaxLinks}
+	for _, word := range cfg.StopWords {
+		if word = normalizeText(word); word != "" {
+			checker.stopWords = append(checker.stopWords, word)
+		}
+	}
+	for _, pattern := range cfg.Patterns {
+		if strings.TrimSpace(pattern) == "" {
+			continue
+		}
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid moderation pattern %q: %w", pattern, err)
+		}
+		checker.patterns = append(checker.patterns, compiled)
+	}
+	domains := cfg.BlockedDomains
+	if domains == nil {
+		domains = defaultBlockedDomains
+	}
+	for _, domain := range domains {
+		if domain = strings.ToLower(strings.TrimSpace(domain)); domain != "" {
+			checker.blockedDomains = append(checker.blockedDomains, domain)
+		}
+	}
+	return checker, nil
+}
+
+func (c *RuleChecker) Check(_ context.Context, content moderation.Content) (moderation.Verdict, error) {
+	var reasons []string
+	normalized := " " + normalizeText(content.Text) + " "
+	for _, word := range c.stopWords {
+		if strings.Contains(normalized, " "+word+" ") {
+			reasons = append(reasons, "stop word: "+word)
+		}
+	}
+	for _, pattern := range c.patterns {
+		if pattern.MatchString(content.Text) {
+			reasons = append(reasons, "pattern: "+pattern.String())
+		}
+	}
+	links := linkPattern.FindAllString(content.Text, -1)
+	if c.maxLinks > 0 && len(links) > c.maxLinks {
+		reasons = append(reasons, fmt.Sprintf("too many links: %d", len(links)))
+	}
+	for _, link := range links {
+		if host := linkHost(link); host != "" && c.isBlocked(host) {
+			reasons = append(reasons, "blocked link: "+host)
+		}
+	}
+	return moderation.Verdict{Hold: len(reasons) > 0, Reasons: reasons}, nil
+}
+
+func (c *RuleChecker) isBlocked(host string) bool {
+	for _, domain := range c.blockedDomains {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+func linkHost(link string) string {
+	if !strings.Contains(link, "://") {
+		link = "http://" + link
+	}
+	parsed, err := url.Parse(strings.TrimRight(link, ".,;:!?)"))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+}
+
+// normalizeText приводит текст к нижнему регистру и заменяет знаки препинания пробелами,
+// чтобы стоп-слово находилось и рядом с пунктуацией, но не внутри другого слова.
+func normalizeText(text string) string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(fields, " ")
+}
@@ -0,0 +1,46 @@
+package moderation
+
+import (
+	"context"
+	"testing"
+
+	"profzom/internal/domain/moderation"
+)
+
+func TestRuleChecker(t *testing.T) {
+	checker, err := NewRuleChecker(RuleConfig{StopWords: []string{"Казино", "easy money"}, Patterns: []string{`(?i)предоплат`}, MaxLinks: 2})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	cases := []struct {
+		name string
+		text string
+		hold bool
+	}{
+		{name: "clean", text: "Стажировка Go-разработчика, подробности на https://example.com/jobs", hold: false},
+		{name: "stop word with punctuation", text: "Лучшее КАЗИНО!", hold: true},
+		{name: "stop word inside another word", text: "Казиноподобные механики не используем", hold: false},
+		{name: "stop phrase", text: "Easy, money for students", hold: true},
+		{name: "pattern", text: "Нужна Предоплата за обучение", hold: true},
+		{name: "too many links", text: "https://a.ru https://b.ru www.c.ru", hold: true},
+		{name: "shortener", text: "Анкета: https://bit.ly/abc.", hold: true},
+	}
+	for _, tc := range cases {
+		verdict, err := checker.Check(context.Background(), moderation.Content{Type: moderation.ContentMessage, Text: tc.text})
+		if err != nil {
+			t.Fatalf("%s: expected nil error, got %v", tc.name, err)
+		}
+		if verdict.Hold != tc.hold {
+			t.Fatalf("%s: expected hold=%v, got %+v", tc.name, tc.hold, verdict)
+		}
+		if verdict.Hold && len(verdict.Reasons) == 0 {
+			t.Fatalf("%s: expected reasons for held content", tc.name)
+		}
+	}
+}
+
+func TestNewRuleChecker_InvalidPattern(t *testing.T) {
+	if _, err := NewRuleChecker(RuleConfig{Patterns: []string{"("}}); err == nil {
+		t.Fatal("expected error for invalid pattern")
+	}
+}
@@ -0,0 +1,105 @@
+package realtime
+
+import (
+	"encoding/json"
+	"sync"
+
+	"profzom/internal/common"
+)
+
+const (
+	TypeMessageCreated           = "message.created"
+	TypeMessageRead              = "message.read"
+	TypeApplicationStatusChanged = "application.status_changed"
+
+	subscriberBuffer = 16
+)
+
+// Event — событие для подписчиков потока. UserIDs — получатели; в поток клиента они не попадают.
+type Event struct {
+	Type    string          `json:"type"`
+	UserIDs []common.UUID   `json:"user_ids"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// Hub — локальный pub/sub внутри одного процесса. События из других реплик приходят в него через мост LISTEN/NOTIFY.
+type Hub struct {
+	mu      sync.RWMutex
+	nextID  int
+	clients map[common.UUID]map[int]chan Event
+	closed  bool
+}
+
+func NewHub() *Hub {
+	return &Hub{clients: make(map[common.UUID]map[int]chan Event)}
+}
+
+// Subscribe регистрирует подписчика пользователя; cancel обязательно вызвать при закрытии соединения.
+func (h *Hub) Subscribe(userID common.UUID) (<-chan Event, func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ch := make(chan Event, subscriberBuffer)
+	if h.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	h.nextID++
+	id := h.nextID
+	if h.clients[userID] == nil {
+		h.clients[userID] = make(map[int]chan Event)
+	}
+	h.clients[userID][id] = ch
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		// повторный вызов или вызов после Close: канал уже закрыт и удалён из clients
+		if _, ok := h.clients[userID][id]; !ok {
+			return
+		}
+		delete(h.clients[userID], id)
+		if len(h.clients[userID]) == 0 {
+			delete(h.clients, userID)
+		}
+		close(ch)
+	}
+}
+
+// Dispatch раздаёт событие подписчикам получателей. Медленный подписчик с заполненным буфером событие пропускает:
+// клиент всё равно может догрузить пропущенное обычными GET-запросами.
+func (h *Hub) Dispatch(event Event) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for _, userID := range event.UserIDs {
+		for _, ch := range h.clients[userID] {
+			select {
+			case ch <- event:
+			default:
+			}
+		}
+	}
+}
+
+// Close завершает все подписки; новые после этого сразу получают закрытый канал.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for userID, clients := range h.clients {
+		for id, ch := range clients {
+			close(ch)
+			delete(clients, id)
+		}
+		delete(h.clients, userID)
+	}
+}
+
+// Subscribers возвращает число активных подписок.
+func (h *Hub) Subscribers() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	count := 0
+	for _, clients := range h.clients {
+		count += len(clients)
+	}
+	return count
+}
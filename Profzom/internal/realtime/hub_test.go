@@ -0,0 +1,60 @@
+package realtime
+
+import (
+	"testing"
+
+	"profzom/internal/common"
+)
+
+func TestHub_DispatchesToRecipientsOnly(t *testing.T) {
+	hub := NewHub()
+	recipient, other := common.NewUUID(), common.NewUUID()
+	events, cancel := hub.Subscribe(recipient)
+	defer cancel()
+	otherEvents, cancelOther := hub.Subscribe(other)
+	defer cancelOther()
+
+	hub.Dispatch(Event{Type: TypeMessageCreated, UserIDs: []common.UUID{recipient}})
+
+	select {
+	case event := <-events:
+		if event.Type != TypeMessageCreated {
+			t.Fatalf("expected %q event, got %q", TypeMessageCreated, event.Type)
+		}
+	default:
+		t.Fatal("expected event for recipient")
+	}
+	select {
+	case event := <-otherEvents:
+		t.Fatalf("expected no event for other user, got %+v", event)
+	default:
+	}
+}
+
+func TestHub_SlowSubscriberDoesNotBlock(t *testing.T) {
+	hub := NewHub()
+	userID := common.NewUUID()
+	_, cancel := hub.Subscribe(userID)
+	defer cancel()
+
+	for i := 0; i < subscriberBuffer*2; i++ {
+		hub.Dispatch(Event{Type: TypeMessageCreated, UserIDs: []common.UUID{userID}})
+	}
+}
+
+func TestHub_CloseEndsSubscriptions(t *testing.T) {
+	hub := NewHub()
+	events, cancel := hub.Subscribe(common.NewUUID())
+	hub.Close()
+	if _, ok := <-events; ok {
+		t.Fatal("expected channel to be closed")
+	}
+	cancel()
+	if hub.Subscribers() != 0 {
+		t.Fatalf("expected no subscribers, got %d", hub.Subscribers())
+	}
+	late, _ := hub.Subscribe(common.NewUUID())
+	if _, ok := <-late; ok {
+		t.Fatal("expected subscription after close to be closed")
+	}
+}
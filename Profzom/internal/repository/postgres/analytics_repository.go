@@ -17,6 +17,7 @@ func NewAnalyticsRepository(db *sql.DB) *AnalyticsRepository {
 	return &AnalyticsRepository{db: db}
 }
 
+// Create идемпотентен по event.ID: диспетчер outbox может доставить одно событие повторно.
 func (r *AnalyticsRepository) Create(ctx context.Context, event analytics.Event) error {
 	if event.ID == "" {
 		event.ID = common.NewUUID()
@@ -24,7 +25,8 @@ func (r *AnalyticsRepository) Create(ctx context.Context, event analytics.Event)
 	if event.CreatedAt.IsZero() {
 		event.CreatedAt = time.Now().UTC()
 	}
-	_, err := r.db.ExecContext(ctx, `INSERT INTO analytics_events (id, user_id, name, payload, created_at) VALUES ($1, $2, $3, $4, $5)`,
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO analytics_events (id, user_id, name, payload, created_at) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (id) DO NOTHING`,
 		event.ID, event.UserID, event.Name, event.Payload, event.CreatedAt)
 	if err != nil {
 		return common.NewError(common.CodeInternal, "failed to store analytics event", err)
@@ -4,11 +4,15 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 
+	"github.com/lib/pq"
+
 	"profzom/internal/common"
 	"profzom/internal/domain/application"
+	"profzom/internal/domain/profile"
 )
 
 type ApplicationRepository struct {
@@ -24,17 +28,23 @@ func (r *ApplicationRepository) Create(ctx context.Context, app application.Appl
 	now := time.Now().UTC()
 	app.CreatedAt = now
 	app.UpdatedAt = now
-	_, err := r.db.ExecContext(ctx, `INSERT INTO applications (id, vacancy_id, student_id, status, feedback, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
-		app.ID, app.VacancyID, app.StudentID, app.Status, app.Feedback, app.CreatedAt, app.UpdatedAt)
+	err := runInTx(ctx, r.db, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `INSERT INTO applications (id, vacancy_id, student_id, status, feedback, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			app.ID, app.VacancyID, app.StudentID, app.Status, app.Feedback, app.CreatedAt, app.UpdatedAt)
+		if err != nil {
+			return common.NewError(common.CodeInternal, "failed to create application", err)
+		}
+		return insertStatusEvent(ctx, tx, application.StatusEvent{ApplicationID: app.ID, ToStatus: app.Status, ChangedBy: app.StudentID, CreatedAt: now})
+	})
 	if err != nil {
-		return nil, common.NewError(common.CodeInternal, "failed to create application", err)
+		return nil, err
 	}
 	return &app, nil
 }
 
 func (r *ApplicationRepository) GetByID(ctx context.Context, id common.UUID) (*application.Application, error) {
-	row := r.db.QueryRowContext(ctx, `SELECT id, vacancy_id, student_id, status, feedback, created_at, updated_at FROM applications WHERE id = $1`, id)
+	row := conn(ctx, r.db).QueryRowContext(ctx, `SELECT id, vacancy_id, student_id, status, feedback, created_at, updated_at FROM applications WHERE id = $1`, id)
 	var app application.Application
 	if err := row.Scan(&app.ID, &app.VacancyID, &app.StudentID, &app.Status, &app.Feedback, &app.CreatedAt, &app.UpdatedAt); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -47,7 +57,7 @@ func (r *ApplicationRepository) GetByID(ctx context.Context, id common.UUID) (*a
 }
 
 func (r *ApplicationRepository) ListByVacancy(ctx context.Context, vacancyID common.UUID) ([]application.Application, error) {
-	rows, err := r.db.QueryContext(ctx, `SELECT id, vacancy_id, student_id, status, feedback, created_at, updated_at FROM applications WHERE vacancy_id = $1`, vacancyID)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT id, vacancy_id, student_id, status, feedback, created_at, updated_at FROM applications WHERE vacancy_id = $1`, vacancyID)
 	if err != nil {
 		return nil, common.NewError(common.CodeInternal, "failed to list applications", err)
 	}
@@ -64,57 +74,233 @@ func (r *ApplicationRepository) ListByVacancy(ctx context.Context, vacancyID com
 	return items, nil
 }
 
-func (r *ApplicationRepository) ListByStudent(ctx context.Context, studentID common.UUID) ([]application.Application, error) {
-	rows, err := r.db.QueryContext(ctx, `SELECT id, vacancy_id, student_id, status, feedback, created_at, updated_at FROM applications WHERE student_id = $1`, studentID)
+func (r *ApplicationRepository) ListByStudent(ctx context.Context, studentID common.UUID, page common.PageRequest) ([]application.Application, *common.Cursor, error) {
+	args := []interface{}{studentID}
+	condition := "student_id = $1"
+	if page.After != nil {
+		args = append(args, page.After.CreatedAt, page.After.ID)
+		condition += " AND (created_at, id) < ($2, $3)"
+	}
+	args = append(args, page.Limit+1)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, fmt.Sprintf(`SELECT id, vacancy_id, student_id, status, feedback, created_at, updated_at
+		FROM applications WHERE %s ORDER BY created_at DESC, id DESC LIMIT $%d`, condition, len(args)), args...)
 	if err != nil {
-		return nil, common.NewError(common.CodeInternal, "failed to list student applications", err)
+		return nil, nil, common.NewError(common.CodeInternal, "failed to list student applications", err)
 	}
 	defer rows.Close()
 	var items []application.Application
 	for rows.Next() {
 		var app application.Application
 		if err := rows.Scan(&app.ID, &app.VacancyID, &app.StudentID, &app.Status, &app.Feedback, &app.CreatedAt, &app.UpdatedAt); err != nil {
-			return nil, common.NewError(common.CodeInternal, "failed to scan application", err)
+			return nil, nil, common.NewError(common.CodeInternal, "failed to scan application", err)
 		}
 		app.Status = normalizeStatus(app.Status)
 		items = append(items, app)
 	}
-	return items, nil
+	if err := rows.Err(); err != nil {
+		return nil, nil, common.NewError(common.CodeInternal, "failed to list student applications", err)
+	}
+	items, next := trimPage(items, page.Limit, applicationCursor)
+	return items, next, nil
 }
 
-func (r *ApplicationRepository) ListByCompany(ctx context.Context, companyID common.UUID) ([]application.Application, error) {
-	rows, err := r.db.QueryContext(ctx, `SELECT a.id, a.vacancy_id, a.student_id, a.status, a.feedback, a.created_at, a.updated_at
+func (r *ApplicationRepository) ListByCompany(ctx context.Context, companyID common.UUID, page common.PageRequest) ([]application.Application, *common.Cursor, error) {
+	args := []interface{}{companyID}
+	condition := "v.company_id = $1"
+	if page.After != nil {
+		args = append(args, page.After.CreatedAt, page.After.ID)
+		condition += " AND (a.created_at, a.id) < ($2, $3)"
+	}
+	args = append(args, page.Limit+1)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, fmt.Sprintf(`SELECT a.id, a.vacancy_id, a.student_id, a.status, a.feedback, a.created_at, a.updated_at
 		FROM applications a
 		JOIN vacancies v ON v.id = a.vacancy_id
-		WHERE v.company_id = $1
-		ORDER BY a.created_at DESC`, companyID)
+		WHERE %s
+		ORDER BY a.created_at DESC, a.id DESC
+		LIMIT $%d`, condition, len(args)), args...)
 	if err != nil {
-		return nil, common.NewError(common.CodeInternal, "failed to list company applications", err)
+		return nil, nil, common.NewError(common.CodeInternal, "failed to list company applications", err)
 	}
 	defer rows.Close()
 	var items []application.Application
 	for rows.Next() {
 		var app application.Application
 		if err := rows.Scan(&app.ID, &app.VacancyID, &app.StudentID, &app.Status, &app.Feedback, &app.CreatedAt, &app.UpdatedAt); err != nil {
-			return nil, common.NewError(common.CodeInternal, "failed to scan application", err)
+			return nil, nil, common.NewError(common.CodeInternal, "failed to scan application", err)
 		}
 		app.Status = normalizeStatus(app.Status)
 		items = append(items, app)
 	}
-	return items, nil
+	if err := rows.Err(); err != nil {
+		return nil, nil, common.NewError(common.CodeInternal, "failed to list company applications", err)
+	}
+	items, next := trimPage(items, page.Limit, applicationCursor)
+	return items, next, nil
+}
+
+// ListPipeline отдаёт отклики компании вместе с вакансией и профилем студента одним запросом.
+func (r *ApplicationRepository) ListPipeline(ctx context.Context, query application.PipelineQuery) ([]application.PipelineItem, *common.Cursor, error) {
+	var args []interface{}
+	conditions := []string{"TRUE"}
+	if query.CompanyID != "" {
+		args = append(args, query.CompanyID)
+		conditions = append(conditions, fmt.Sprintf("v.company_id = $%d", len(args)))
+	}
+	if query.VacancyID != nil {
+		args = append(args, *query.VacancyID)
+		conditions = append(conditions, fmt.Sprintf("a.vacancy_id = $%d", len(args)))
+	}
+	if query.StudentID != nil {
+		args = append(args, *query.StudentID)
+		conditions = append(conditions, fmt.Sprintf("a.student_id = $%d", len(args)))
+	}
+	if len(query.Statuses) > 0 {
+		args = append(args, pq.Array(statusFilterValues(query.Statuses)))
+		conditions = append(conditions, fmt.Sprintf("lower(trim(a.status)) = ANY($%d)", len(args)))
+	}
+	direction, comparison := "DESC", "<"
+	if query.Sort == application.PipelineSortOldest {
+		direction, comparison = "ASC", ">"
+	}
+	if query.After != nil {
+		args = append(args, query.After.CreatedAt, query.After.ID)
+		conditions = append(conditions, fmt.Sprintf("(a.created_at, a.id) %s ($%d, $%d)", comparison, len(args)-1, len(args)))
+	}
+	args = append(args, query.Limit+1)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, fmt.Sprintf(`SELECT a.id, a.vacancy_id, a.student_id, a.status, a.feedback, a.created_at, a.updated_at,
+			v.title, v.status,
+			sp.user_id IS NOT NULL, COALESCE(sp.name, ''), COALESCE(sp.university, ''), COALESCE(sp.course, 0), COALESCE(sp.specialty, ''),
+			COALESCE(sp.bio, ''), COALESCE(sp.skills, ARRAY[]::TEXT[]), COALESCE(sp.created_at, a.created_at), COALESCE(sp.updated_at, a.created_at)
+		FROM applications a
+		JOIN vacancies v ON v.id = a.vacancy_id
+		LEFT JOIN student_profiles sp ON sp.user_id = a.student_id
+		WHERE %s
+		ORDER BY a.created_at %s, a.id %s
+		LIMIT $%d`, strings.Join(conditions, " AND "), direction, direction, len(args)), args...)
+	if err != nil {
+		return nil, nil, common.NewError(common.CodeInternal, "failed to list application pipeline", err)
+	}
+	defer rows.Close()
+	var items []application.PipelineItem
+	for rows.Next() {
+		var item application.PipelineItem
+		var student profile.StudentProfile
+		var hasProfile bool
+		if err := rows.Scan(&item.ID, &item.VacancyID, &item.StudentID, &item.Status, &item.Feedback, &item.CreatedAt, &item.UpdatedAt,
+			&item.Vacancy.Title, &item.Vacancy.Status,
+			&hasProfile, &student.Name, &student.University, &student.Course, &student.Specialty,
+			&student.About, pq.Array(&student.Skills), &student.CreatedAt, &student.UpdatedAt); err != nil {
+			return nil, nil, common.NewError(common.CodeInternal, "failed to scan application pipeline", err)
+		}
+		item.Status = normalizeStatus(item.Status)
+		item.Vacancy.ID = item.VacancyID
+		if hasProfile {
+			student.UserID = item.StudentID
+			item.Student = &student
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, common.NewError(common.CodeInternal, "failed to list application pipeline", err)
+	}
+	items, next := trimPage(items, query.Limit, func(item application.PipelineItem) common.Cursor { return applicationCursor(item.Application) })
+	return items, next, nil
+}
+
+func (r *ApplicationRepository) CountByStatus(ctx context.Context, companyID common.UUID, vacancyID *common.UUID) (map[application.Status]int, error) {
+	args := []interface{}{companyID}
+	condition := "v.company_id = $1"
+	if vacancyID != nil {
+		args = append(args, *vacancyID)
+		condition += " AND a.vacancy_id = $2"
+	}
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT a.status, COUNT(*)
+		FROM applications a
+		JOIN vacancies v ON v.id = a.vacancy_id
+		WHERE `+condition+`
+		GROUP BY a.status`, args...)
+	if err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to count applications", err)
+	}
+	defer rows.Close()
+	counts := make(map[application.Status]int)
+	for rows.Next() {
+		var status application.Status
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, common.NewError(common.CodeInternal, "failed to scan application counts", err)
+		}
+		counts[normalizeStatus(status)] += count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to count applications", err)
+	}
+	return counts, nil
 }
 
-func (r *ApplicationRepository) UpdateStatus(ctx context.Context, id common.UUID, status application.Status, feedback string) (*application.Application, error) {
+// UpdateStatus меняет статус отклика и пишет событие в application_status_events в одной транзакции.
+func (r *ApplicationRepository) UpdateStatus(ctx context.Context, event application.StatusEvent) (*application.Application, error) {
 	updatedAt := time.Now().UTC()
-	_, err := r.db.ExecContext(ctx, `UPDATE applications SET status = $1, feedback = $2, updated_at = $3 WHERE id = $4`, status, feedback, updatedAt, id)
+	event.CreatedAt = updatedAt
+	err := runInTx(ctx, r.db, func(tx *sql.Tx) error {
+		// статус меняется, только если он всё ещё равен FromStatus: иначе параллельный запрос успел раньше,
+		// и проверка перехода в сервисе была сделана по устаревшему статусу
+		var id common.UUID
+		err := tx.QueryRowContext(ctx, `UPDATE applications SET status = $1, feedback = $2, updated_at = $3
+			WHERE id = $4 AND (lower(trim(status)) = $5::text OR ($5::text = 'invited' AND lower(trim(status)) = 'interview'))
+			RETURNING id`, event.ToStatus, event.Feedback, updatedAt, event.ApplicationID, event.FromStatus).Scan(&id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return common.NewError(common.CodeConflict, "application status has changed", err)
+			}
+			return common.NewError(common.CodeInternal, "failed to update application", err)
+		}
+		return insertStatusEvent(ctx, tx, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, event.ApplicationID)
+}
+
+func (r *ApplicationRepository) ListStatusEvents(ctx context.Context, applicationID common.UUID) ([]application.StatusEvent, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT id, application_id, from_status, to_status, changed_by, feedback, created_at
+		FROM application_status_events WHERE application_id = $1 ORDER BY created_at ASC, id ASC`, applicationID)
+	if err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to list application history", err)
+	}
+	defer rows.Close()
+	var items []application.StatusEvent
+	for rows.Next() {
+		var event application.StatusEvent
+		var changedBy sql.NullString
+		if err := rows.Scan(&event.ID, &event.ApplicationID, &event.FromStatus, &event.ToStatus, &changedBy, &event.Feedback, &event.CreatedAt); err != nil {
+			return nil, common.NewError(common.CodeInternal, "failed to scan application history", err)
+		}
+		event.ChangedBy = common.UUID(changedBy.String)
+		items = append(items, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to list application history", err)
+	}
+	return items, nil
+}
+
+func insertStatusEvent(ctx context.Context, tx *sql.Tx, event application.StatusEvent) error {
+	if event.ID == "" {
+		event.ID = common.NewUUID()
+	}
+	_, err := tx.ExecContext(ctx, `INSERT INTO application_status_events (id, application_id, from_status, to_status, changed_by, feedback, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		event.ID, event.ApplicationID, event.FromStatus, event.ToStatus, event.ChangedBy, event.Feedback, event.CreatedAt)
 	if err != nil {
-		return nil, common.NewError(common.CodeInternal, "failed to update application", err)
+		return common.NewError(common.CodeInternal, "failed to store application status event", err)
 	}
-	return r.GetByID(ctx, id)
+	return nil
 }
 
 func (r *ApplicationRepository) FindByVacancyAndStudent(ctx context.Context, vacancyID, studentID common.UUID) (*application.Application, error) {
-	row := r.db.QueryRowContext(ctx, `SELECT id, vacancy_id, student_id, status, feedback, created_at, updated_at FROM applications WHERE vacancy_id = $1 AND student_id = $2`, vacancyID, studentID)
+	row := conn(ctx, r.db).QueryRowContext(ctx, `SELECT id, vacancy_id, student_id, status, feedback, created_at, updated_at FROM applications WHERE vacancy_id = $1 AND student_id = $2`, vacancyID, studentID)
 	var app application.Application
 	if err := row.Scan(&app.ID, &app.VacancyID, &app.StudentID, &app.Status, &app.Feedback, &app.CreatedAt, &app.UpdatedAt); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -133,3 +319,21 @@ func normalizeStatus(status application.Status) application.Status {
 	}
 	return normalized
 }
+
+// statusFilterValues переводит фильтр по статусам в значения для lower(trim(status)) = ANY(...):
+// так фильтр совпадает с normalizeStatus в CountByStatus, а invited находит и старые записи со статусом interview.
+func statusFilterValues(statuses []application.Status) []string {
+	values := make([]string, 0, len(statuses)+1)
+	for _, status := range statuses {
+		normalized := normalizeStatus(status)
+		values = append(values, string(normalized))
+		if normalized == application.StatusInvited {
+			values = append(values, "interview")
+		}
+	}
+	return values
+}
+
+func applicationCursor(app application.Application) common.Cursor {
+	return common.Cursor{CreatedAt: app.CreatedAt, ID: app.ID}
+}
@@ -0,0 +1,21 @@
+package postgres
+
+import (
+	"reflect"
+	"testing"
+
+	"profzom/internal/domain/application"
+)
+
+func TestStatusFilterValues_MatchesLegacyInterview(t *testing.T) {
+	got := statusFilterValues([]application.Status{" Invited ", application.StatusApplied})
+	want := []string{"invited", "interview", "applied"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for _, stored := range []application.Status{"interview", " INTERVIEW", "Invited"} {
+		if normalizeStatus(stored) != application.StatusInvited {
+			t.Fatalf("expected %q to be counted as invited", stored)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/lib/pq"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/message"
+)
+
+const attachmentColumns = `id, application_id, message_id, uploader_id, file_name, content_type, size, storage_key, created_at`
+
+func scanAttachment(row rowScanner, attachment *message.Attachment) error {
+	var messageID sql.NullString
+	if err := row.Scan(&attachment.ID, &attachment.ApplicationID, &messageID, &attachment.UploaderID, &attachment.FileName,
+		&attachment.ContentType, &attachment.Size, &attachment.StorageKey, &attachment.CreatedAt); err != nil {
+		return err
+	}
+	if messageID.Valid {
+		id := common.UUID(messageID.String)
+		attachment.MessageID = &id
+	}
+	return nil
+}
+
+type AttachmentRepository struct {
+	db *sql.DB
+}
+
+func NewAttachmentRepository(db *sql.DB) *AttachmentRepository {
+	return &AttachmentRepository{db: db}
+}
+
+func (r *AttachmentRepository) Create(ctx context.Context, attachment message.Attachment) (*message.Attachment, error) {
+	attachment.ID = common.NewUUID()
+	attachment.CreatedAt = time.Now().UTC()
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO message_attachments (id, application_id, uploader_id, file_name, content_type, size, storage_key, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`, attachment.ID, attachment.ApplicationID, attachment.UploaderID, attachment.FileName,
+		attachment.ContentType, attachment.Size, attachment.StorageKey, attachment.CreatedAt)
+	if err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to create attachment", err)
+	}
+	return &attachment, nil
+}
+
+func (r *AttachmentRepository) GetByID(ctx context.Context, id common.UUID) (*message.Attachment, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+attachmentColumns+` FROM message_attachments WHERE id = $1`, id)
+	var attachment message.Attachment
+	if err := scanAttachment(row, &attachment); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, common.NewError(common.CodeNotFound, "attachment not found", err)
+		}
+		return nil, common.NewError(common.CodeInternal, "failed to load attachment", err)
+	}
+	return &attachment, nil
+}
+
+func (r *AttachmentRepository) Attach(ctx context.Context, messageID, applicationID, uploaderID common.UUID, ids []common.UUID) ([]message.Attachment, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `UPDATE message_attachments SET message_id = $1
+		WHERE id = ANY($4::uuid[]) AND application_id = $2 AND uploader_id = $3 AND message_id IS NULL
+		RETURNING `+attachmentColumns, messageID, applicationID, uploaderID, pq.Array(uuidStrings(ids)))
+	if err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to attach files", err)
+	}
+	items, err := scanAttachments(rows)
+	if err != nil {
+		return nil, err
+	}
+	// RETURNING не сохраняет порядок, а клиент ждёт вложения в том порядке, в котором их передал
+	position := make(map[common.UUID]int, len(ids))
+	for i, id := range ids {
+		position[id] = i
+	}
+	sort.SliceStable(items, func(i, j int) bool { return position[items[i].ID] < position[items[j].ID] })
+	return items, nil
+}
+
+func (r *AttachmentRepository) ListByMessages(ctx context.Context, messageIDs []common.UUID) (map[common.UUID][]message.Attachment, error) {
+	result := make(map[common.UUID][]message.Attachment)
+	if len(messageIDs) == 0 {
+		return result, nil
+	}
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT `+attachmentColumns+` FROM message_attachments
+		WHERE message_id = ANY($1::uuid[]) ORDER BY created_at ASC, id ASC`, pq.Array(uuidStrings(messageIDs)))
+	if err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to list attachments", err)
+	}
+	items, err := scanAttachments(rows)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		result[*item.MessageID] = append(result[*item.MessageID], item)
+	}
+	return result, nil
+}
+
+func scanAttachments(rows *sql.Rows) ([]message.Attachment, error) {
+	defer rows.Close()
+	var items []message.Attachment
+	for rows.Next() {
+		var attachment message.Attachment
+		if err := scanAttachment(rows, &attachment); err != nil {
+			return nil, common.NewError(common.CodeInternal, "failed to scan attachment", err)
+		}
+		items = append(items, attachment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to list attachments", err)
+	}
+	return items, nil
+}
+
+func uuidStrings(ids []common.UUID) []string {
+	values := make([]string, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, id.String())
+	}
+	return values
+}
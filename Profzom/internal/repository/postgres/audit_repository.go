@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/admin"
+)
+
+type AuditRepository struct {
+	db *sql.DB
+}
+
+func NewAuditRepository(db *sql.DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+func (r *AuditRepository) Create(ctx context.Context, entry admin.AuditEntry) error {
+	if entry.ID == "" {
+		entry.ID = common.NewUUID()
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now().UTC()
+	}
+	details := entry.Details
+	if details == nil {
+		details = map[string]string{}
+	}
+	encoded, err := json.Marshal(details)
+	if err != nil {
+		return common.NewError(common.CodeInternal, "failed to encode audit details", err)
+	}
+	_, err = conn(ctx, r.db).ExecContext(ctx, `INSERT INTO admin_audit_log (id, admin_id, action, target_type, target_id, details, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		entry.ID, nullableUUIDPtr(entry.AdminID), entry.Action, entry.TargetType, entry.TargetID, encoded, entry.CreatedAt)
+	if err != nil {
+		return common.NewError(common.CodeInternal, "failed to write audit log", err)
+	}
+	return nil
+}
+
+func (r *AuditRepository) List(ctx context.Context, query admin.AuditQuery) ([]admin.AuditEntry, *common.Cursor, error) {
+	var args []interface{}
+	conditions := []string{"TRUE"}
+	if query.AdminID != nil {
+		args = append(args, *query.AdminID)
+		conditions = append(conditions, fmt.Sprintf("admin_id = $%d", len(args)))
+	}
+	if query.TargetID != "" {
+		args = append(args, query.TargetID)
+		conditions = append(conditions, fmt.Sprintf("target_id = $%d", len(args)))
+	}
+	if query.Action != "" {
+		args = append(args, query.Action)
+		conditions = append(conditions, fmt.Sprintf("action = $%d", len(args)))
+	}
+	if query.After != nil {
+		args = append(args, query.After.CreatedAt, query.After.ID)
+		conditions = append(conditions, fmt.Sprintf("(created_at, id) < ($%d, $%d)", len(args)-1, len(args)))
+	}
+	args = append(args, query.Limit+1)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, fmt.Sprintf(`SELECT id, admin_id, action, target_type, target_id, details, created_at
+		FROM admin_audit_log WHERE %s ORDER BY created_at DESC, id DESC LIMIT $%d`, strings.Join(conditions, " AND "), len(args)), args...)
+	if err != nil {
+		return nil, nil, common.NewError(common.CodeInternal, "failed to list audit log", err)
+	}
+	defer rows.Close()
+	var items []admin.AuditEntry
+	for rows.Next() {
+		var entry admin.AuditEntry
+		var adminID sql.NullString
+		var details []byte
+		if err := rows.Scan(&entry.ID, &adminID, &entry.Action, &entry.TargetType, &entry.TargetID, &details, &entry.CreatedAt); err != nil {
+			return nil, nil, common.NewError(common.CodeInternal, "failed to scan audit entry", err)
+		}
+		entry.AdminID = nullUUID(adminID)
+		if len(details) > 0 {
+			if err := json.Unmarshal(details, &entry.Details); err != nil {
+				return nil, nil, common.NewError(common.CodeInternal, "failed to decode audit details", err)
+			}
+		}
+		items = append(items, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, common.NewError(common.CodeInternal, "failed to list audit log", err)
+	}
+	items, next := trimPage(items, query.Limit, func(entry admin.AuditEntry) common.Cursor {
+		return common.Cursor{CreatedAt: entry.CreatedAt, ID: entry.ID}
+	})
+	return items, next, nil
+}
@@ -23,7 +23,7 @@ func NewOTPRepository(db *sql.DB) *OTPRepository {
 func (r *OTPRepository) UpsertCode(ctx context.Context, userID, code string, expiresAtUnix int64, attemptsLeft int) error {
 	codeHash := hashOTP(code)
 	now := time.Now().UTC()
-	_, err := r.db.ExecContext(ctx, `INSERT INTO otp_codes (user_id, code_hash, expires_at, created_at, attempts, requested_at)
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO otp_codes (user_id, code_hash, expires_at, created_at, attempts, requested_at)
 		VALUES ($1, $2, $3, $4, $5, $6)
 		ON CONFLICT (user_id) DO UPDATE SET code_hash = EXCLUDED.code_hash, expires_at = EXCLUDED.expires_at, created_at = EXCLUDED.created_at, attempts = EXCLUDED.attempts, requested_at = EXCLUDED.requested_at`,
 		userID, codeHash, time.Unix(expiresAtUnix, 0).UTC(), now, attemptsLeft, now)
@@ -34,7 +34,7 @@ func (r *OTPRepository) UpsertCode(ctx context.Context, userID, code string, exp
 }
 
 func (r *OTPRepository) VerifyCode(ctx context.Context, userID, code string, nowUnix int64) (bool, error) {
-	row := r.db.QueryRowContext(ctx, `SELECT code_hash, expires_at, attempts FROM otp_codes WHERE user_id = $1`, userID)
+	row := conn(ctx, r.db).QueryRowContext(ctx, `SELECT code_hash, expires_at, attempts FROM otp_codes WHERE user_id = $1`, userID)
 	var storedHash string
 	var expiresAt time.Time
 	var attemptsLeft int
@@ -64,7 +64,7 @@ func (r *OTPRepository) VerifyCode(ctx context.Context, userID, code string, now
 			}
 			return false, nil
 		}
-		_, err := r.db.ExecContext(ctx, `UPDATE otp_codes SET attempts = $1 WHERE user_id = $2`, attemptsLeft, userID)
+		_, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE otp_codes SET attempts = $1 WHERE user_id = $2`, attemptsLeft, userID)
 		if err != nil {
 			return false, common.NewError(common.CodeInternal, "failed to update otp attempts", err)
 		}
@@ -74,7 +74,7 @@ func (r *OTPRepository) VerifyCode(ctx context.Context, userID, code string, now
 }
 
 func (r *OTPRepository) GetState(ctx context.Context, userID string) (*auth.OTPState, error) {
-	row := r.db.QueryRowContext(ctx, `SELECT user_id, attempts, expires_at, requested_at FROM otp_codes WHERE user_id = $1`, userID)
+	row := conn(ctx, r.db).QueryRowContext(ctx, `SELECT user_id, attempts, expires_at, requested_at FROM otp_codes WHERE user_id = $1`, userID)
 	var state auth.OTPState
 	var expiresAt time.Time
 	var requestedAt time.Time
@@ -90,7 +90,7 @@ func (r *OTPRepository) GetState(ctx context.Context, userID string) (*auth.OTPS
 }
 
 func (r *OTPRepository) InvalidateCode(ctx context.Context, userID string) error {
-	_, err := r.db.ExecContext(ctx, `DELETE FROM otp_codes WHERE user_id = $1`, userID)
+	_, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM otp_codes WHERE user_id = $1`, userID)
 	if err != nil {
 		return common.NewError(common.CodeInternal, "failed to invalidate otp", err)
 	}
@@ -98,7 +98,7 @@ func (r *OTPRepository) InvalidateCode(ctx context.Context, userID string) error
 }
 
 func (r *OTPRepository) DeleteExpired(ctx context.Context, beforeUnix int64) error {
-	_, err := r.db.ExecContext(ctx, `DELETE FROM otp_codes WHERE expires_at < $1`, time.Unix(beforeUnix, 0).UTC())
+	_, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM otp_codes WHERE expires_at < $1`, time.Unix(beforeUnix, 0).UTC())
 	if err != nil {
 		return common.NewError(common.CodeInternal, "failed to delete expired otp", err)
 	}
@@ -115,7 +115,7 @@ func NewRefreshTokenRepository(db *sql.DB) *RefreshTokenRepository {
 
 func (r *RefreshTokenRepository) Store(ctx context.Context, token auth.RefreshToken) error {
 	hash := hashToken(token.Token)
-	_, err := r.db.ExecContext(ctx, `INSERT INTO refresh_tokens (id, user_id, token_hash, expires_at, created_at)
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO refresh_tokens (id, user_id, token_hash, expires_at, created_at)
 		VALUES ($1, $2, $3, $4, $5)`,
 		token.ID, token.UserID, hash, token.ExpiresAt, token.CreatedAt)
 	if err != nil {
@@ -126,7 +126,7 @@ func (r *RefreshTokenRepository) Store(ctx context.Context, token auth.RefreshTo
 
 func (r *RefreshTokenRepository) GetByToken(ctx context.Context, token string) (*auth.RefreshToken, error) {
 	hash := hashToken(token)
-	row := r.db.QueryRowContext(ctx, `SELECT id, user_id, token_hash, expires_at, created_at, revoked_at FROM refresh_tokens WHERE token_hash = $1`, hash)
+	row := conn(ctx, r.db).QueryRowContext(ctx, `SELECT id, user_id, token_hash, expires_at, created_at, revoked_at FROM refresh_tokens WHERE token_hash = $1`, hash)
 	var rt auth.RefreshToken
 	var tokenHash string
 	if err := row.Scan(&rt.ID, &rt.UserID, &tokenHash, &rt.ExpiresAt, &rt.CreatedAt, &rt.RevokedAt); err != nil {
@@ -141,7 +141,7 @@ func (r *RefreshTokenRepository) GetByToken(ctx context.Context, token string) (
 
 func (r *RefreshTokenRepository) Revoke(ctx context.Context, token string, revokedAtUnix int64) error {
 	hash := hashToken(token)
-	_, err := r.db.ExecContext(ctx, `UPDATE refresh_tokens SET revoked_at = $1 WHERE token_hash = $2`, time.Unix(revokedAtUnix, 0).UTC(), hash)
+	_, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE refresh_tokens SET revoked_at = $1 WHERE token_hash = $2`, time.Unix(revokedAtUnix, 0).UTC(), hash)
 	if err != nil {
 		return common.NewError(common.CodeInternal, "failed to revoke refresh token", err)
 	}
@@ -149,7 +149,7 @@ func (r *RefreshTokenRepository) Revoke(ctx context.Context, token string, revok
 }
 
 func (r *RefreshTokenRepository) RevokeAll(ctx context.Context, userID common.UUID, revokedAtUnix int64) error {
-	_, err := r.db.ExecContext(ctx, `UPDATE refresh_tokens SET revoked_at = $1 WHERE user_id = $2 AND revoked_at IS NULL`, time.Unix(revokedAtUnix, 0).UTC(), userID)
+	_, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE refresh_tokens SET revoked_at = $1 WHERE user_id = $2 AND revoked_at IS NULL`, time.Unix(revokedAtUnix, 0).UTC(), userID)
 	if err != nil {
 		return common.NewError(common.CodeInternal, "failed to revoke refresh tokens", err)
 	}
@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/user"
+)
+
+type BlockListRepository struct {
+	db *sql.DB
+}
+
+func NewBlockListRepository(db *sql.DB) *BlockListRepository {
+	return &BlockListRepository{db: db}
+}
+
+func (r *BlockListRepository) Add(ctx context.Context, blockerID, blockedID common.UUID) (*user.BlockedUser, error) {
+	blocked := user.BlockedUser{BlockerID: blockerID, BlockedID: blockedID}
+	err := conn(ctx, r.db).QueryRowContext(ctx, `INSERT INTO user_blocks (blocker_id, blocked_id, created_at)
+		SELECT $1, id, $3 FROM users WHERE id = $2
+		ON CONFLICT (blocker_id, blocked_id) DO UPDATE SET created_at = user_blocks.created_at
+		RETURNING created_at`, blockerID, blockedID, time.Now().UTC()).Scan(&blocked.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, common.NewError(common.CodeNotFound, "user not found", err)
+		}
+		return nil, common.NewError(common.CodeInternal, "failed to block user", err)
+	}
+	return &blocked, nil
+}
+
+func (r *BlockListRepository) Remove(ctx context.Context, blockerID, blockedID common.UUID) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM user_blocks WHERE blocker_id = $1 AND blocked_id = $2`, blockerID, blockedID)
+	if err != nil {
+		return common.NewError(common.CodeInternal, "failed to unblock user", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return common.NewError(common.CodeInternal, "failed to unblock user", err)
+	}
+	if affected == 0 {
+		return common.NewError(common.CodeNotFound, "blocked user not found", nil)
+	}
+	return nil
+}
+
+func (r *BlockListRepository) List(ctx context.Context, blockerID common.UUID, page common.PageRequest) ([]user.BlockedUser, *common.Cursor, error) {
+	args := []interface{}{blockerID}
+	condition := "blocker_id = $1"
+	if page.After != nil {
+		args = append(args, page.After.CreatedAt, page.After.ID)
+		condition += " AND (created_at, blocked_id) < ($2, $3)"
+	}
+	args = append(args, page.Limit+1)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, fmt.Sprintf(`SELECT blocker_id, blocked_id, created_at FROM user_blocks
+		WHERE %s ORDER BY created_at DESC, blocked_id DESC LIMIT $%d`, condition, len(args)), args...)
+	if err != nil {
+		return nil, nil, common.NewError(common.CodeInternal, "failed to list blocked users", err)
+	}
+	defer rows.Close()
+	var items []user.BlockedUser
+	for rows.Next() {
+		var item user.BlockedUser
+		if err := rows.Scan(&item.BlockerID, &item.BlockedID, &item.CreatedAt); err != nil {
+			return nil, nil, common.NewError(common.CodeInternal, "failed to scan blocked user", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, common.NewError(common.CodeInternal, "failed to list blocked users", err)
+	}
+	items, next := trimPage(items, page.Limit, func(item user.BlockedUser) common.Cursor {
+		return common.Cursor{CreatedAt: item.CreatedAt, ID: item.BlockedID}
+	})
+	return items, next, nil
+}
+
+func (r *BlockListRepository) Between(ctx context.Context, a, b common.UUID) (bool, error) {
+	var exists bool
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM user_blocks
+		WHERE (blocker_id = $1 AND blocked_id = $2) OR (blocker_id = $2 AND blocked_id = $1))`, a, b).Scan(&exists)
+	if err != nil {
+		return false, common.NewError(common.CodeInternal, "failed to check block list", err)
+	}
+	return exists, nil
+}
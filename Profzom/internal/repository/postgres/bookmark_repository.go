@@ -0,0 +1,124 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/vacancy"
+)
+
+type BookmarkRepository struct {
+	db *sql.DB
+}
+
+func NewBookmarkRepository(db *sql.DB) *BookmarkRepository {
+	return &BookmarkRepository{db: db}
+}
+
+func (r *BookmarkRepository) Save(ctx context.Context, studentID, vacancyID common.UUID) (*vacancy.Bookmark, error) {
+	bookmark := vacancy.Bookmark{StudentID: studentID, VacancyID: vacancyID}
+	err := conn(ctx, r.db).QueryRowContext(ctx, `INSERT INTO saved_vacancies (student_id, vacancy_id, vacancy_updated_at, saved_at)
+		SELECT $1, id, updated_at, $3 FROM vacancies WHERE id = $2
+		ON CONFLICT (student_id, vacancy_id) DO UPDATE SET vacancy_updated_at = EXCLUDED.vacancy_updated_at
+		RETURNING vacancy_updated_at, saved_at`, studentID, vacancyID, time.Now().UTC()).Scan(&bookmark.VacancyUpdatedAt, &bookmark.SavedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, common.NewError(common.CodeNotFound, "vacancy not found", err)
+		}
+		return nil, common.NewError(common.CodeInternal, "failed to save vacancy", err)
+	}
+	return &bookmark, nil
+}
+
+func (r *BookmarkRepository) Delete(ctx context.Context, studentID, vacancyID common.UUID) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM saved_vacancies WHERE student_id = $1 AND vacancy_id = $2`, studentID, vacancyID)
+	if err != nil {
+		return common.NewError(common.CodeInternal, "failed to delete saved vacancy", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return common.NewError(common.CodeInternal, "failed to delete saved vacancy", err)
+	}
+	if affected == 0 {
+		return common.NewError(common.CodeNotFound, "saved vacancy not found", nil)
+	}
+	return nil
+}
+
+func (r *BookmarkRepository) List(ctx context.Context, studentID common.UUID, page common.PageRequest) ([]vacancy.SavedVacancy, *common.Cursor, error) {
+	args := []interface{}{studentID}
+	condition := "s.student_id = $1"
+	if page.After != nil {
+		args = append(args, page.After.CreatedAt, page.After.ID)
+		condition += " AND (s.saved_at, s.vacancy_id) < ($2, $3)"
+	}
+	args = append(args, page.Limit+1)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, fmt.Sprintf(`SELECT %s, s.saved_at, s.vacancy_updated_at
+		FROM saved_vacancies s JOIN vacancies v ON v.id = s.vacancy_id
+		WHERE %s ORDER BY s.saved_at DESC, s.vacancy_id DESC LIMIT $%d`, qualifiedVacancyColumns("v"), condition, len(args)), args...)
+	if err != nil {
+		return nil, nil, common.NewError(common.CodeInternal, "failed to list saved vacancies", err)
+	}
+	defer rows.Close()
+	var items []vacancy.SavedVacancy
+	for rows.Next() {
+		var item vacancy.SavedVacancy
+		var savedVersion time.Time
+		if err := scanVacancy(rows, &item.Vacancy, &item.SavedAt, &savedVersion); err != nil {
+			return nil, nil, common.NewError(common.CodeInternal, "failed to scan saved vacancy", err)
+		}
+		item.Changed = item.Vacancy.UpdatedAt.After(savedVersion)
+		item.Closed = item.Vacancy.Status != vacancy.StatusPublished
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, common.NewError(common.CodeInternal, "failed to list saved vacancies", err)
+	}
+	items, next := trimPage(items, page.Limit, func(item vacancy.SavedVacancy) common.Cursor {
+		return common.Cursor{CreatedAt: item.SavedAt, ID: item.Vacancy.ID}
+	})
+	return items, next, nil
+}
+
+func (r *BookmarkRepository) SavedIDs(ctx context.Context, studentID common.UUID, vacancyIDs []common.UUID) (map[common.UUID]bool, error) {
+	saved := make(map[common.UUID]bool, len(vacancyIDs))
+	if len(vacancyIDs) == 0 {
+		return saved, nil
+	}
+	ids := make([]string, 0, len(vacancyIDs))
+	for _, id := range vacancyIDs {
+		ids = append(ids, id.String())
+	}
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT vacancy_id FROM saved_vacancies WHERE student_id = $1 AND vacancy_id = ANY($2::uuid[])`, studentID, pq.Array(ids))
+	if err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to load saved vacancies", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id common.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, common.NewError(common.CodeInternal, "failed to scan saved vacancy", err)
+		}
+		saved[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to load saved vacancies", err)
+	}
+	return saved, nil
+}
+
+// qualifiedVacancyColumns добавляет алиас таблицы к vacancyColumns для запросов с JOIN; бейдж company_verified идёт последним, как в vacancySelectColumns.
+func qualifiedVacancyColumns(alias string) string {
+	columns := strings.Split(vacancyColumns, ", ")
+	for i, column := range columns {
+		columns[i] = alias + "." + column
+	}
+	return strings.Join(append(columns, companyVerifiedColumn(alias)), ", ")
+}
@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/interview"
+)
+
+type InterviewRepository struct {
+	db *sql.DB
+}
+
+func NewInterviewRepository(db *sql.DB) *InterviewRepository {
+	return &InterviewRepository{db: db}
+}
+
+// Propose создаёт собеседование или перезаписывает предложение целиком: прежние слоты и выбор удаляются.
+func (r *InterviewRepository) Propose(ctx context.Context, iv interview.Interview) (*interview.Interview, error) {
+	now := time.Now().UTC()
+	err := runInTx(ctx, r.db, func(tx *sql.Tx) error {
+		var id common.UUID
+		err := tx.QueryRowContext(ctx, `INSERT INTO interviews (id, application_id, status, format, location, timezone, proposed_by, selected_slot_id, cancelled_by, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, NULL, NULL, $8, $8)
+			ON CONFLICT (application_id) DO UPDATE SET status = EXCLUDED.status, format = EXCLUDED.format, location = EXCLUDED.location,
+				timezone = EXCLUDED.timezone, proposed_by = EXCLUDED.proposed_by, selected_slot_id = NULL, cancelled_by = NULL, updated_at = EXCLUDED.updated_at
+			RETURNING id`,
+			common.NewUUID(), iv.ApplicationID, iv.Status, iv.Format, iv.Location, iv.Timezone, iv.ProposedBy, now).Scan(&id)
+		if err != nil {
+			return common.NewError(common.CodeInternal, "failed to store interview", err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM interview_slots WHERE interview_id = $1`, id); err != nil {
+			return common.NewError(common.CodeInternal, "failed to replace interview slots", err)
+		}
+		for _, slot := range iv.Slots {
+			_, err := tx.ExecContext(ctx, `INSERT INTO interview_slots (id, interview_id, starts_at, ends_at) VALUES ($1, $2, $3, $4)`,
+				common.NewUUID(), id, slot.StartsAt.UTC(), slot.EndsAt.UTC())
+			if err != nil {
+				return common.NewError(common.CodeInternal, "failed to store interview slot", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByApplication(ctx, iv.ApplicationID)
+}
+
+func (r *InterviewRepository) GetByApplication(ctx context.Context, applicationID common.UUID) (*interview.Interview, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `SELECT id, application_id, status, format, location, timezone, proposed_by, selected_slot_id, cancelled_by, created_at, updated_at
+		FROM interviews WHERE application_id = $1`, applicationID)
+	var iv interview.Interview
+	var proposedBy, selectedSlotID, cancelledBy sql.NullString
+	if err := row.Scan(&iv.ID, &iv.ApplicationID, &iv.Status, &iv.Format, &iv.Location, &iv.Timezone, &proposedBy, &selectedSlotID, &cancelledBy, &iv.CreatedAt, &iv.UpdatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, common.NewError(common.CodeNotFound, "interview not found", err)
+		}
+		return nil, common.NewError(common.CodeInternal, "failed to load interview", err)
+	}
+	iv.ProposedBy = common.UUID(proposedBy.String)
+	iv.SelectedSlotID = nullUUID(selectedSlotID)
+	iv.CancelledBy = nullUUID(cancelledBy)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT id, starts_at, ends_at FROM interview_slots WHERE interview_id = $1 ORDER BY starts_at ASC, id ASC`, iv.ID)
+	if err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to load interview slots", err)
+	}
+	defer rows.Close()
+	iv.Slots = []interview.Slot{}
+	for rows.Next() {
+		var slot interview.Slot
+		if err := rows.Scan(&slot.ID, &slot.StartsAt, &slot.EndsAt); err != nil {
+			return nil, common.NewError(common.CodeInternal, "failed to scan interview slot", err)
+		}
+		iv.Slots = append(iv.Slots, slot)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to load interview slots", err)
+	}
+	return &iv, nil
+}
+
+// UpdateState сохраняет статус, выбранный слот и отменившую сторону; слоты не меняются.
+func (r *InterviewRepository) UpdateState(ctx context.Context, iv interview.Interview) (*interview.Interview, error) {
+	var selectedSlotID, cancelledBy interface{}
+	if iv.SelectedSlotID != nil {
+		selectedSlotID = *iv.SelectedSlotID
+	}
+	if iv.CancelledBy != nil {
+		cancelledBy = *iv.CancelledBy
+	}
+	result, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE interviews SET status = $1, selected_slot_id = $2, cancelled_by = $3, updated_at = $4 WHERE id = $5`,
+		iv.Status, selectedSlotID, cancelledBy, time.Now().UTC(), iv.ID)
+	if err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to update interview", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return nil, common.NewError(common.CodeNotFound, "interview not found", nil)
+	}
+	return r.GetByApplication(ctx, iv.ApplicationID)
+}
+
+func nullUUID(value sql.NullString) *common.UUID {
+	if !value.Valid {
+		return nil
+	}
+	id := common.UUID(value.String)
+	return &id
+}
@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/invitation"
+)
+
+const invitationColumns = "id, vacancy_id, company_id, student_id, message, status, application_id, responded_at, created_at, updated_at"
+
+type InvitationRepository struct {
+	db *sql.DB
+}
+
+func NewInvitationRepository(db *sql.DB) *InvitationRepository {
+	return &InvitationRepository{db: db}
+}
+
+func scanInvitation(row rowScanner, inv *invitation.Invitation) error {
+	var applicationID sql.NullString
+	var respondedAt sql.NullTime
+	if err := row.Scan(&inv.ID, &inv.VacancyID, &inv.CompanyID, &inv.StudentID, &inv.Message, &inv.Status, &applicationID, &respondedAt, &inv.CreatedAt, &inv.UpdatedAt); err != nil {
+		return err
+	}
+	inv.ApplicationID = nullUUID(applicationID)
+	inv.RespondedAt = nil
+	if respondedAt.Valid {
+		value := respondedAt.Time.UTC()
+		inv.RespondedAt = &value
+	}
+	return nil
+}
+
+func (r *InvitationRepository) Create(ctx context.Context, inv invitation.Invitation) (*invitation.Invitation, error) {
+	inv.ID = common.NewUUID()
+	inv.CreatedAt = time.Now().UTC()
+	inv.UpdatedAt = inv.CreatedAt
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO invitations (`+invitationColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, NULL, NULL, $7, $7)`,
+		inv.ID, inv.VacancyID, inv.CompanyID, inv.StudentID, inv.Message, inv.Status, inv.CreatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, common.NewError(common.CodeConflict, "invitation already sent", err)
+		}
+		return nil, common.NewError(common.CodeInternal, "failed to create invitation", err)
+	}
+	return &inv, nil
+}
+
+func (r *InvitationRepository) GetByID(ctx context.Context, id common.UUID) (*invitation.Invitation, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+invitationColumns+` FROM invitations WHERE id = $1`, id)
+	return r.scanOne(row)
+}
+
+func (r *InvitationRepository) FindPending(ctx context.Context, vacancyID, studentID common.UUID) (*invitation.Invitation, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+invitationColumns+` FROM invitations
+		WHERE vacancy_id = $1 AND student_id = $2 AND status = $3`, vacancyID, studentID, invitation.StatusPending)
+	return r.scanOne(row)
+}
+
+func (r *InvitationRepository) scanOne(row *sql.Row) (*invitation.Invitation, error) {
+	var inv invitation.Invitation
+	if err := scanInvitation(row, &inv); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, common.NewError(common.CodeNotFound, "invitation not found", err)
+		}
+		return nil, common.NewError(common.CodeInternal, "failed to load invitation", err)
+	}
+	return &inv, nil
+}
+
+func (r *InvitationRepository) ListByStudent(ctx context.Context, studentID common.UUID, page common.PageRequest) ([]invitation.Invitation, *common.Cursor, error) {
+	return r.list(ctx, "student_id", studentID, page)
+}
+
+func (r *InvitationRepository) ListByCompany(ctx context.Context, companyID common.UUID, page common.PageRequest) ([]invitation.Invitation, *common.Cursor, error) {
+	return r.list(ctx, "company_id", companyID, page)
+}
+
+func (r *InvitationRepository) list(ctx context.Context, column string, ownerID common.UUID, page common.PageRequest) ([]invitation.Invitation, *common.Cursor, error) {
+	args := []interface{}{ownerID}
+	condition := column + " = $1"
+	if page.After != nil {
+		args = append(args, page.After.CreatedAt, page.After.ID)
+		condition += " AND (created_at, id) < ($2, $3)"
+	}
+	args = append(args, page.Limit+1)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, fmt.Sprintf(`SELECT %s
+		FROM invitations WHERE %s ORDER BY created_at DESC, id DESC LIMIT $%d`, invitationColumns, condition, len(args)), args...)
+	if err != nil {
+		return nil, nil, common.NewError(common.CodeInternal, "failed to list invitations", err)
+	}
+	defer rows.Close()
+	var items []invitation.Invitation
+	for rows.Next() {
+		var inv invitation.Invitation
+		if err := scanInvitation(rows, &inv); err != nil {
+			return nil, nil, common.NewError(common.CodeInternal, "failed to scan invitation", err)
+		}
+		items = append(items, inv)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, common.NewError(common.CodeInternal, "failed to list invitations", err)
+	}
+	items, next := trimPage(items, page.Limit, func(inv invitation.Invitation) common.Cursor {
+		return common.Cursor{CreatedAt: inv.CreatedAt, ID: inv.ID}
+	})
+	return items, next, nil
+}
+
+// UpdateStatus меняет только pending-приглашение, поэтому одновременные ответ студента и отзыв компанией не перезапишут друг друга.
+func (r *InvitationRepository) UpdateStatus(ctx context.Context, inv invitation.Invitation) (*invitation.Invitation, error) {
+	now := time.Now().UTC()
+	row := conn(ctx, r.db).QueryRowContext(ctx, `UPDATE invitations SET status = $1, application_id = $2, responded_at = $3, updated_at = $3
+		WHERE id = $4 AND status = $5
+		RETURNING `+invitationColumns,
+		inv.Status, nullableUUIDPtr(inv.ApplicationID), now, inv.ID, invitation.StatusPending)
+	var updated invitation.Invitation
+	if err := scanInvitation(row, &updated); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, common.NewError(common.CodeConflict, "invitation is no longer pending", err)
+		}
+		return nil, common.NewError(common.CodeInternal, "failed to update invitation", err)
+	}
+	return &updated, nil
+}
+
+func nullableUUIDPtr(id *common.UUID) interface{} {
+	if id == nil {
+		return nil
+	}
+	return nullableUUID(*id)
+}
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
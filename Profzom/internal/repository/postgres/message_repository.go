@@ -4,12 +4,35 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"time"
 
+	"github.com/lib/pq"
+
 	"profzom/internal/common"
 	"profzom/internal/domain/message"
 )
 
+const messageColumns = `id, application_id, sender_id, kind, body, moderation_status, created_at`
+
+func scanMessage(row rowScanner, msg *message.Message) error {
+	var senderID sql.NullString
+	if err := row.Scan(&msg.ID, &msg.ApplicationID, &senderID, &msg.Kind, &msg.Body, &msg.Moderation, &msg.CreatedAt); err != nil {
+		return err
+	}
+	if senderID.Valid {
+		msg.SenderID = common.UUID(senderID.String)
+	}
+	return nil
+}
+
+func nullableUUID(id common.UUID) interface{} {
+	if id == "" {
+		return nil
+	}
+	return id
+}
+
 type MessageRepository struct {
 	db *sql.DB
 }
@@ -21,35 +44,63 @@ func NewMessageRepository(db *sql.DB) *MessageRepository {
 func (r *MessageRepository) Create(ctx context.Context, msg message.Message) (*message.Message, error) {
 	msg.ID = common.NewUUID()
 	msg.CreatedAt = time.Now().UTC()
-	_, err := r.db.ExecContext(ctx, `INSERT INTO messages (id, application_id, sender_id, body, created_at)
-		VALUES ($1, $2, $3, $4, $5)`, msg.ID, msg.ApplicationID, msg.SenderID, msg.Body, msg.CreatedAt)
+	if msg.Kind == "" {
+		msg.Kind = message.KindUser
+	}
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO messages (id, application_id, sender_id, kind, body, moderation_status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`, msg.ID, msg.ApplicationID, nullableUUID(msg.SenderID), msg.Kind, msg.Body, msg.Moderation, msg.CreatedAt)
 	if err != nil {
 		return nil, common.NewError(common.CodeInternal, "failed to create message", err)
 	}
 	return &msg, nil
 }
 
-func (r *MessageRepository) ListByApplication(ctx context.Context, applicationID common.UUID, limit, offset int) ([]message.Message, error) {
-	rows, err := r.db.QueryContext(ctx, `SELECT id, application_id, sender_id, body, created_at FROM messages WHERE application_id = $1 ORDER BY created_at ASC LIMIT $2 OFFSET $3`, applicationID, limit, offset)
+func (r *MessageRepository) GetByID(ctx context.Context, id common.UUID) (*message.Message, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+messageColumns+` FROM messages WHERE id = $1`, id)
+	var msg message.Message
+	if err := scanMessage(row, &msg); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, common.NewError(common.CodeNotFound, "message not found", err)
+		}
+		return nil, common.NewError(common.CodeInternal, "failed to load message", err)
+	}
+	return &msg, nil
+}
+
+func (r *MessageRepository) ListByApplication(ctx context.Context, applicationID, viewerID common.UUID, page common.PageRequest) ([]message.Message, *common.Cursor, error) {
+	args := []interface{}{applicationID, nullableUUID(viewerID)}
+	condition := "application_id = $1 AND (moderation_status = '' OR $2::uuid IS NULL OR sender_id = $2)"
+	if page.After != nil {
+		args = append(args, page.After.CreatedAt, page.After.ID)
+		condition += " AND (created_at, id) > ($3, $4)"
+	}
+	args = append(args, page.Limit+1)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, fmt.Sprintf(`SELECT %s FROM messages WHERE %s ORDER BY created_at ASC, id ASC LIMIT $%d`, messageColumns, condition, len(args)), args...)
 	if err != nil {
-		return nil, common.NewError(common.CodeInternal, "failed to list messages", err)
+		return nil, nil, common.NewError(common.CodeInternal, "failed to list messages", err)
 	}
 	defer rows.Close()
 	var items []message.Message
 	for rows.Next() {
 		var msg message.Message
-		if err := rows.Scan(&msg.ID, &msg.ApplicationID, &msg.SenderID, &msg.Body, &msg.CreatedAt); err != nil {
-			return nil, common.NewError(common.CodeInternal, "failed to scan message", err)
+		if err := scanMessage(rows, &msg); err != nil {
+			return nil, nil, common.NewError(common.CodeInternal, "failed to scan message", err)
 		}
 		items = append(items, msg)
 	}
-	return items, nil
+	if err := rows.Err(); err != nil {
+		return nil, nil, common.NewError(common.CodeInternal, "failed to list messages", err)
+	}
+	items, next := trimPage(items, page.Limit, func(msg message.Message) common.Cursor {
+		return common.Cursor{CreatedAt: msg.CreatedAt, ID: msg.ID}
+	})
+	return items, next, nil
 }
 
 func (r *MessageRepository) LatestByApplication(ctx context.Context, applicationID common.UUID) (*message.Message, error) {
-	row := r.db.QueryRowContext(ctx, `SELECT id, application_id, sender_id, body, created_at FROM messages WHERE application_id = $1 ORDER BY created_at DESC LIMIT 1`, applicationID)
+	row := conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+messageColumns+` FROM messages WHERE application_id = $1 ORDER BY created_at DESC LIMIT 1`, applicationID)
 	var msg message.Message
-	if err := row.Scan(&msg.ID, &msg.ApplicationID, &msg.SenderID, &msg.Body, &msg.CreatedAt); err != nil {
+	if err := scanMessage(row, &msg); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, common.NewError(common.CodeNotFound, "message not found", err)
 		}
@@ -57,3 +108,100 @@ func (r *MessageRepository) LatestByApplication(ctx context.Context, application
 	}
 	return &msg, nil
 }
+
+func (r *MessageRepository) SetModeration(ctx context.Context, id common.UUID, status message.ModerationStatus) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE messages SET moderation_status = $1 WHERE id = $2`, status, id)
+	if err != nil {
+		return common.NewError(common.CodeInternal, "failed to update message moderation", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return common.NewError(common.CodeNotFound, "message not found", nil)
+	}
+	return nil
+}
+
+const messageReadColumns = `application_id, user_id, last_read_message_id, last_read_created_at, read_at`
+
+func scanReadState(row rowScanner, state *message.ReadState) error {
+	return row.Scan(&state.ApplicationID, &state.UserID, &state.LastReadMessageID, &state.LastReadCreatedAt, &state.ReadAt)
+}
+
+func (r *MessageRepository) MarkRead(ctx context.Context, applicationID, userID, messageID common.UUID) (*message.ReadState, error) {
+	// отметка сдвигается только на более позднее сообщение, поэтому повторный или запоздавший запрос её не откатывает
+	row := conn(ctx, r.db).QueryRowContext(ctx, `INSERT INTO message_reads (application_id, user_id, last_read_message_id, last_read_created_at, read_at)
+		SELECT m.application_id, $2, m.id, m.created_at, $4 FROM messages m WHERE m.id = $3 AND m.application_id = $1
+		ON CONFLICT (application_id, user_id) DO UPDATE SET
+			last_read_message_id = EXCLUDED.last_read_message_id,
+			last_read_created_at = EXCLUDED.last_read_created_at,
+			read_at = EXCLUDED.read_at
+		WHERE (message_reads.last_read_created_at, message_reads.last_read_message_id) < (EXCLUDED.last_read_created_at, EXCLUDED.last_read_message_id)
+		RETURNING `+messageReadColumns, applicationID, userID, messageID, time.Now().UTC())
+	var state message.ReadState
+	err := scanReadState(row, &state)
+	if err == nil {
+		return &state, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, common.NewError(common.CodeInternal, "failed to mark messages read", err)
+	}
+	// строка не вернулась: либо сообщения нет в переписке, либо отметка уже дальше
+	row = conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+messageReadColumns+` FROM message_reads r
+		WHERE r.application_id = $1 AND r.user_id = $2 AND EXISTS (SELECT 1 FROM messages m WHERE m.id = $3 AND m.application_id = $1)`, applicationID, userID, messageID)
+	if err := scanReadState(row, &state); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, common.NewError(common.CodeNotFound, "message not found", err)
+		}
+		return nil, common.NewError(common.CodeInternal, "failed to load read state", err)
+	}
+	return &state, nil
+}
+
+func (r *MessageRepository) ReadStates(ctx context.Context, applicationID common.UUID) ([]message.ReadState, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT `+messageReadColumns+` FROM message_reads WHERE application_id = $1`, applicationID)
+	if err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to load read states", err)
+	}
+	defer rows.Close()
+	var states []message.ReadState
+	for rows.Next() {
+		var state message.ReadState
+		if err := scanReadState(rows, &state); err != nil {
+			return nil, common.NewError(common.CodeInternal, "failed to scan read state", err)
+		}
+		states = append(states, state)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to load read states", err)
+	}
+	return states, nil
+}
+
+func (r *MessageRepository) CountUnread(ctx context.Context, userID common.UUID, applicationIDs []common.UUID) (map[common.UUID]int, error) {
+	counts := make(map[common.UUID]int, len(applicationIDs))
+	if len(applicationIDs) == 0 {
+		return counts, nil
+	}
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT m.application_id, count(*) FROM messages m
+		LEFT JOIN message_reads r ON r.application_id = m.application_id AND r.user_id = $1
+		WHERE m.application_id = ANY($2::uuid[])
+			AND (m.sender_id IS NULL OR m.sender_id <> $1)
+			AND m.moderation_status = ''
+			AND (r.user_id IS NULL OR (m.created_at, m.id) > (r.last_read_created_at, r.last_read_message_id))
+		GROUP BY m.application_id`, userID, pq.Array(uuidStrings(applicationIDs)))
+	if err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to count unread messages", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var applicationID common.UUID
+		var count int
+		if err := rows.Scan(&applicationID, &count); err != nil {
+			return nil, common.NewError(common.CodeInternal, "failed to scan unread count", err)
+		}
+		counts[applicationID] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to count unread messages", err)
+	}
+	return counts, nil
+}
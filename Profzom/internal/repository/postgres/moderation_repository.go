@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/moderation"
+)
+
+type ModerationRepository struct {
+	db *sql.DB
+}
+
+func NewModerationRepository(db *sql.DB) *ModerationRepository {
+	return &ModerationRepository{db: db}
+}
+
+const moderationColumns = `id, content_type, content_id, author_id, text, reasons, status, review_reason, reviewed_by, reviewed_at, created_at, updated_at`
+
+func scanModerationItem(row rowScanner, item *moderation.Item) error {
+	var authorID, reviewedBy sql.NullString
+	var reviewedAt sql.NullTime
+	var reasons []string
+	if err := row.Scan(&item.ID, &item.ContentType, &item.ContentID, &authorID, &item.Text, pq.Array(&reasons), &item.Status, &item.ReviewReason, &reviewedBy, &reviewedAt, &item.CreatedAt, &item.UpdatedAt); err != nil {
+		return err
+	}
+	item.AuthorID = common.UUID(authorID.String)
+	item.Reasons = reasons
+	if item.Reasons == nil {
+		item.Reasons = []string{}
+	}
+	item.ReviewedBy = nullUUID(reviewedBy)
+	item.ReviewedAt = nil
+	if reviewedAt.Valid {
+		value := reviewedAt.Time
+		item.ReviewedAt = &value
+	}
+	return nil
+}
+
+func (r *ModerationRepository) Enqueue(ctx context.Context, item moderation.Item) (*moderation.Item, error) {
+	now := time.Now().UTC()
+	if item.ID == "" {
+		item.ID = common.NewUUID()
+	}
+	if item.Reasons == nil {
+		item.Reasons = []string{}
+	}
+	row := conn(ctx, r.db).QueryRowContext(ctx, `INSERT INTO moderation_items (id, content_type, content_id, author_id, text, reasons, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8)
+		ON CONFLICT (content_type, content_id) WHERE status = 'pending'
+		DO UPDATE SET text = EXCLUDED.text, reasons = EXCLUDED.reasons, updated_at = EXCLUDED.updated_at
+		RETURNING `+moderationColumns,
+		item.ID, item.ContentType, item.ContentID, nullableUUID(item.AuthorID), item.Text, pq.Array(item.Reasons), moderation.StatusPending, now)
+	var saved moderation.Item
+	if err := scanModerationItem(row, &saved); err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to enqueue moderation item", err)
+	}
+	return &saved, nil
+}
+
+func (r *ModerationRepository) GetByID(ctx context.Context, id common.UUID) (*moderation.Item, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+moderationColumns+` FROM moderation_items WHERE id = $1`, id)
+	var item moderation.Item
+	if err := scanModerationItem(row, &item); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, common.NewError(common.CodeNotFound, "moderation item not found", err)
+		}
+		return nil, common.NewError(common.CodeInternal, "failed to load moderation item", err)
+	}
+	return &item, nil
+}
+
+func (r *ModerationRepository) Save(ctx context.Context, item moderation.Item) (*moderation.Item, error) {
+	seen := item.UpdatedAt
+	item.UpdatedAt = time.Now().UTC()
+	row := conn(ctx, r.db).QueryRowContext(ctx, `UPDATE moderation_items SET status = $1, review_reason = $2, reviewed_by = $3, reviewed_at = $4, updated_at = $5
+		WHERE id = $6 AND status = $7 AND updated_at = $8
+		RETURNING id`,
+		item.Status, item.ReviewReason, nullableUUIDPtr(item.ReviewedBy), item.ReviewedAt, item.UpdatedAt, item.ID, moderation.StatusPending, seen)
+	var id common.UUID
+	if err := row.Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, common.NewError(common.CodeConflict, "moderation item was changed or reviewed concurrently", err)
+		}
+		return nil, common.NewError(common.CodeInternal, "failed to save moderation item", err)
+	}
+	return &item, nil
+}
+
+func (r *ModerationRepository) ListByStatus(ctx context.Context, status moderation.Status, contentType moderation.ContentType, page common.PageRequest) ([]moderation.Item, *common.Cursor, error) {
+	args := []interface{}{status}
+	condition := "status = $1"
+	if contentType != "" {
+		args = append(args, contentType)
+		condition += fmt.Sprintf(" AND content_type = $%d", len(args))
+	}
+	if page.After != nil {
+		args = append(args, page.After.CreatedAt, page.After.ID)
+		condition += fmt.Sprintf(" AND (created_at, id) > ($%d, $%d)", len(args)-1, len(args))
+	}
+	args = append(args, page.Limit+1)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, fmt.Sprintf(`SELECT %s FROM moderation_items WHERE %s ORDER BY created_at, id LIMIT $%d`, moderationColumns, condition, len(args)), args...)
+	if err != nil {
+		return nil, nil, common.NewError(common.CodeInternal, "failed to list moderation items", err)
+	}
+	defer rows.Close()
+	var items []moderation.Item
+	for rows.Next() {
+		var item moderation.Item
+		if err := scanModerationItem(rows, &item); err != nil {
+			return nil, nil, common.NewError(common.CodeInternal, "failed to scan moderation item", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, common.NewError(common.CodeInternal, "failed to list moderation items", err)
+	}
+	items, next := trimPage(items, page.Limit, func(item moderation.Item) common.Cursor {
+		return common.Cursor{CreatedAt: item.CreatedAt, ID: item.ID}
+	})
+	return items, next, nil
+}
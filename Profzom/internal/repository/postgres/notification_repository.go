@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/notification"
+)
+
+type NotificationRepository struct {
+	db *sql.DB
+}
+
+func NewNotificationRepository(db *sql.DB) *NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+func (r *NotificationRepository) Create(ctx context.Context, n notification.Notification) error {
+	if n.ID == "" {
+		n.ID = common.NewUUID()
+	}
+	if n.CreatedAt.IsZero() {
+		n.CreatedAt = time.Now().UTC()
+	}
+	payload := n.Payload
+	if payload == nil {
+		payload = map[string]string{}
+	}
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return common.NewError(common.CodeInternal, "failed to encode notification payload", err)
+	}
+	_, err = conn(ctx, r.db).ExecContext(ctx, `INSERT INTO notifications (id, user_id, type, text, payload, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (id) DO NOTHING`, n.ID, n.UserID, n.Type, n.Text, encoded, n.CreatedAt)
+	if err != nil {
+		return common.NewError(common.CodeInternal, "failed to create notification", err)
+	}
+	return nil
+}
+
+func (r *NotificationRepository) List(ctx context.Context, userID common.UUID, unreadOnly bool, page common.PageRequest) ([]notification.Notification, *common.Cursor, error) {
+	args := []interface{}{userID}
+	condition := "user_id = $1"
+	if unreadOnly {
+		condition += " AND read_at IS NULL"
+	}
+	if page.After != nil {
+		args = append(args, page.After.CreatedAt, page.After.ID)
+		condition += fmt.Sprintf(" AND (created_at, id) < ($%d, $%d)", len(args)-1, len(args))
+	}
+	args = append(args, page.Limit+1)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, fmt.Sprintf(`SELECT id, user_id, type, text, payload, read_at, created_at
+		FROM notifications WHERE %s ORDER BY created_at DESC, id DESC LIMIT $%d`, condition, len(args)), args...)
+	if err != nil {
+		return nil, nil, common.NewError(common.CodeInternal, "failed to list notifications", err)
+	}
+	defer rows.Close()
+	var items []notification.Notification
+	for rows.Next() {
+		var item notification.Notification
+		var payload []byte
+		var readAt sql.NullTime
+		if err := rows.Scan(&item.ID, &item.UserID, &item.Type, &item.Text, &payload, &readAt, &item.CreatedAt); err != nil {
+			return nil, nil, common.NewError(common.CodeInternal, "failed to scan notification", err)
+		}
+		if len(payload) > 0 {
+			if err := json.Unmarshal(payload, &item.Payload); err != nil {
+				return nil, nil, common.NewError(common.CodeInternal, "failed to decode notification payload", err)
+			}
+		}
+		if readAt.Valid {
+			value := readAt.Time.UTC()
+			item.ReadAt = &value
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, common.NewError(common.CodeInternal, "failed to list notifications", err)
+	}
+	items, next := trimPage(items, page.Limit, func(item notification.Notification) common.Cursor {
+		return common.Cursor{CreatedAt: item.CreatedAt, ID: item.ID}
+	})
+	return items, next, nil
+}
+
+func (r *NotificationRepository) MarkRead(ctx context.Context, userID, id common.UUID, readAt time.Time) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE notifications SET read_at = COALESCE(read_at, $1)
+		WHERE id = $2 AND user_id = $3`, readAt, id, userID)
+	if err != nil {
+		return common.NewError(common.CodeInternal, "failed to mark notification read", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return common.NewError(common.CodeInternal, "failed to mark notification read", err)
+	}
+	if affected == 0 {
+		return common.NewError(common.CodeNotFound, "notification not found", nil)
+	}
+	return nil
+}
+
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, userID common.UUID, readAt time.Time) (int, error) {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE notifications SET read_at = $1 WHERE user_id = $2 AND read_at IS NULL`, readAt, userID)
+	if err != nil {
+		return 0, common.NewError(common.CodeInternal, "failed to mark notifications read", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, common.NewError(common.CodeInternal, "failed to mark notifications read", err)
+	}
+	return int(affected), nil
+}
+
+func (r *NotificationRepository) CountUnread(ctx context.Context, userID common.UUID) (int, error) {
+	var count int
+	if err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT count(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL`, userID).Scan(&count); err != nil {
+		return 0, common.NewError(common.CodeInternal, "failed to count notifications", err)
+	}
+	return count, nil
+}
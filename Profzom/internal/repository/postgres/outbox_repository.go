@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/outbox"
+)
+
+type OutboxRepository struct {
+	db *sql.DB
+}
+
+func NewOutboxRepository(db *sql.DB) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// Enqueue пишет в транзакцию из контекста, если она есть: так событие фиксируется вместе с доменной записью.
+func (r *OutboxRepository) Enqueue(ctx context.Context, message outbox.Message) error {
+	if message.ID == "" {
+		message.ID = common.NewUUID()
+	}
+	now := time.Now().UTC()
+	if message.CreatedAt.IsZero() {
+		message.CreatedAt = now
+	}
+	if message.AvailableAt.IsZero() {
+		message.AvailableAt = message.CreatedAt
+	}
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO outbox_messages (id, topic, payload, available_at, created_at) VALUES ($1, $2, $3, $4, $5)`,
+		message.ID, message.Topic, message.Payload, message.AvailableAt, message.CreatedAt)
+	if err != nil {
+		return common.NewError(common.CodeInternal, "failed to enqueue outbox message", err)
+	}
+	return nil
+}
+
+func (r *OutboxRepository) Claim(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]outbox.Message, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `UPDATE outbox_messages SET available_at = $2
+		WHERE id IN (
+			SELECT id FROM outbox_messages
+			WHERE delivered_at IS NULL AND failed_at IS NULL AND available_at <= $1
+			ORDER BY available_at, created_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, topic, payload, attempts, last_error, delivered_sinks, available_at, created_at`, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to claim outbox messages", err)
+	}
+	defer rows.Close()
+	var items []outbox.Message
+	for rows.Next() {
+		var message outbox.Message
+		if err := rows.Scan(&message.ID, &message.Topic, &message.Payload, &message.Attempts, &message.LastError, pq.Array(&message.DeliveredSinks), &message.AvailableAt, &message.CreatedAt); err != nil {
+			return nil, common.NewError(common.CodeInternal, "failed to scan outbox message", err)
+		}
+		items = append(items, message)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to claim outbox messages", err)
+	}
+	return items, nil
+}
+
+func (r *OutboxRepository) MarkSinkDelivered(ctx context.Context, id common.UUID, sink string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE outbox_messages SET delivered_sinks = array_append(delivered_sinks, $2::text)
+		WHERE id = $1 AND NOT ($2::text = ANY(delivered_sinks))`, id, sink)
+	if err != nil {
+		return common.NewError(common.CodeInternal, "failed to mark outbox sink delivered", err)
+	}
+	return nil
+}
+
+func (r *OutboxRepository) MarkDelivered(ctx context.Context, id common.UUID, deliveredAt time.Time) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE outbox_messages SET delivered_at = $2, attempts = attempts + 1, last_error = '' WHERE id = $1`, id, deliveredAt)
+	if err != nil {
+		return common.NewError(common.CodeInternal, "failed to mark outbox message delivered", err)
+	}
+	return nil
+}
+
+func (r *OutboxRepository) Reschedule(ctx context.Context, id common.UUID, attempts int, availableAt time.Time, lastError string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE outbox_messages SET attempts = $2, available_at = $3, last_error = $4 WHERE id = $1`, id, attempts, availableAt, lastError)
+	if err != nil {
+		return common.NewError(common.CodeInternal, "failed to reschedule outbox message", err)
+	}
+	return nil
+}
+
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id common.UUID, attempts int, failedAt time.Time, lastError string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE outbox_messages SET attempts = $2, failed_at = $3, last_error = $4 WHERE id = $1`, id, attempts, failedAt, lastError)
+	if err != nil {
+		return common.NewError(common.CodeInternal, "failed to mark outbox message failed", err)
+	}
+	return nil
+}
+
+func (r *OutboxRepository) PurgeDelivered(ctx context.Context, before time.Time) (int64, error) {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM outbox_messages WHERE delivered_at IS NOT NULL AND delivered_at < $1`, before)
+	if err != nil {
+		return 0, common.NewError(common.CodeInternal, "failed to purge outbox messages", err)
+	}
+	affected, _ := result.RowsAffected()
+	return affected, nil
+}
@@ -0,0 +1,14 @@
+package postgres
+
+import "profzom/internal/common"
+
+// trimPage отбрасывает запись, запрошенную сверх лимита, и строит курсор следующей страницы.
+// Репозитории выбирают limit+1 строк, чтобы понять, есть ли продолжение, без отдельного COUNT.
+func trimPage[T any](items []T, limit int, cursorOf func(T) common.Cursor) ([]T, *common.Cursor) {
+	if limit <= 0 || len(items) <= limit {
+		return items, nil
+	}
+	items = items[:limit]
+	next := cursorOf(items[len(items)-1])
+	return items, &next
+}
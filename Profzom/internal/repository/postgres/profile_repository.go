@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/lib/pq"
@@ -20,11 +22,18 @@ func NewStudentProfileRepository(db *sql.DB) *StudentProfileRepository {
 	return &StudentProfileRepository{db: db}
 }
 
+const studentProfileColumns = "user_id, name, university, course, specialty, bio, skills, visible_to_companies, created_at, updated_at"
+
+func scanStudentProfile(row rowScanner, p *profile.StudentProfile, extra ...interface{}) error {
+	dest := []interface{}{&p.UserID, &p.Name, &p.University, &p.Course, &p.Specialty, &p.About, pq.Array(&p.Skills), &p.VisibleToCompanies, &p.CreatedAt, &p.UpdatedAt}
+	return row.Scan(append(dest, extra...)...)
+}
+
 func (r *StudentProfileRepository) GetByUserID(ctx context.Context, userID common.UUID) (*profile.StudentProfile, error) {
-	row := r.db.QueryRowContext(ctx, `SELECT user_id, name, university, course, specialty, bio, skills, created_at, updated_at
+	row := conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+studentProfileColumns+`
 		FROM student_profiles WHERE user_id = $1`, userID)
 	var p profile.StudentProfile
-	if err := row.Scan(&p.UserID, &p.Name, &p.University, &p.Course, &p.Specialty, &p.About, pq.Array(&p.Skills), &p.CreatedAt, &p.UpdatedAt); err != nil {
+	if err := scanStudentProfile(row, &p); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, common.NewError(common.CodeNotFound, "student profile not found", err)
 		}
@@ -33,14 +42,17 @@ func (r *StudentProfileRepository) GetByUserID(ctx context.Context, userID commo
 	return &p, nil
 }
 
+// Upsert вместе с профилем сохраняет skill_tags — нормализованные навыки, по которым работает поиск кандидатов.
 func (r *StudentProfileRepository) Upsert(ctx context.Context, profile profile.StudentProfile) (*profile.StudentProfile, error) {
 	now := time.Now().UTC()
 	profile.UpdatedAt = now
-	_, err := r.db.ExecContext(ctx, `INSERT INTO student_profiles (user_id, name, university, course, specialty, bio, skills, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO student_profiles (user_id, name, university, course, specialty, bio, skills, skill_tags, visible_to_companies, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		ON CONFLICT (user_id) DO UPDATE SET name = EXCLUDED.name, university = EXCLUDED.university, course = EXCLUDED.course,
-		specialty = EXCLUDED.specialty, bio = EXCLUDED.bio, skills = EXCLUDED.skills, updated_at = EXCLUDED.updated_at`,
-		profile.UserID, profile.Name, profile.University, profile.Course, profile.Specialty, profile.About, pq.Array(profile.Skills), now, now)
+		specialty = EXCLUDED.specialty, bio = EXCLUDED.bio, skills = EXCLUDED.skills, skill_tags = EXCLUDED.skill_tags,
+		visible_to_companies = EXCLUDED.visible_to_companies, updated_at = EXCLUDED.updated_at`,
+		profile.UserID, profile.Name, profile.University, profile.Course, profile.Specialty, profile.About, pq.Array(profile.Skills),
+		pq.Array(normalizeSkillTags(profile.Skills)), profile.VisibleToCompanies, now, now)
 	if err != nil {
 		return nil, common.NewError(common.CodeInternal, "failed to upsert student profile", err)
 	}
@@ -48,6 +60,95 @@ func (r *StudentProfileRepository) Upsert(ctx context.Context, profile profile.S
 	return &profile, nil
 }
 
+// normalizeSkillTags нужна потому, что в Upsert имя profile занято параметром.
+func normalizeSkillTags(skills []string) []string {
+	return profile.NormalizeSkills(skills)
+}
+
+func (r *StudentProfileRepository) Search(ctx context.Context, query profile.CandidateQuery) ([]profile.StudentProfile, *common.Cursor, error) {
+	args := []interface{}{}
+	conditions := []string{"visible_to_companies"}
+	rankExpr := "0::real"
+	orderBy := "created_at DESC, user_id DESC"
+	from := "student_profiles"
+	byRank := false
+	if query.Text != "" {
+		args = append(args, query.Text)
+		from = fmt.Sprintf("student_profiles, websearch_to_tsquery('russian', $%d) AS q", len(args))
+		conditions = append(conditions, "search_vector @@ q")
+		rankExpr = "ts_rank(search_vector, q)"
+		byRank = true
+		orderBy = rankExpr + " DESC, created_at DESC, user_id DESC"
+	}
+	if len(query.Skills) > 0 {
+		args = append(args, pq.Array(query.Skills))
+		operator := "&&"
+		if query.SkillsMode == profile.SkillsMatchAll {
+			operator = "@>"
+		}
+		conditions = append(conditions, fmt.Sprintf("skill_tags %s $%d::text[]", operator, len(args)))
+	}
+	if query.University != "" {
+		args = append(args, "%"+query.University+"%")
+		conditions = append(conditions, fmt.Sprintf("university ILIKE $%d", len(args)))
+	}
+	if query.Specialty != "" {
+		args = append(args, "%"+query.Specialty+"%")
+		conditions = append(conditions, fmt.Sprintf("specialty ILIKE $%d", len(args)))
+	}
+	if query.CourseMin != nil {
+		args = append(args, *query.CourseMin)
+		conditions = append(conditions, fmt.Sprintf("course >= $%d", len(args)))
+	}
+	if query.CourseMax != nil {
+		args = append(args, *query.CourseMax)
+		conditions = append(conditions, fmt.Sprintf("course <= $%d", len(args)))
+	}
+	if query.After != nil {
+		if byRank {
+			args = append(args, query.After.Rank, query.After.CreatedAt, query.After.ID)
+			conditions = append(conditions, fmt.Sprintf("(%s, created_at, user_id) < ($%d::real, $%d, $%d)", rankExpr, len(args)-2, len(args)-1, len(args)))
+		} else {
+			args = append(args, query.After.CreatedAt, query.After.ID)
+			conditions = append(conditions, fmt.Sprintf("(created_at, user_id) < ($%d, $%d)", len(args)-1, len(args)))
+		}
+	}
+	args = append(args, query.Limit+1)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, fmt.Sprintf(`SELECT %s, %s
+		FROM %s WHERE %s ORDER BY %s LIMIT $%d`, studentProfileColumns, rankExpr, from, strings.Join(conditions, " AND "), orderBy, len(args)), args...)
+	if err != nil {
+		return nil, nil, common.NewError(common.CodeInternal, "failed to search candidates", err)
+	}
+	defer rows.Close()
+	type rankedProfile struct {
+		profile profile.StudentProfile
+		rank    float64
+	}
+	var ranked []rankedProfile
+	for rows.Next() {
+		var item rankedProfile
+		if err := scanStudentProfile(rows, &item.profile, &item.rank); err != nil {
+			return nil, nil, common.NewError(common.CodeInternal, "failed to scan candidate", err)
+		}
+		ranked = append(ranked, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, common.NewError(common.CodeInternal, "failed to search candidates", err)
+	}
+	ranked, next := trimPage(ranked, query.Limit, func(item rankedProfile) common.Cursor {
+		cursor := common.Cursor{CreatedAt: item.profile.CreatedAt, ID: item.profile.UserID}
+		if byRank {
+			cursor.Rank = item.rank
+		}
+		return cursor
+	})
+	items := make([]profile.StudentProfile, 0, len(ranked))
+	for _, item := range ranked {
+		items = append(items, item.profile)
+	}
+	return items, next, nil
+}
+
 type CompanyProfileRepository struct {
 	db *sql.DB
 }
@@ -57,7 +158,7 @@ func NewCompanyProfileRepository(db *sql.DB) *CompanyProfileRepository {
 }
 
 func (r *CompanyProfileRepository) GetByUserID(ctx context.Context, userID common.UUID) (*profile.CompanyProfile, error) {
-	row := r.db.QueryRowContext(ctx, `SELECT user_id, name, industry, description, contact_name, contact_email, contact_phone, created_at, updated_at
+	row := conn(ctx, r.db).QueryRowContext(ctx, `SELECT user_id, name, industry, description, contact_name, contact_email, contact_phone, created_at, updated_at
 		FROM company_profiles WHERE user_id = $1`, userID)
 	var p profile.CompanyProfile
 	if err := row.Scan(&p.UserID, &p.Name, &p.Industry, &p.Description, &p.ContactName, &p.ContactEmail, &p.ContactPhone, &p.CreatedAt, &p.UpdatedAt); err != nil {
@@ -72,7 +173,7 @@ func (r *CompanyProfileRepository) GetByUserID(ctx context.Context, userID commo
 func (r *CompanyProfileRepository) Upsert(ctx context.Context, profile profile.CompanyProfile) (*profile.CompanyProfile, error) {
 	now := time.Now().UTC()
 	profile.UpdatedAt = now
-	_, err := r.db.ExecContext(ctx, `INSERT INTO company_profiles (user_id, name, industry, description, contact_name, contact_email, contact_phone, created_at, updated_at)
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO company_profiles (user_id, name, industry, description, contact_name, contact_email, contact_phone, created_at, updated_at)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		ON CONFLICT (user_id) DO UPDATE SET name = EXCLUDED.name, industry = EXCLUDED.industry, description = EXCLUDED.description,
 		contact_name = EXCLUDED.contact_name, contact_email = EXCLUDED.contact_email, contact_phone = EXCLUDED.contact_phone, updated_at = EXCLUDED.updated_at`,
@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+
+	"profzom/internal/common"
+	"profzom/internal/realtime"
+)
+
+const (
+	realtimeChannel = "profzom_realtime"
+	// realtimeMaxPayload — запас до лимита NOTIFY в 8000 байт.
+	realtimeMaxPayload = 7900
+)
+
+// RealtimePublisher отправляет события через pg_notify. Внутри UnitOfWork NOTIFY доставляется только после коммита,
+// поэтому подписчики не увидят сообщение из откатившейся транзакции.
+type RealtimePublisher struct {
+	db *sql.DB
+}
+
+func NewRealtimePublisher(db *sql.DB) *RealtimePublisher {
+	return &RealtimePublisher{db: db}
+}
+
+func (p *RealtimePublisher) Publish(ctx context.Context, event realtime.Event) error {
+	payload, err := encodeRealtimeEvent(event)
+	if err != nil {
+		return err
+	}
+	if len(payload) > realtimeMaxPayload {
+		// Без data событие только сообщает о факте изменения, а содержимое клиент догружает обычным запросом.
+		event.Data = nil
+		if payload, err = encodeRealtimeEvent(event); err != nil {
+			return err
+		}
+	}
+	if _, err := conn(ctx, p.db).ExecContext(ctx, `SELECT pg_notify($1, $2)`, realtimeChannel, string(payload)); err != nil {
+		return common.NewError(common.CodeInternal, "failed to publish realtime event", err)
+	}
+	return nil
+}
+
+type errorLogger interface {
+	Error(msg string)
+}
+
+func encodeRealtimeEvent(event realtime.Event) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(event); err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to encode realtime event", err)
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+// RealtimeListener — мост LISTEN/NOTIFY: каждая реплика API слушает канал и раздаёт события в свой Hub.
+type RealtimeListener struct {
+	dsn    string
+	hub    *realtime.Hub
+	logger errorLogger
+}
+
+func NewRealtimeListener(dsn string, hub *realtime.Hub, logger errorLogger) *RealtimeListener {
+	return &RealtimeListener{dsn: dsn, hub: hub, logger: logger}
+}
+
+func (l *RealtimeListener) Run(ctx context.Context) {
+	listener := pq.NewListener(l.dsn, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			l.logError("realtime listener: " + err.Error())
+		}
+	})
+	defer listener.Close()
+	if err := listener.Listen(realtimeChannel); err != nil {
+		l.logError("realtime listen failed: " + err.Error())
+		return
+	}
+	ping := time.NewTicker(time.Minute)
+	defer ping.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case notification := <-listener.Notify:
+			// nil приходит после переподключения; пропущенные за это время события клиенты догружают сами.
+			if notification == nil {
+				continue
+			}
+			var event realtime.Event
+			if err := json.Unmarshal([]byte(notification.Extra), &event); err != nil {
+				l.logError("realtime listener: invalid payload: " + err.Error())
+				continue
+			}
+			l.hub.Dispatch(event)
+		case <-ping.C:
+			_ = listener.Ping()
+		}
+	}
+}
+
+func (l *RealtimeListener) logError(message string) {
+	if l.logger != nil {
+		l.logger.Error(message)
+	}
+}
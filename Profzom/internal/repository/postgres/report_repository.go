@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/moderation"
+)
+
+type ReportRepository struct {
+	db *sql.DB
+}
+
+func NewReportRepository(db *sql.DB) *ReportRepository {
+	return &ReportRepository{db: db}
+}
+
+const reportColumns = `id, reporter_id, target_type, target_id, target_user_id, reason, comment, text, status, resolution, resolved_by, resolved_at, created_at, updated_at`
+
+func scanReport(row rowScanner, report *moderation.Report) error {
+	var targetUserID, resolvedBy sql.NullString
+	var resolvedAt sql.NullTime
+	if err := row.Scan(&report.ID, &report.ReporterID, &report.TargetType, &report.TargetID, &targetUserID, &report.Reason, &report.Comment, &report.Text, &report.Status, &report.Resolution, &resolvedBy, &resolvedAt, &report.CreatedAt, &report.UpdatedAt); err != nil {
+		return err
+	}
+	report.TargetUserID = common.UUID(targetUserID.String)
+	report.ResolvedBy = nullUUID(resolvedBy)
+	report.ResolvedAt = nil
+	if resolvedAt.Valid {
+		value := resolvedAt.Time
+		report.ResolvedAt = &value
+	}
+	return nil
+}
+
+func (r *ReportRepository) Create(ctx context.Context, report moderation.Report) (*moderation.Report, error) {
+	if report.ID == "" {
+		report.ID = common.NewUUID()
+	}
+	report.Status = moderation.ReportOpen
+	report.CreatedAt = time.Now().UTC()
+	report.UpdatedAt = report.CreatedAt
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO reports (id, reporter_id, target_type, target_id, target_user_id, reason, comment, text, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $10)`,
+		report.ID, report.ReporterID, report.TargetType, report.TargetID, nullableUUID(report.TargetUserID), report.Reason, report.Comment, report.Text, report.Status, report.CreatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, common.NewError(common.CodeConflict, "report already submitted", err)
+		}
+		return nil, common.NewError(common.CodeInternal, "failed to create report", err)
+	}
+	return &report, nil
+}
+
+func (r *ReportRepository) GetByID(ctx context.Context, id common.UUID) (*moderation.Report, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+reportColumns+` FROM reports WHERE id = $1`, id)
+	var report moderation.Report
+	if err := scanReport(row, &report); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, common.NewError(common.CodeNotFound, "report not found", err)
+		}
+		return nil, common.NewError(common.CodeInternal, "failed to load report", err)
+	}
+	return &report, nil
+}
+
+func (r *ReportRepository) Save(ctx context.Context, report moderation.Report) (*moderation.Report, error) {
+	report.UpdatedAt = time.Now().UTC()
+	_, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE reports SET status = $1, resolution = $2, resolved_by = $3, resolved_at = $4, updated_at = $5 WHERE id = $6`,
+		report.Status, report.Resolution, nullableUUIDPtr(report.ResolvedBy), report.ResolvedAt, report.UpdatedAt, report.ID)
+	if err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to save report", err)
+	}
+	return &report, nil
+}
+
+func (r *ReportRepository) List(ctx context.Context, query moderation.ReportQuery) ([]moderation.Report, *common.Cursor, error) {
+	var args []interface{}
+	conditions := []string{"TRUE"}
+	if query.Status != "" {
+		args = append(args, query.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if query.TargetType != "" {
+		args = append(args, query.TargetType)
+		conditions = append(conditions, fmt.Sprintf("target_type = $%d", len(args)))
+	}
+	if query.TargetUserID != nil {
+		args = append(args, *query.TargetUserID)
+		conditions = append(conditions, fmt.Sprintf("target_user_id = $%d", len(args)))
+	}
+	if query.After != nil {
+		args = append(args, query.After.CreatedAt, query.After.ID)
+		conditions = append(conditions, fmt.Sprintf("(created_at, id) > ($%d, $%d)", len(args)-1, len(args)))
+	}
+	args = append(args, query.Limit+1)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, fmt.Sprintf(`SELECT %s FROM reports WHERE %s ORDER BY created_at, id LIMIT $%d`, reportColumns, strings.Join(conditions, " AND "), len(args)), args...)
+	if err != nil {
+		return nil, nil, common.NewError(common.CodeInternal, "failed to list reports", err)
+	}
+	defer rows.Close()
+	var items []moderation.Report
+	for rows.Next() {
+		var report moderation.Report
+		if err := scanReport(rows, &report); err != nil {
+			return nil, nil, common.NewError(common.CodeInternal, "failed to scan report", err)
+		}
+		items = append(items, report)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, common.NewError(common.CodeInternal, "failed to list reports", err)
+	}
+	items, next := trimPage(items, query.Limit, func(report moderation.Report) common.Cursor {
+		return common.Cursor{CreatedAt: report.CreatedAt, ID: report.ID}
+	})
+	return items, next, nil
+}
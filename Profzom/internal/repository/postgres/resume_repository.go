@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/profile"
+)
+
+var resumeTables = []string{"student_education", "student_experience", "student_links", "student_languages", "student_certificates"}
+
+type ResumeRepository struct {
+	db *sql.DB
+}
+
+func NewResumeRepository(db *sql.DB) *ResumeRepository {
+	return &ResumeRepository{db: db}
+}
+
+func (r *ResumeRepository) GetByStudent(ctx context.Context, studentID common.UUID) (*profile.Resume, error) {
+	resume := profile.Resume{
+		Education:    []profile.Education{},
+		Experience:   []profile.Experience{},
+		Links:        []profile.Link{},
+		Languages:    []profile.Language{},
+		Certificates: []profile.Certificate{},
+	}
+	db := conn(ctx, r.db)
+	err := queryEach(ctx, db, `SELECT institution, degree, field, start_year, end_year FROM student_education WHERE student_id = $1 ORDER BY position`, studentID, func(row rowScanner) error {
+		var item profile.Education
+		var endYear sql.NullInt64
+		if err := row.Scan(&item.Institution, &item.Degree, &item.Field, &item.StartYear, &endYear); err != nil {
+			return err
+		}
+		item.EndYear = nullIntPtr(endYear)
+		resume.Education = append(resume.Education, item)
+		return nil
+	})
+	if err == nil {
+		err = queryEach(ctx, db, `SELECT kind, title, organization, start_month, end_month, description, url FROM student_experience WHERE student_id = $1 ORDER BY position`, studentID, func(row rowScanner) error {
+			var item profile.Experience
+			if err := row.Scan(&item.Kind, &item.Title, &item.Organization, &item.StartMonth, &item.EndMonth, &item.Description, &item.URL); err != nil {
+				return err
+			}
+			resume.Experience = append(resume.Experience, item)
+			return nil
+		})
+	}
+	if err == nil {
+		err = queryEach(ctx, db, `SELECT kind, url FROM student_links WHERE student_id = $1 ORDER BY position`, studentID, func(row rowScanner) error {
+			var item profile.Link
+			if err := row.Scan(&item.Kind, &item.URL); err != nil {
+				return err
+			}
+			resume.Links = append(resume.Links, item)
+			return nil
+		})
+	}
+	if err == nil {
+		err = queryEach(ctx, db, `SELECT name, level FROM student_languages WHERE student_id = $1 ORDER BY position`, studentID, func(row rowScanner) error {
+			var item profile.Language
+			if err := row.Scan(&item.Name, &item.Level); err != nil {
+				return err
+			}
+			resume.Languages = append(resume.Languages, item)
+			return nil
+		})
+	}
+	if err == nil {
+		err = queryEach(ctx, db, `SELECT name, issuer, issued_month, url FROM student_certificates WHERE student_id = $1 ORDER BY position`, studentID, func(row rowScanner) error {
+			var item profile.Certificate
+			if err := row.Scan(&item.Name, &item.Issuer, &item.IssuedMonth, &item.URL); err != nil {
+				return err
+			}
+			resume.Certificates = append(resume.Certificates, item)
+			return nil
+		})
+	}
+	if err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to load resume", err)
+	}
+	return &resume, nil
+}
+
+func (r *ResumeRepository) Replace(ctx context.Context, studentID common.UUID, resume profile.Resume) error {
+	db := conn(ctx, r.db)
+	for _, table := range resumeTables {
+		if _, err := db.ExecContext(ctx, `DELETE FROM `+table+` WHERE student_id = $1`, studentID); err != nil {
+			return common.NewError(common.CodeInternal, "failed to replace resume", err)
+		}
+	}
+	for i, item := range resume.Education {
+		if _, err := db.ExecContext(ctx, `INSERT INTO student_education (student_id, position, institution, degree, field, start_year, end_year)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)`, studentID, i, item.Institution, item.Degree, item.Field, item.StartYear, item.EndYear); err != nil {
+			return common.NewError(common.CodeInternal, "failed to save education", err)
+		}
+	}
+	for i, item := range resume.Experience {
+		if _, err := db.ExecContext(ctx, `INSERT INTO student_experience (student_id, position, kind, title, organization, start_month, end_month, description, url)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`, studentID, i, item.Kind, item.Title, item.Organization, item.StartMonth, item.EndMonth, item.Description, item.URL); err != nil {
+			return common.NewError(common.CodeInternal, "failed to save experience", err)
+		}
+	}
+	for i, item := range resume.Links {
+		if _, err := db.ExecContext(ctx, `INSERT INTO student_links (student_id, position, kind, url) VALUES ($1, $2, $3, $4)`, studentID, i, item.Kind, item.URL); err != nil {
+			return common.NewError(common.CodeInternal, "failed to save links", err)
+		}
+	}
+	for i, item := range resume.Languages {
+		if _, err := db.ExecContext(ctx, `INSERT INTO student_languages (student_id, position, name, level) VALUES ($1, $2, $3, $4)`, studentID, i, item.Name, item.Level); err != nil {
+			return common.NewError(common.CodeInternal, "failed to save languages", err)
+		}
+	}
+	for i, item := range resume.Certificates {
+		if _, err := db.ExecContext(ctx, `INSERT INTO student_certificates (student_id, position, name, issuer, issued_month, url)
+			VALUES ($1, $2, $3, $4, $5, $6)`, studentID, i, item.Name, item.Issuer, item.IssuedMonth, item.URL); err != nil {
+			return common.NewError(common.CodeInternal, "failed to save certificates", err)
+		}
+	}
+	return nil
+}
+
+// queryEach передаёт каждую строку результата в scan; arg == nil означает запрос без параметров.
+func queryEach(ctx context.Context, db dbtx, query string, arg interface{}, scan func(rowScanner) error) error {
+	var args []interface{}
+	if arg != nil {
+		args = append(args, arg)
+	}
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/savedsearch"
+	"profzom/internal/domain/vacancy"
+)
+
+const savedSearchColumns = "id, student_id, name, query_text, vacancy_type, location, salary_from, salary_to, salary_period, created_at, updated_at"
+
+type SavedSearchRepository struct {
+	db *sql.DB
+}
+
+func NewSavedSearchRepository(db *sql.DB) *SavedSearchRepository {
+	return &SavedSearchRepository{db: db}
+}
+
+func (r *SavedSearchRepository) Create(ctx context.Context, search savedsearch.SavedSearch) (*savedsearch.SavedSearch, error) {
+	search.ID = common.NewUUID()
+	search.CreatedAt = time.Now().UTC()
+	search.UpdatedAt = search.CreatedAt
+	q := search.Query
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO saved_searches (`+savedSearchColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $10)`,
+		search.ID, search.StudentID, search.Name, q.Text, q.Type, q.Location, q.SalaryFrom, q.SalaryTo, q.Period, search.CreatedAt)
+	if err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to create saved search", err)
+	}
+	return &search, nil
+}
+
+func (r *SavedSearchRepository) ListByStudent(ctx context.Context, studentID common.UUID) ([]savedsearch.SavedSearch, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT `+savedSearchColumns+` FROM saved_searches
+		WHERE student_id = $1 ORDER BY created_at DESC, id DESC`, studentID)
+	if err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to list saved searches", err)
+	}
+	defer rows.Close()
+	var items []savedsearch.SavedSearch
+	for rows.Next() {
+		var item savedsearch.SavedSearch
+		var salaryFrom, salaryTo sql.NullInt64
+		if err := rows.Scan(&item.ID, &item.StudentID, &item.Name, &item.Query.Text, &item.Query.Type, &item.Query.Location, &salaryFrom, &salaryTo, &item.Query.Period, &item.CreatedAt, &item.UpdatedAt); err != nil {
+			return nil, common.NewError(common.CodeInternal, "failed to scan saved search", err)
+		}
+		item.Query.SalaryFrom = nullIntPtr(salaryFrom)
+		item.Query.SalaryTo = nullIntPtr(salaryTo)
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to list saved searches", err)
+	}
+	return items, nil
+}
+
+func (r *SavedSearchRepository) Delete(ctx context.Context, id, studentID common.UUID) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM saved_searches WHERE id = $1 AND student_id = $2`, id, studentID)
+	if err != nil {
+		return common.NewError(common.CodeInternal, "failed to delete saved search", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return common.NewError(common.CodeInternal, "failed to delete saved search", err)
+	}
+	if affected == 0 {
+		return common.NewError(common.CodeNotFound, "saved search not found", nil)
+	}
+	return nil
+}
+
+// RecordMatches повторяет условия VacancyRepository.Search, но проверяет одну вакансию сразу против всех поисков.
+func (r *SavedSearchRepository) RecordMatches(ctx context.Context, vacancyID common.UUID) (int, error) {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO saved_search_matches (search_id, student_id, vacancy_id, created_at)
+		SELECT s.id, s.student_id, v.id, $3
+		FROM saved_searches s JOIN vacancies v ON v.id = $1
+		WHERE v.status = $2
+			AND (v.expires_at IS NULL OR v.expires_at > $3)
+			AND (s.query_text = '' OR v.search_vector @@ websearch_to_tsquery('russian', s.query_text))
+			AND (s.vacancy_type = '' OR lower(v.vacancy_type) = lower(s.vacancy_type))
+			AND (s.location = '' OR v.location ILIKE '%' || s.location || '%')
+			AND (s.salary_from IS NULL OR COALESCE(v.salary_max, v.salary_min) >= s.salary_from)
+			AND (s.salary_to IS NULL OR COALESCE(v.salary_min, v.salary_max) <= s.salary_to)
+			AND (s.salary_period = '' OR v.salary_period = s.salary_period)
+		ON CONFLICT (search_id, vacancy_id) DO NOTHING`, vacancyID, vacancy.StatusPublished, time.Now().UTC())
+	if err != nil {
+		return 0, common.NewError(common.CodeInternal, "failed to record saved search matches", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, common.NewError(common.CodeInternal, "failed to record saved search matches", err)
+	}
+	return int(affected), nil
+}
+
+func (r *SavedSearchRepository) ClaimMatches(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]savedsearch.Match, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `WITH claimed AS (
+			UPDATE saved_search_matches SET claimed_until = $2
+			WHERE (search_id, vacancy_id) IN (
+				SELECT m.search_id, m.vacancy_id
+				FROM saved_search_matches m
+				JOIN vacancies v ON v.id = m.vacancy_id
+				WHERE m.notified_at IS NULL
+					AND (m.claimed_until IS NULL OR m.claimed_until <= $1)
+					AND v.status = $4
+				ORDER BY m.student_id, m.created_at, m.vacancy_id
+				LIMIT $3
+				FOR UPDATE OF m SKIP LOCKED
+			)
+			RETURNING search_id, student_id, vacancy_id, created_at
+		)
+		SELECT c.search_id, s.name, c.student_id, c.vacancy_id, v.title, c.created_at
+		FROM claimed c
+		JOIN saved_searches s ON s.id = c.search_id
+		JOIN vacancies v ON v.id = c.vacancy_id
+		ORDER BY c.student_id, c.created_at, c.vacancy_id`, now, now.Add(lease), limit, vacancy.StatusPublished)
+	if err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to load saved search matches", err)
+	}
+	defer rows.Close()
+	var items []savedsearch.Match
+	for rows.Next() {
+		var item savedsearch.Match
+		if err := rows.Scan(&item.SearchID, &item.SearchName, &item.StudentID, &item.VacancyID, &item.VacancyTitle, &item.CreatedAt); err != nil {
+			return nil, common.NewError(common.CodeInternal, "failed to scan saved search match", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to load saved search matches", err)
+	}
+	return items, nil
+}
+
+func (r *SavedSearchRepository) MarkNotified(ctx context.Context, studentID common.UUID, vacancyIDs []common.UUID, notifiedAt time.Time) error {
+	ids := make([]string, 0, len(vacancyIDs))
+	for _, id := range vacancyIDs {
+		ids = append(ids, id.String())
+	}
+	_, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE saved_search_matches SET notified_at = $1
+		WHERE student_id = $2 AND vacancy_id = ANY($3::uuid[]) AND notified_at IS NULL`, notifiedAt, studentID, pq.Array(ids))
+	if err != nil {
+		return common.NewError(common.CodeInternal, "failed to mark saved search matches", err)
+	}
+	return nil
+}
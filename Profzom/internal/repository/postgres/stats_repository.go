@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/admin"
+	"profzom/internal/domain/application"
+	"profzom/internal/domain/user"
+	"profzom/internal/domain/vacancy"
+)
+
+type StatsRepository struct {
+	db *sql.DB
+}
+
+func NewStatsRepository(db *sql.DB) *StatsRepository {
+	return &StatsRepository{db: db}
+}
+
+func (r *StatsRepository) Stats(ctx context.Context, since time.Time) (*admin.Stats, error) {
+	db := conn(ctx, r.db)
+	stats := &admin.Stats{
+		Since:                since,
+		UsersByRole:          map[user.Role]int{},
+		VacanciesByStatus:    map[vacancy.Status]int{},
+		ApplicationsByStatus: map[application.Status]int{},
+		Events:               map[string]int{},
+	}
+	err := db.QueryRowContext(ctx, `SELECT COUNT(*), COUNT(*) FILTER (WHERE created_at >= $1), COUNT(*) FILTER (WHERE blocked_at IS NOT NULL) FROM users`, since).
+		Scan(&stats.Users, &stats.NewUsers, &stats.BlockedUsers)
+	if err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to count users", err)
+	}
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM applications WHERE created_at >= $1`, since).Scan(&stats.NewApplications); err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to count applications", err)
+	}
+	err = queryEach(ctx, db, `SELECT role, COUNT(*) FROM user_roles GROUP BY role`, nil, func(row rowScanner) error {
+		var role user.Role
+		var count int
+		if err := row.Scan(&role, &count); err != nil {
+			return err
+		}
+		stats.UsersByRole[role] = count
+		return nil
+	})
+	if err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to count users by role", err)
+	}
+	err = queryEach(ctx, db, `SELECT status, COUNT(*) FROM vacancies GROUP BY status`, nil, func(row rowScanner) error {
+		var status vacancy.Status
+		var count int
+		if err := row.Scan(&status, &count); err != nil {
+			return err
+		}
+		stats.VacanciesByStatus[status] = count
+		return nil
+	})
+	if err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to count vacancies", err)
+	}
+	err = queryEach(ctx, db, `SELECT status, COUNT(*) FROM applications GROUP BY status`, nil, func(row rowScanner) error {
+		var status application.Status
+		var count int
+		if err := row.Scan(&status, &count); err != nil {
+			return err
+		}
+		stats.ApplicationsByStatus[normalizeStatus(status)] += count
+		return nil
+	})
+	if err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to count applications", err)
+	}
+	err = queryEach(ctx, db, `SELECT name, COUNT(*) FROM analytics_events WHERE created_at >= $1 GROUP BY name`, since, func(row rowScanner) error {
+		var name string
+		var count int
+		if err := row.Scan(&name, &count); err != nil {
+			return err
+		}
+		stats.Events[name] = count
+		return nil
+	})
+	if err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to count events", err)
+	}
+	return stats, nil
+}
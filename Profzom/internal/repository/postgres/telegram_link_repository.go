@@ -18,7 +18,7 @@ func NewTelegramLinkRepository(db *sql.DB) *TelegramLinkRepository {
 }
 
 func (r *TelegramLinkRepository) GetByChatID(ctx context.Context, chatID int64) (*telegram.Link, error) {
-	row := r.db.QueryRowContext(ctx, `SELECT user_id, phone, chat_id, verified_at FROM telegram_links WHERE chat_id = $1`, chatID)
+	row := conn(ctx, r.db).QueryRowContext(ctx, `SELECT user_id, phone, chat_id, verified_at FROM telegram_links WHERE chat_id = $1`, chatID)
 	var link telegram.Link
 	var phoneValue sql.NullString
 	if err := row.Scan(&link.UserID, &phoneValue, &link.ChatID, &link.VerifiedAt); err != nil {
@@ -34,7 +34,7 @@ func (r *TelegramLinkRepository) GetByChatID(ctx context.Context, chatID int64)
 }
 
 func (r *TelegramLinkRepository) GetByPhone(ctx context.Context, phone string) (*telegram.Link, error) {
-	row := r.db.QueryRowContext(ctx, `SELECT user_id, phone, chat_id, verified_at FROM telegram_links WHERE phone = $1`, phone)
+	row := conn(ctx, r.db).QueryRowContext(ctx, `SELECT user_id, phone, chat_id, verified_at FROM telegram_links WHERE phone = $1`, phone)
 	var link telegram.Link
 	var phoneValue sql.NullString
 	if err := row.Scan(&link.UserID, &phoneValue, &link.ChatID, &link.VerifiedAt); err != nil {
@@ -50,7 +50,7 @@ func (r *TelegramLinkRepository) GetByPhone(ctx context.Context, phone string) (
 }
 
 func (r *TelegramLinkRepository) GetByUserID(ctx context.Context, userID string) (*telegram.Link, error) {
-	row := r.db.QueryRowContext(ctx, `SELECT user_id, phone, chat_id, verified_at FROM telegram_links WHERE user_id = $1`, userID)
+	row := conn(ctx, r.db).QueryRowContext(ctx, `SELECT user_id, phone, chat_id, verified_at FROM telegram_links WHERE user_id = $1`, userID)
 	var link telegram.Link
 	var phoneValue sql.NullString
 	if err := row.Scan(&link.UserID, &phoneValue, &link.ChatID, &link.VerifiedAt); err != nil {
@@ -4,8 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/lib/pq"
+
 	"profzom/internal/common"
 	"profzom/internal/domain/user"
 )
@@ -19,35 +23,31 @@ func NewUserRepository(db *sql.DB) *UserRepository {
 }
 
 func (r *UserRepository) FindByPhone(ctx context.Context, phone string) (*user.User, error) {
-	row := r.db.QueryRowContext(ctx, `SELECT id, phone, created_at, updated_at FROM users WHERE phone = $1`, phone)
-	var u user.User
-	var phoneValue sql.NullString
-	if err := row.Scan(&u.ID, &phoneValue, &u.CreatedAt, &u.UpdatedAt); err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, common.NewError(common.CodeNotFound, "user not found", err)
-		}
-		return nil, common.NewError(common.CodeInternal, "failed to load user", err)
-	}
-	u.Phone = phoneValue.String
-	roles, err := r.ListRoles(ctx, u.ID)
-	if err != nil {
-		return nil, err
-	}
-	u.Roles = roles
-	return &u, nil
+	return r.load(ctx, `SELECT `+userColumns+` FROM users WHERE phone = $1`, phone)
 }
 
 func (r *UserRepository) GetByID(ctx context.Context, id common.UUID) (*user.User, error) {
-	row := r.db.QueryRowContext(ctx, `SELECT id, phone, created_at, updated_at FROM users WHERE id = $1`, id)
+	return r.load(ctx, `SELECT `+userColumns+` FROM users WHERE id = $1`, id)
+}
+
+const userColumns = `id, phone, created_at, updated_at, blocked_at, block_reason`
+
+func (r *UserRepository) load(ctx context.Context, query string, arg interface{}) (*user.User, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, query, arg)
 	var u user.User
 	var phoneValue sql.NullString
-	if err := row.Scan(&u.ID, &phoneValue, &u.CreatedAt, &u.UpdatedAt); err != nil {
+	var blockedAt sql.NullTime
+	if err := row.Scan(&u.ID, &phoneValue, &u.CreatedAt, &u.UpdatedAt, &blockedAt, &u.BlockReason); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, common.NewError(common.CodeNotFound, "user not found", err)
 		}
 		return nil, common.NewError(common.CodeInternal, "failed to load user", err)
 	}
 	u.Phone = phoneValue.String
+	if blockedAt.Valid {
+		value := blockedAt.Time
+		u.BlockedAt = &value
+	}
 	roles, err := r.ListRoles(ctx, u.ID)
 	if err != nil {
 		return nil, err
@@ -63,7 +63,7 @@ func (r *UserRepository) Create(ctx context.Context, phone string) (*user.User,
 	if phone == "" {
 		phoneValue = nil
 	}
-	_, err := r.db.ExecContext(ctx, `INSERT INTO users (id, phone, created_at, updated_at) VALUES ($1, $2, $3, $4)`, id, phoneValue, now, now)
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO users (id, phone, created_at, updated_at) VALUES ($1, $2, $3, $4)`, id, phoneValue, now, now)
 	if err != nil {
 		return nil, common.NewError(common.CodeInternal, "failed to create user", err)
 	}
@@ -71,12 +71,12 @@ func (r *UserRepository) Create(ctx context.Context, phone string) (*user.User,
 }
 
 func (r *UserRepository) SetRoles(ctx context.Context, userID common.UUID, roles []user.Role) error {
-	_, err := r.db.ExecContext(ctx, `DELETE FROM user_roles WHERE user_id = $1`, userID)
+	_, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM user_roles WHERE user_id = $1`, userID)
 	if err != nil {
 		return common.NewError(common.CodeInternal, "failed to reset roles", err)
 	}
 	for _, role := range roles {
-		_, err := r.db.ExecContext(ctx, `INSERT INTO user_roles (user_id, role) VALUES ($1, $2)`, userID, role)
+		_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO user_roles (user_id, role) VALUES ($1, $2)`, userID, role)
 		if err != nil {
 			return common.NewError(common.CodeInternal, "failed to set role", err)
 		}
@@ -85,7 +85,7 @@ func (r *UserRepository) SetRoles(ctx context.Context, userID common.UUID, roles
 }
 
 func (r *UserRepository) ListRoles(ctx context.Context, userID common.UUID) ([]user.Role, error) {
-	rows, err := r.db.QueryContext(ctx, `SELECT role FROM user_roles WHERE user_id = $1`, userID)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT role FROM user_roles WHERE user_id = $1`, userID)
 	if err != nil {
 		return nil, common.NewError(common.CodeInternal, "failed to list roles", err)
 	}
@@ -100,3 +100,105 @@ func (r *UserRepository) ListRoles(ctx context.Context, userID common.UUID) ([]u
 	}
 	return roles, nil
 }
+
+func (r *UserRepository) AddRole(ctx context.Context, userID common.UUID, role user.Role) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO user_roles (user_id, role) VALUES ($1, $2) ON CONFLICT DO NOTHING`, userID, role)
+	if err != nil {
+		return common.NewError(common.CodeInternal, "failed to add role", err)
+	}
+	return nil
+}
+
+func (r *UserRepository) RemoveRole(ctx context.Context, userID common.UUID, role user.Role) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM user_roles WHERE user_id = $1 AND role = $2`, userID, role)
+	if err != nil {
+		return common.NewError(common.CodeInternal, "failed to remove role", err)
+	}
+	return nil
+}
+
+func (r *UserRepository) SetBlocked(ctx context.Context, userID common.UUID, blockedAt *time.Time, reason string) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE users SET blocked_at = $1, block_reason = $2, updated_at = $3 WHERE id = $4`, blockedAt, reason, time.Now().UTC(), userID)
+	if err != nil {
+		return common.NewError(common.CodeInternal, "failed to update user block", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return common.NewError(common.CodeNotFound, "user not found", nil)
+	}
+	return nil
+}
+
+func (r *UserRepository) IsBlocked(ctx context.Context, userID common.UUID) (bool, error) {
+	var blocked bool
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT blocked_at IS NOT NULL FROM users WHERE id = $1`, userID).Scan(&blocked)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, common.NewError(common.CodeNotFound, "user not found", err)
+		}
+		return false, common.NewError(common.CodeInternal, "failed to load user", err)
+	}
+	return blocked, nil
+}
+
+// Search ищет аккаунты для админки, новые первыми; имя подтягивается из профиля студента или компании.
+func (r *UserRepository) Search(ctx context.Context, query user.SearchQuery) ([]user.Account, *common.Cursor, error) {
+	var args []interface{}
+	conditions := []string{"TRUE"}
+	if query.Text != "" {
+		args = append(args, query.Text, "%"+query.Text+"%")
+		conditions = append(conditions, fmt.Sprintf("(u.id::text = $%d OR u.phone ILIKE $%d OR sp.name ILIKE $%d OR cp.name ILIKE $%d)", len(args)-1, len(args), len(args), len(args)))
+	}
+	if query.Role != "" {
+		args = append(args, query.Role)
+		conditions = append(conditions, fmt.Sprintf("EXISTS (SELECT 1 FROM user_roles ur WHERE ur.user_id = u.id AND ur.role = $%d)", len(args)))
+	}
+	if query.Blocked != nil {
+		if *query.Blocked {
+			conditions = append(conditions, "u.blocked_at IS NOT NULL")
+		} else {
+			conditions = append(conditions, "u.blocked_at IS NULL")
+		}
+	}
+	if query.After != nil {
+		args = append(args, query.After.CreatedAt, query.After.ID)
+		conditions = append(conditions, fmt.Sprintf("(u.created_at, u.id) < ($%d, $%d)", len(args)-1, len(args)))
+	}
+	args = append(args, query.Limit+1)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, fmt.Sprintf(`SELECT u.id, COALESCE(u.phone, ''), COALESCE(sp.name, cp.name, ''), u.blocked_at, u.block_reason, u.created_at,
+			ARRAY(SELECT ur.role FROM user_roles ur WHERE ur.user_id = u.id ORDER BY ur.role)
+		FROM users u
+		LEFT JOIN student_profiles sp ON sp.user_id = u.id
+		LEFT JOIN company_profiles cp ON cp.user_id = u.id
+		WHERE %s
+		ORDER BY u.created_at DESC, u.id DESC
+		LIMIT $%d`, strings.Join(conditions, " AND "), len(args)), args...)
+	if err != nil {
+		return nil, nil, common.NewError(common.CodeInternal, "failed to search users", err)
+	}
+	defer rows.Close()
+	var items []user.Account
+	for rows.Next() {
+		var account user.Account
+		var blockedAt sql.NullTime
+		var roles []string
+		if err := rows.Scan(&account.ID, &account.Phone, &account.Name, &blockedAt, &account.BlockReason, &account.CreatedAt, pq.Array(&roles)); err != nil {
+			return nil, nil, common.NewError(common.CodeInternal, "failed to scan user", err)
+		}
+		if blockedAt.Valid {
+			value := blockedAt.Time
+			account.BlockedAt = &value
+		}
+		account.Roles = make([]user.Role, len(roles))
+		for i, role := range roles {
+			account.Roles[i] = user.Role(role)
+		}
+		items = append(items, account)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, common.NewError(common.CodeInternal, "failed to search users", err)
+	}
+	items, next := trimPage(items, query.Limit, func(account user.Account) common.Cursor {
+		return common.Cursor{CreatedAt: account.CreatedAt, ID: account.ID}
+	})
+	return items, next, nil
+}
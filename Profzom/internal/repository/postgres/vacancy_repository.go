@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/lib/pq"
@@ -20,14 +22,41 @@ func NewVacancyRepository(db *sql.DB) *VacancyRepository {
 	return &VacancyRepository{db: db}
 }
 
+const vacancyColumns = `id, company_id, title, vacancy_type, description, requirements, conditions, salary_min, salary_max, salary_currency, salary_period, salary_negotiable, location, status, expires_at, created_at, updated_at`
+
+// vacancySelectColumns — колонки для scanVacancy: vacancyColumns и вычисляемый бейдж company_verified.
+var vacancySelectColumns = vacancyColumns + ", " + companyVerifiedColumn("vacancies")
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanVacancy(row rowScanner, v *vacancy.Vacancy, extra ...interface{}) error {
+	var expiresAt sql.NullTime
+	var salaryMin, salaryMax sql.NullInt64
+	dest := []interface{}{&v.ID, &v.CompanyID, &v.Title, &v.Type, &v.Description, pq.Array(&v.Requirements), pq.Array(&v.Conditions), &salaryMin, &salaryMax, &v.Currency, &v.Period, &v.IsNegotiable, &v.Location, &v.Status, &expiresAt, &v.CreatedAt, &v.UpdatedAt, &v.CompanyVerified}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return err
+	}
+	v.SalaryMin = nullIntPtr(salaryMin)
+	v.SalaryMax = nullIntPtr(salaryMax)
+	v.ExpiresAt = nil
+	if expiresAt.Valid {
+		value := expiresAt.Time
+		v.ExpiresAt = &value
+	}
+	return nil
+}
+
 func (r *VacancyRepository) Create(ctx context.Context, v vacancy.Vacancy) (*vacancy.Vacancy, error) {
 	v.ID = common.NewUUID()
 	now := time.Now().UTC()
 	v.CreatedAt = now
 	v.UpdatedAt = now
-	_, err := r.db.ExecContext(ctx, `INSERT INTO vacancies (id, company_id, title, vacancy_type, description, requirements, conditions, salary, location, status, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
-		v.ID, v.CompanyID, v.Title, v.Type, v.Description, pq.Array(v.Requirements), pq.Array(v.Conditions), v.Salary, v.Location, v.Status, v.CreatedAt, v.UpdatedAt)
+	err := conn(ctx, r.db).QueryRowContext(ctx, `INSERT INTO vacancies (id, company_id, title, vacancy_type, description, requirements, conditions, salary_min, salary_max, salary_currency, salary_period, salary_negotiable, location, status, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+		RETURNING `+companyVerifiedColumn("vacancies"),
+		v.ID, v.CompanyID, v.Title, v.Type, v.Description, pq.Array(v.Requirements), pq.Array(v.Conditions), v.SalaryMin, v.SalaryMax, v.Currency, v.Period, v.IsNegotiable, v.Location, v.Status, v.ExpiresAt, v.CreatedAt, v.UpdatedAt).Scan(&v.CompanyVerified)
 	if err != nil {
 		return nil, common.NewError(common.CodeInternal, "failed to create vacancy", err)
 	}
@@ -36,19 +65,27 @@ func (r *VacancyRepository) Create(ctx context.Context, v vacancy.Vacancy) (*vac
 
 func (r *VacancyRepository) Update(ctx context.Context, v vacancy.Vacancy) (*vacancy.Vacancy, error) {
 	v.UpdatedAt = time.Now().UTC()
-	_, err := r.db.ExecContext(ctx, `UPDATE vacancies SET title = $1, vacancy_type = $2, description = $3, requirements = $4, conditions = $5, salary = $6, location = $7, status = $8, updated_at = $9
-		WHERE id = $10 AND company_id = $11`,
-		v.Title, v.Type, v.Description, pq.Array(v.Requirements), pq.Array(v.Conditions), v.Salary, v.Location, v.Status, v.UpdatedAt, v.ID, v.CompanyID)
+	err := conn(ctx, r.db).QueryRowContext(ctx, `UPDATE vacancies SET title = $1, vacancy_type = $2, description = $3, requirements = $4, conditions = $5,
+		salary_min = $6, salary_max = $7, salary_currency = $8, salary_period = $9, salary_negotiable = $10,
+		location = $11, status = $12, expires_at = $13, updated_at = $14
+		WHERE id = $15 AND company_id = $16
+		RETURNING `+companyVerifiedColumn("vacancies"),
+		v.Title, v.Type, v.Description, pq.Array(v.Requirements), pq.Array(v.Conditions),
+		v.SalaryMin, v.SalaryMax, v.Currency, v.Period, v.IsNegotiable,
+		v.Location, v.Status, v.ExpiresAt, v.UpdatedAt, v.ID, v.CompanyID).Scan(&v.CompanyVerified)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, common.NewError(common.CodeNotFound, "vacancy not found", err)
+		}
 		return nil, common.NewError(common.CodeInternal, "failed to update vacancy", err)
 	}
 	return &v, nil
 }
 
 func (r *VacancyRepository) GetByID(ctx context.Context, id common.UUID) (*vacancy.Vacancy, error) {
-	row := r.db.QueryRowContext(ctx, `SELECT id, company_id, title, vacancy_type, description, requirements, conditions, salary, location, status, created_at, updated_at FROM vacancies WHERE id = $1`, id)
+	row := conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+vacancySelectColumns+` FROM vacancies WHERE id = $1`, id)
 	var v vacancy.Vacancy
-	if err := row.Scan(&v.ID, &v.CompanyID, &v.Title, &v.Type, &v.Description, pq.Array(&v.Requirements), pq.Array(&v.Conditions), &v.Salary, &v.Location, &v.Status, &v.CreatedAt, &v.UpdatedAt); err != nil {
+	if err := scanVacancy(row, &v); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, common.NewError(common.CodeNotFound, "vacancy not found", err)
 		}
@@ -57,38 +94,153 @@ func (r *VacancyRepository) GetByID(ctx context.Context, id common.UUID) (*vacan
 	return &v, nil
 }
 
-func (r *VacancyRepository) ListPublished(ctx context.Context, limit, offset int) ([]vacancy.Vacancy, error) {
-	rows, err := r.db.QueryContext(ctx, `SELECT id, company_id, title, vacancy_type, description, requirements, conditions, salary, location, status, created_at, updated_at
-		FROM vacancies WHERE status = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3`, vacancy.StatusPublished, limit, offset)
+func (r *VacancyRepository) ListPublished(ctx context.Context, page common.PageRequest) ([]vacancy.Vacancy, *common.Cursor, error) {
+	return r.Search(ctx, vacancy.SearchQuery{Sort: vacancy.SortNewest, Limit: page.Limit, After: page.After})
+}
+
+func (r *VacancyRepository) Search(ctx context.Context, query vacancy.SearchQuery) ([]vacancy.Vacancy, *common.Cursor, error) {
+	args := []interface{}{vacancy.StatusPublished, time.Now().UTC()}
+	conditions := []string{"status = $1", "(expires_at IS NULL OR expires_at > $2)"}
+	rankExpr := "0::real"
+	orderBy := "created_at DESC, id DESC"
+	from := "vacancies"
+	byRank := false
+	if query.Text != "" {
+		args = append(args, query.Text)
+		from = fmt.Sprintf("vacancies, websearch_to_tsquery('russian', $%d) AS q", len(args))
+		conditions = append(conditions, "search_vector @@ q")
+		rankExpr = "ts_rank(search_vector, q)"
+		if query.Sort != vacancy.SortNewest {
+			byRank = true
+			orderBy = rankExpr + " DESC, created_at DESC, id DESC"
+		}
+	}
+	if query.Type != "" {
+		args = append(args, query.Type)
+		conditions = append(conditions, fmt.Sprintf("lower(vacancy_type) = lower($%d)", len(args)))
+	}
+	if query.Location != "" {
+		args = append(args, "%"+query.Location+"%")
+		conditions = append(conditions, fmt.Sprintf("location ILIKE $%d", len(args)))
+	}
+	if query.SalaryFrom != nil {
+		args = append(args, *query.SalaryFrom)
+		conditions = append(conditions, fmt.Sprintf("COALESCE(salary_max, salary_min) >= $%d", len(args)))
+	}
+	if query.SalaryTo != nil {
+		args = append(args, *query.SalaryTo)
+		conditions = append(conditions, fmt.Sprintf("COALESCE(salary_min, salary_max) <= $%d", len(args)))
+	}
+	if query.Period != "" {
+		args = append(args, query.Period)
+		conditions = append(conditions, fmt.Sprintf("salary_period = $%d", len(args)))
+	}
+	if query.After != nil {
+		if byRank {
+			args = append(args, query.After.Rank, query.After.CreatedAt, query.After.ID)
+			conditions = append(conditions, fmt.Sprintf("(%s, created_at, id) < ($%d::real, $%d, $%d)", rankExpr, len(args)-2, len(args)-1, len(args)))
+		} else {
+			args = append(args, query.After.CreatedAt, query.After.ID)
+			conditions = append(conditions, fmt.Sprintf("(created_at, id) < ($%d, $%d)", len(args)-1, len(args)))
+		}
+	}
+	args = append(args, query.Limit+1)
+	statement := fmt.Sprintf(`SELECT %s, %s
+		FROM %s WHERE %s ORDER BY %s LIMIT $%d`, vacancySelectColumns, rankExpr, from, strings.Join(conditions, " AND "), orderBy, len(args))
+	rows, err := conn(ctx, r.db).QueryContext(ctx, statement, args...)
 	if err != nil {
-		return nil, common.NewError(common.CodeInternal, "failed to list vacancies", err)
+		return nil, nil, common.NewError(common.CodeInternal, "failed to search vacancies", err)
+	}
+	defer rows.Close()
+	type rankedVacancy struct {
+		vacancy vacancy.Vacancy
+		rank    float64
+	}
+	var ranked []rankedVacancy
+	for rows.Next() {
+		var item rankedVacancy
+		v := &item.vacancy
+		if err := scanVacancy(rows, v, &item.rank); err != nil {
+			return nil, nil, common.NewError(common.CodeInternal, "failed to scan vacancy", err)
+		}
+		ranked = append(ranked, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, common.NewError(common.CodeInternal, "failed to search vacancies", err)
+	}
+	ranked, next := trimPage(ranked, query.Limit, func(item rankedVacancy) common.Cursor {
+		cursor := common.Cursor{CreatedAt: item.vacancy.CreatedAt, ID: item.vacancy.ID}
+		if byRank {
+			cursor.Rank = item.rank
+		}
+		return cursor
+	})
+	var items []vacancy.Vacancy
+	for _, item := range ranked {
+		items = append(items, item.vacancy)
+	}
+	return items, next, nil
+}
+
+func (r *VacancyRepository) ListByCompany(ctx context.Context, companyID common.UUID, page common.PageRequest) ([]vacancy.Vacancy, *common.Cursor, error) {
+	args := []interface{}{companyID}
+	condition := "company_id = $1"
+	if page.After != nil {
+		args = append(args, page.After.CreatedAt, page.After.ID)
+		condition += " AND (created_at, id) < ($2, $3)"
+	}
+	args = append(args, page.Limit+1)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, fmt.Sprintf(`SELECT %s
+		FROM vacancies WHERE %s ORDER BY created_at DESC, id DESC LIMIT $%d`, vacancySelectColumns, condition, len(args)), args...)
+	if err != nil {
+		return nil, nil, common.NewError(common.CodeInternal, "failed to list company vacancies", err)
 	}
 	defer rows.Close()
 	var items []vacancy.Vacancy
 	for rows.Next() {
 		var v vacancy.Vacancy
-		if err := rows.Scan(&v.ID, &v.CompanyID, &v.Title, &v.Type, &v.Description, pq.Array(&v.Requirements), pq.Array(&v.Conditions), &v.Salary, &v.Location, &v.Status, &v.CreatedAt, &v.UpdatedAt); err != nil {
-			return nil, common.NewError(common.CodeInternal, "failed to scan vacancy", err)
+		if err := scanVacancy(rows, &v); err != nil {
+			return nil, nil, common.NewError(common.CodeInternal, "failed to scan vacancy", err)
 		}
 		items = append(items, v)
 	}
-	return items, nil
+	if err := rows.Err(); err != nil {
+		return nil, nil, common.NewError(common.CodeInternal, "failed to list company vacancies", err)
+	}
+	items, next := trimPage(items, page.Limit, vacancyCursor)
+	return items, next, nil
 }
 
-func (r *VacancyRepository) ListByCompany(ctx context.Context, companyID common.UUID) ([]vacancy.Vacancy, error) {
-	rows, err := r.db.QueryContext(ctx, `SELECT id, company_id, title, vacancy_type, description, requirements, conditions, salary, location, status, created_at, updated_at
-		FROM vacancies WHERE company_id = $1 ORDER BY created_at DESC`, companyID)
+func (r *VacancyRepository) CloseExpired(ctx context.Context, now time.Time) ([]vacancy.Vacancy, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `UPDATE vacancies SET status = $1, updated_at = $2
+		WHERE status = $3 AND expires_at IS NOT NULL AND expires_at <= $2
+		RETURNING `+vacancySelectColumns, vacancy.StatusClosed, now, vacancy.StatusPublished)
 	if err != nil {
-		return nil, common.NewError(common.CodeInternal, "failed to list company vacancies", err)
+		return nil, common.NewError(common.CodeInternal, "failed to close expired vacancies", err)
 	}
 	defer rows.Close()
 	var items []vacancy.Vacancy
 	for rows.Next() {
 		var v vacancy.Vacancy
-		if err := rows.Scan(&v.ID, &v.CompanyID, &v.Title, &v.Type, &v.Description, pq.Array(&v.Requirements), pq.Array(&v.Conditions), &v.Salary, &v.Location, &v.Status, &v.CreatedAt, &v.UpdatedAt); err != nil {
+		if err := scanVacancy(rows, &v); err != nil {
 			return nil, common.NewError(common.CodeInternal, "failed to scan vacancy", err)
 		}
 		items = append(items, v)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, common.NewError(common.CodeInternal, "failed to close expired vacancies", err)
+	}
 	return items, nil
 }
+
+func nullIntPtr(value sql.NullInt64) *int {
+	if !value.Valid {
+		return nil
+	}
+	v := int(value.Int64)
+	return &v
+}
+
+func vacancyCursor(v vacancy.Vacancy) common.Cursor {
+	return common.Cursor{CreatedAt: v.CreatedAt, ID: v.ID}
+}
@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"profzom/internal/common"
+	"profzom/internal/domain/profile"
+)
+
+type VerificationRepository struct {
+	db *sql.DB
+}
+
+func NewVerificationRepository(db *sql.DB) *VerificationRepository {
+	return &VerificationRepository{db: db}
+}
+
+const verificationColumns = `cv.company_id, COALESCE(cp.name, ''), cv.status, cv.legal_name, cv.inn, cv.ogrn, cv.rejection_reason, cv.submitted_at, cv.reviewed_by, cv.reviewed_at, cv.updated_at`
+
+// companyVerifiedColumn — бейдж company_verified для выборок вакансий: заявка компании-владельца одобрена.
+func companyVerifiedColumn(table string) string {
+	return `EXISTS (SELECT 1 FROM company_verifications cv WHERE cv.company_id = ` + table + `.company_id AND cv.status = 'verified')`
+}
+
+func scanVerification(row rowScanner, v *profile.CompanyVerification) error {
+	var submittedAt time.Time
+	var reviewedBy sql.NullString
+	var reviewedAt sql.NullTime
+	if err := row.Scan(&v.CompanyID, &v.CompanyName, &v.Status, &v.LegalName, &v.INN, &v.OGRN, &v.RejectionReason, &submittedAt, &reviewedBy, &reviewedAt, &v.UpdatedAt); err != nil {
+		return err
+	}
+	v.SubmittedAt = &submittedAt
+	v.ReviewedBy = nullUUID(reviewedBy)
+	v.ReviewedAt = nil
+	if reviewedAt.Valid {
+		value := reviewedAt.Time
+		v.ReviewedAt = &value
+	}
+	return nil
+}
+
+func (r *VerificationRepository) GetByCompany(ctx context.Context, companyID common.UUID) (*profile.CompanyVerification, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+verificationColumns+`
+		FROM company_verifications cv LEFT JOIN company_profiles cp ON cp.user_id = cv.company_id
+		WHERE cv.company_id = $1`, companyID)
+	var v profile.CompanyVerification
+	if err := scanVerification(row, &v); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, common.NewError(common.CodeNotFound, "company verification not found", err)
+		}
+		return nil, common.NewError(common.CodeInternal, "failed to load company verification", err)
+	}
+	return &v, nil
+}
+
+func (r *VerificationRepository) Save(ctx context.Context, v profile.CompanyVerification) (*profile.CompanyVerification, error) {
+	v.UpdatedAt = time.Now().UTC()
+	var companyID common.UUID
+	err := conn(ctx, r.db).QueryRowContext(ctx, `INSERT INTO company_verifications (company_id, status, legal_name, inn, ogrn, rejection_reason, submitted_at, reviewed_by, reviewed_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (company_id) DO UPDATE SET status = EXCLUDED.status, legal_name = EXCLUDED.legal_name, inn = EXCLUDED.inn, ogrn = EXCLUDED.ogrn,
+		rejection_reason = EXCLUDED.rejection_reason, submitted_at = EXCLUDED.submitted_at, reviewed_by = EXCLUDED.reviewed_by,
+		reviewed_at = EXCLUDED.reviewed_at, updated_at = EXCLUDED.updated_at
+		WHERE company_verifications.status <> $11
+		RETURNING company_id`,
+		v.CompanyID, v.Status, v.LegalName, v.INN, v.OGRN, v.RejectionReason, v.SubmittedAt, nullableUUIDPtr(v.ReviewedBy), v.ReviewedAt, v.UpdatedAt, profile.VerificationVerified).Scan(&companyID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, common.NewError(common.CodeConflict, "company is already verified", err)
+		}
+		return nil, common.NewError(common.CodeInternal, "failed to save company verification", err)
+	}
+	return &v, nil
+}
+
+func (r *VerificationRepository) Review(ctx context.Context, v profile.CompanyVerification) (*profile.CompanyVerification, error) {
+	seen := v.UpdatedAt
+	v.UpdatedAt = time.Now().UTC()
+	var companyID common.UUID
+	err := conn(ctx, r.db).QueryRowContext(ctx, `UPDATE company_verifications
+		SET status = $2, rejection_reason = $3, reviewed_by = $4, reviewed_at = $5, updated_at = $6
+		WHERE company_id = $1 AND status = $7 AND updated_at = $8
+		RETURNING company_id`,
+		v.CompanyID, v.Status, v.RejectionReason, nullableUUIDPtr(v.ReviewedBy), v.ReviewedAt, v.UpdatedAt, profile.VerificationPending, seen).Scan(&companyID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, common.NewError(common.CodeConflict, "verification is no longer pending review", err)
+		}
+		return nil, common.NewError(common.CodeInternal, "failed to review company verification", err)
+	}
+	return &v, nil
+}
+
+func (r *VerificationRepository) ListByStatus(ctx context.Context, status profile.VerificationStatus, page common.PageRequest) ([]profile.CompanyVerification, *common.Cursor, error) {
+	args := []interface{}{status}
+	condition := "cv.status = $1"
+	if page.After != nil {
+		args = append(args, page.After.CreatedAt, page.After.ID)
+		condition += " AND (cv.submitted_at, cv.company_id) > ($2, $3)"
+	}
+	args = append(args, page.Limit+1)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, fmt.Sprintf(`SELECT %s
+		FROM company_verifications cv LEFT JOIN company_profiles cp ON cp.user_id = cv.company_id
+		WHERE %s ORDER BY cv.submitted_at, cv.company_id LIMIT $%d`, verificationColumns, condition, len(args)), args...)
+	if err != nil {
+		return nil, nil, common.NewError(common.CodeInternal, "failed to list company verifications", err)
+	}
+	defer rows.Close()
+	var items []profile.CompanyVerification
+	for rows.Next() {
+		var v profile.CompanyVerification
+		if err := scanVerification(rows, &v); err != nil {
+			return nil, nil, common.NewError(common.CodeInternal, "failed to scan company verification", err)
+		}
+		items = append(items, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, common.NewError(common.CodeInternal, "failed to list company verifications", err)
+	}
+	items, next := trimPage(items, page.Limit, func(v profile.CompanyVerification) common.Cursor {
+		return common.Cursor{CreatedAt: *v.SubmittedAt, ID: v.CompanyID}
+	})
+	return items, next, nil
+}
@@ -0,0 +1,28 @@
+package resume
+
+import "time"
+
+// Document — резюме, подготовленное к выводу: сервис собирает его из профиля, а рендеры не знают о доменных типах.
+type Document struct {
+	Name        string
+	Headline    string
+	Details     []string
+	Skills      []string
+	About       string
+	Sections    []Section
+	GeneratedAt time.Time
+}
+
+type Section struct {
+	Title   string
+	Entries []Entry
+}
+
+// Entry — строка раздела: заголовок, подзаголовок, период и необязательные описание и ссылка.
+type Entry struct {
+	Title       string
+	Subtitle    string
+	Period      string
+	Description string
+	URL         string
+}
@@ -0,0 +1,58 @@
+package resume
+
+import (
+	"bytes"
+	"html/template"
+)
+
+var htmlTemplate = template.Must(template.New("resume").Parse(`<!DOCTYPE html>
+<html lang="ru">
+<head>
+<meta charset="utf-8">
+<title>{{.Name}}</title>
+<style>
+body { font-family: "Helvetica Neue", Arial, sans-serif; color: #222; max-width: 760px; margin: 32px auto; padding: 0 24px; line-height: 1.45; }
+h1 { margin: 0; font-size: 28px; }
+h2 { margin: 28px 0 8px; font-size: 16px; text-transform: uppercase; letter-spacing: .06em; border-bottom: 1px solid #ddd; padding-bottom: 4px; }
+.headline { font-size: 17px; color: #555; margin: 4px 0; }
+.details, .meta { color: #666; font-size: 14px; }
+.entry { margin: 10px 0; }
+.entry-title { font-weight: bold; }
+.period { float: right; color: #666; font-size: 14px; }
+.description { margin: 4px 0 0; white-space: pre-line; }
+.skills span { display: inline-block; background: #f1f1f1; border-radius: 4px; padding: 2px 8px; margin: 0 4px 4px 0; font-size: 14px; }
+footer { margin-top: 40px; color: #999; font-size: 12px; }
+@media print { body { margin: 0 auto; } a { color: inherit; } }
+</style>
+</head>
+<body>
+<header>
+<h1>{{.Name}}</h1>
+{{if .Headline}}<p class="headline">{{.Headline}}</p>{{end}}
+{{if .Details}}<p class="details">{{range $i, $d := .Details}}{{if $i}} · {{end}}{{$d}}{{end}}</p>{{end}}
+</header>
+{{if .About}}<h2>About</h2>
+<p class="description">{{.About}}</p>{{end}}
+{{if .Skills}}<h2>Skills</h2>
+<p class="skills">{{range .Skills}}<span>{{.}}</span>{{end}}</p>{{end}}
+{{range .Sections}}<h2>{{.Title}}</h2>
+{{range .Entries}}<div class="entry">
+{{if .Period}}<span class="period">{{.Period}}</span>{{end}}
+<div class="entry-title">{{if .URL}}<a href="{{.URL}}">{{.Title}}</a>{{else}}{{.Title}}{{end}}</div>
+{{if .Subtitle}}<div class="meta">{{.Subtitle}}</div>{{end}}
+{{if .Description}}<p class="description">{{.Description}}</p>{{end}}
+</div>
+{{end}}{{end}}
+<footer>Generated by ProfZoom on {{.GeneratedAt.Format "2006-01-02"}}</footer>
+</body>
+</html>
+`))
+
+// RenderHTML собирает самостоятельную HTML-страницу резюме со встроенными стилями, пригодную для печати в PDF из браузера.
+func RenderHTML(doc Document) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, doc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
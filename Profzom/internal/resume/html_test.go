@@ -0,0 +1,23 @@
+package resume
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderHTML_EscapesContent(t *testing.T) {
+	doc := sampleDocument()
+	doc.About = "<script>alert(1)</script>"
+	doc.Sections[0].Entries[0].URL = "javascript:alert(1)"
+	rendered, err := RenderHTML(doc)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	html := string(rendered)
+	if strings.Contains(html, "<script>") || strings.Contains(html, "javascript:") {
+		t.Fatalf("expected user content to be escaped, got %s", html)
+	}
+	if !strings.Contains(html, "Иван Петров") || !strings.Contains(html, "2023-06 – present") {
+		t.Fatal("expected name and period in original spelling")
+	}
+}
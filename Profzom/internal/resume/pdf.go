@@ -0,0 +1,282 @@
+package resume
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	pageWidth    = 595.28
+	pageHeight   = 841.89
+	pageMargin   = 50.0
+	contentWidth = pageWidth - 2*pageMargin
+	lineSpacing  = 1.35
+
+	colorText  = "0.13"
+	colorMuted = "0.4"
+)
+
+type pdfFont string
+
+const (
+	fontRegular pdfFont = "F1"
+	fontBold    pdfFont = "F2"
+)
+
+// RenderPDF собирает PDF резюме на стандартных шрифтах Helvetica без встраивания файлов шрифтов.
+// Такие шрифты покрывают только WinAnsi, поэтому кириллица транслитерируется; точное написание сохраняет HTML-экспорт.
+func RenderPDF(doc Document) []byte {
+	layout := newPDFLayout()
+	layout.text(doc.Name, fontBold, 20, colorText, 0)
+	if doc.Headline != "" {
+		layout.text(doc.Headline, fontRegular, 13, colorMuted, 2)
+	}
+	if len(doc.Details) > 0 {
+		layout.text(strings.Join(doc.Details, " · "), fontRegular, 10, colorMuted, 2)
+	}
+	if doc.About != "" {
+		layout.heading("About")
+		layout.text(doc.About, fontRegular, 10.5, colorText, 0)
+	}
+	if len(doc.Skills) > 0 {
+		layout.heading("Skills")
+		layout.text(strings.Join(doc.Skills, ", "), fontRegular, 10.5, colorText, 0)
+	}
+	for _, section := range doc.Sections {
+		layout.heading(section.Title)
+		for i, entry := range section.Entries {
+			gap := 8.0
+			if i == 0 {
+				gap = 0
+			}
+			layout.entryTitle(entry.Title, entry.Period, gap)
+			if entry.Subtitle != "" {
+				layout.text(entry.Subtitle, fontRegular, 10, colorMuted, 0)
+			}
+			if entry.Description != "" {
+				layout.text(entry.Description, fontRegular, 10.5, colorText, 2)
+			}
+			if entry.URL != "" {
+				layout.text(entry.URL, fontRegular, 9.5, colorMuted, 0)
+			}
+		}
+	}
+	layout.text("Generated by ProfZoom on "+doc.GeneratedAt.Format("2006-01-02"), fontRegular, 8, colorMuted, 24)
+	return layout.document(doc)
+}
+
+type pdfLayout struct {
+	pages []*bytes.Buffer
+	y     float64
+}
+
+func newPDFLayout() *pdfLayout {
+	layout := &pdfLayout{}
+	layout.newPage()
+	return layout
+}
+
+func (l *pdfLayout) newPage() {
+	l.pages = append(l.pages, &bytes.Buffer{})
+	l.y = pageHeight - pageMargin
+}
+
+func (l *pdfLayout) page() *bytes.Buffer {
+	return l.pages[len(l.pages)-1]
+}
+
+// advance опускает строку на height и переносит её на новую страницу, если она не помещается.
+func (l *pdfLayout) advance(height float64) {
+	if l.y-height < pageMargin {
+		l.newPage()
+	}
+	l.y -= height
+}
+
+func (l *pdfLayout) heading(title string) {
+	l.advance(18)
+	l.text(strings.ToUpper(title), fontBold, 11, colorText, 0)
+	fmt.Fprintf(l.page(), "%s G 0.5 w %.2f %.2f m %.2f %.2f l S\n", colorMuted, pageMargin, l.y-4, pageWidth-pageMargin, l.y-4)
+	l.y -= 8
+}
+
+// entryTitle пишет заголовок записи жирным, а период — справа на той же строке.
+func (l *pdfLayout) entryTitle(title, period string, gap float64) {
+	l.advance(gap)
+	width := contentWidth
+	encodedPeriod := encodeWinAnsi(period)
+	periodWidth := textWidth(encodedPeriod, fontRegular, 10)
+	if period != "" {
+		width -= periodWidth + 12
+	}
+	for i, line := range wrapText(encodeWinAnsi(title), fontBold, 11, width) {
+		l.advance(11 * lineSpacing)
+		l.show(line, fontBold, 11, colorText, pageMargin)
+		if i == 0 && period != "" {
+			l.show(encodedPeriod, fontRegular, 10, colorMuted, pageWidth-pageMargin-periodWidth)
+		}
+	}
+}
+
+func (l *pdfLayout) text(value string, font pdfFont, size float64, color string, gap float64) {
+	l.advance(gap)
+	for _, paragraph := range strings.Split(value, "\n") {
+		for _, line := range wrapText(encodeWinAnsi(paragraph), font, size, contentWidth) {
+			l.advance(size * lineSpacing)
+			l.show(line, font, size, color, pageMargin)
+		}
+	}
+}
+
+func (l *pdfLayout) show(line []byte, font pdfFont, size float64, color string, x float64) {
+	fmt.Fprintf(l.page(), "BT %s g /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", color, font, size, x, l.y, escapePDFString(line))
+}
+
+func (l *pdfLayout) document(doc Document) []byte {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	kids := make([]string, 0, len(l.pages))
+	for i := range l.pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", 6+2*i))
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(l.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	object(fmt.Sprintf("<< /Title (%s) /Producer (ProfZoom) /CreationDate (D:%s) >>",
+		escapePDFString(encodeWinAnsi(doc.Name)), doc.GeneratedAt.UTC().Format("20060102150405Z")))
+	for i, content := range l.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, 7+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.Bytes()))
+	}
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+// wrapText разбивает строку по словам так, чтобы каждая строка помещалась в width; слишком длинные слова режутся.
+func wrapText(text []byte, font pdfFont, size, width float64) [][]byte {
+	words := bytes.Fields(text)
+	if len(words) == 0 {
+		return [][]byte{{}}
+	}
+	var lines [][]byte
+	var current []byte
+	for _, word := range words {
+		for textWidth(word, font, size) > width {
+			cut := 1
+			for cut < len(word) && textWidth(word[:cut+1], font, size) <= width {
+				cut++
+			}
+			if len(current) > 0 {
+				lines = append(lines, current)
+				current = nil
+			}
+			lines = append(lines, word[:cut])
+			word = word[cut:]
+		}
+		candidate := word
+		if len(current) > 0 {
+			candidate = append(append(append([]byte{}, current...), ' '), word...)
+		}
+		if textWidth(candidate, font, size) > width && len(current) > 0 {
+			lines = append(lines, current)
+			candidate = append([]byte{}, word...)
+		}
+		current = candidate
+	}
+	return append(lines, current)
+}
+
+func textWidth(text []byte, font pdfFont, size float64) float64 {
+	total := 0
+	for _, b := range text {
+		if b >= 32 && b <= 126 {
+			total += helveticaWidths[b-32]
+		} else {
+			total += 556
+		}
+	}
+	width := float64(total) * size / 1000
+	if font == fontBold {
+		// у Helvetica-Bold глифы шире; запаса в 7% хватает, чтобы строка не вылезла за поле
+		width *= 1.07
+	}
+	return width
+}
+
+// helveticaWidths — ширины символов Helvetica для ASCII 32–126 в тысячных долях кегля (из AFM).
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+var winAnsiSpecial = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94,
+	'•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+var cyrillicTranslit = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh", 'з': "z", 'и': "i",
+	'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t",
+	'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "",
+	'э': "e", 'ю': "yu", 'я': "ya", 'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g",
+}
+
+// encodeWinAnsi переводит строку в кодировку WinAnsi; кириллица транслитерируется, остальные символы вне кодировки заменяются на "?".
+func encodeWinAnsi(value string) []byte {
+	out := make([]byte, 0, len(value))
+	for _, r := range value {
+		switch {
+		case r == '\t':
+			out = append(out, ' ')
+		case r < 32:
+		case r < 127 || (r >= 0xA0 && r <= 0xFF):
+			out = append(out, byte(r))
+		case winAnsiSpecial[r] != 0:
+			out = append(out, winAnsiSpecial[r])
+		case r == '№':
+			out = append(out, "No."...)
+		default:
+			lower := []rune(strings.ToLower(string(r)))[0]
+			translit, ok := cyrillicTranslit[lower]
+			if !ok {
+				out = append(out, '?')
+				continue
+			}
+			if lower != r && translit != "" {
+				first, size := utf8.DecodeRuneInString(translit)
+				translit = strings.ToUpper(string(first)) + translit[size:]
+			}
+			out = append(out, translit...)
+		}
+	}
+	return out
+}
+
+func escapePDFString(value []byte) []byte {
+	out := make([]byte, 0, len(value))
+	for _, b := range value {
+		if b == '\\' || b == '(' || b == ')' {
+			out = append(out, '\\')
+		}
+		out = append(out, b)
+	}
+	return out
+}
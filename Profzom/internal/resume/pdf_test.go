@@ -0,0 +1,80 @@
+package resume
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func sampleDocument() Document {
+	return Document{
+		Name:        "Иван Петров",
+		Headline:    "Backend developer",
+		Details:     []string{"МГУ", "Course 3"},
+		Skills:      []string{"go", "postgresql"},
+		About:       "Люблю (сложные) задачи\\",
+		Sections:    []Section{{Title: "Experience", Entries: []Entry{{Title: "Intern", Subtitle: "Яндекс", Period: "2023-06 – present", Description: "Wrote services"}}}},
+		GeneratedAt: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestRenderPDF_ValidStructure(t *testing.T) {
+	doc := RenderPDF(sampleDocument())
+	if !bytes.HasPrefix(doc, []byte("%PDF-1.4")) || !bytes.HasSuffix(doc, []byte("%%EOF\n")) {
+		t.Fatal("expected PDF header and EOF marker")
+	}
+	match := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(doc)
+	if match == nil {
+		t.Fatal("expected startxref")
+	}
+	xref, _ := strconv.Atoi(string(match[1]))
+	if !bytes.HasPrefix(doc[xref:], []byte("xref\n")) {
+		t.Fatalf("expected startxref to point at xref table, got %q", doc[xref:xref+10])
+	}
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(doc[xref:], -1)
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		if !bytes.HasPrefix(doc[offset:], []byte(fmt.Sprintf("%d 0 obj", i+1))) {
+			t.Fatalf("expected xref entry %d to point at its object", i+1)
+		}
+	}
+}
+
+func TestRenderPDF_TransliteratesAndEscapes(t *testing.T) {
+	doc := string(RenderPDF(sampleDocument()))
+	for _, expected := range []string{"(Ivan Petrov)", "(Yandeks)", `Lyublyu \(slozhnye\) zadachi\\`, "2023-06 \x96 present"} {
+		if !strings.Contains(doc, expected) {
+			t.Fatalf("expected PDF to contain %q", expected)
+		}
+	}
+}
+
+func TestRenderPDF_BreaksLongResumeIntoPages(t *testing.T) {
+	doc := sampleDocument()
+	var entries []Entry
+	for i := 0; i < 60; i++ {
+		entries = append(entries, Entry{Title: fmt.Sprintf("Project %d", i), Description: strings.Repeat("long description ", 20)})
+	}
+	doc.Sections = []Section{{Title: "Projects", Entries: entries}}
+	rendered := string(RenderPDF(doc))
+	count := regexp.MustCompile(`/Count (\d+)`).FindStringSubmatch(rendered)
+	if pages, _ := strconv.Atoi(count[1]); pages < 2 {
+		t.Fatalf("expected several pages, got %s", count[1])
+	}
+}
+
+func TestWrapText_FitsWidth(t *testing.T) {
+	lines := wrapText([]byte(strings.Repeat("word ", 50)+strings.Repeat("x", 200)), fontRegular, 10, 200)
+	for _, line := range lines {
+		if textWidth(line, fontRegular, 10) > 200 {
+			t.Fatalf("expected line to fit width, got %q", line)
+		}
+	}
+	if len(lines) < 3 {
+		t.Fatalf("expected text to wrap, got %d lines", len(lines))
+	}
+}
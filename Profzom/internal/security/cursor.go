@@ -0,0 +1,69 @@
+package security
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"profzom/internal/common"
+)
+
+// CursorSigner кодирует курсоры пагинации в непрозрачную строку, подписанную HMAC,
+// чтобы клиент не мог подменить позицию в выдаче.
+type CursorSigner struct {
+	secret []byte
+}
+
+func NewCursorSigner(secret string) *CursorSigner {
+	return &CursorSigner{secret: []byte(secret)}
+}
+
+type cursorPayload struct {
+	CreatedAt string  `json:"t"`
+	ID        string  `json:"id"`
+	Rank      float64 `json:"r,omitempty"`
+	Scope     string  `json:"s,omitempty"`
+}
+
+func (s *CursorSigner) Encode(cursor common.Cursor) (string, error) {
+	payloadJSON, err := json.Marshal(cursorPayload{
+		CreatedAt: cursor.CreatedAt.UTC().Format(time.RFC3339Nano),
+		ID:        cursor.ID.String(),
+		Rank:      cursor.Rank,
+		Scope:     cursor.Scope,
+	})
+	if err != nil {
+		return "", err
+	}
+	payloadEnc := base64.RawURLEncoding.EncodeToString(payloadJSON)
+	return payloadEnc + "." + signHS256(payloadEnc, s.secret), nil
+}
+
+func (s *CursorSigner) Decode(value string) (*common.Cursor, error) {
+	parts := strings.Split(value, ".")
+	if len(parts) != 2 {
+		return nil, errors.New("invalid cursor format")
+	}
+	if !verifyHS256(parts[0], parts[1], s.secret) {
+		return nil, errors.New("invalid cursor signature")
+	}
+	payloadJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	var payload cursorPayload
+	if err := json.Unmarshal(payloadJSON, &payload); err != nil {
+		return nil, err
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, payload.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	id, err := common.ParseUUID(payload.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &common.Cursor{CreatedAt: createdAt, ID: id, Rank: payload.Rank, Scope: payload.Scope}, nil
+}
@@ -0,0 +1,39 @@
+package security
+
+import (
+	"testing"
+	"time"
+
+	"profzom/internal/common"
+)
+
+func TestCursorSigner_RoundTrip(t *testing.T) {
+	signer := NewCursorSigner("secret")
+	cursor := common.Cursor{CreatedAt: time.Date(2024, 5, 1, 10, 30, 0, 123456000, time.UTC), ID: common.NewUUID(), Rank: 0.25, Scope: "3f2a"}
+
+	encoded, err := signer.Encode(cursor)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	decoded, err := signer.Decode(encoded)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if !decoded.CreatedAt.Equal(cursor.CreatedAt) || decoded.ID != cursor.ID || decoded.Rank != cursor.Rank || decoded.Scope != cursor.Scope {
+		t.Fatalf("expected %+v, got %+v", cursor, *decoded)
+	}
+}
+
+func TestCursorSigner_RejectsTampered(t *testing.T) {
+	signer := NewCursorSigner("secret")
+	encoded, err := signer.Encode(common.Cursor{CreatedAt: time.Now().UTC(), ID: common.NewUUID()})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if _, err := NewCursorSigner("other").Decode(encoded); err == nil {
+		t.Fatal("expected signature error for foreign secret")
+	}
+	if _, err := signer.Decode("x" + encoded); err == nil {
+		t.Fatal("expected error for tampered cursor")
+	}
+}
@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLocal_PutOpenDelete(t *testing.T) {
+	store, err := NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	ctx := context.Background()
+	if err := store.Put(ctx, "attachments/a/b", "text/plain", strings.NewReader("hello"), 5); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	file, err := store.Open(ctx, "attachments/a/b")
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	data, _ := io.ReadAll(file)
+	file.Close()
+	if string(data) != "hello" {
+		t.Fatalf("expected stored content, got %q", data)
+	}
+	if err := store.Delete(ctx, "attachments/a/b"); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if _, err := store.Open(ctx, "attachments/a/b"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestLocal_RejectsSizeMismatch(t *testing.T) {
+	store, _ := NewLocal(t.TempDir())
+	ctx := context.Background()
+	if err := store.Put(ctx, "a/b", "text/plain", strings.NewReader("hello"), 10); err == nil {
+		t.Fatal("expected size mismatch error")
+	}
+	if _, err := store.Open(ctx, "a/b"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected partial object to be discarded, got %v", err)
+	}
+}
+
+func TestLocal_RejectsPathTraversal(t *testing.T) {
+	store, _ := NewLocal(t.TempDir())
+	for _, key := range []string{"../secret", "a/../../b", "/etc/passwd", "a//b", ""} {
+		if err := store.Put(context.Background(), key, "text/plain", strings.NewReader("x"), 1); err == nil {
+			t.Fatalf("expected error for key %q", key)
+		}
+	}
+}
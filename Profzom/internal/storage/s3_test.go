@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeS3 — локальная подмена S3: хранит объекты в памяти и перепроверяет подпись каждого запроса.
+type fakeS3 struct {
+	signer  *S3
+	mu      sync.Mutex
+	objects map[string]string
+	types   map[string]string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	date, err := time.Parse("20060102T150405Z", r.Header.Get("X-Amz-Date"))
+	if err != nil {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	check, _ := http.NewRequest(r.Method, "http://"+r.Host+r.URL.EscapedPath(), nil)
+	f.signer.sign(check, date)
+	if check.Header.Get("Authorization") != r.Header.Get("Authorization") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.Path] = string(body)
+		f.types[r.URL.Path] = r.Header.Get("Content-Type")
+	case http.MethodGet:
+		body, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		io.WriteString(w, body)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func newFakeS3(t *testing.T) (*S3, *fakeS3) {
+	t.Helper()
+	cfg := S3Config{Bucket: "profzom", Region: "ru-central1", AccessKey: "AKID", SecretKey: "secret"}
+	fake := &fakeS3{signer: NewS3(cfg, nil), objects: map[string]string{}, types: map[string]string{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	cfg.Endpoint = server.URL + "/"
+	return NewS3(cfg, server.Client()), fake
+}
+
+func TestS3_PutOpenDelete(t *testing.T) {
+	store, fake := newFakeS3(t)
+	ctx := context.Background()
+	if err := store.Put(ctx, "attachments/app/file id", "application/pdf", strings.NewReader("%PDF-1.4"), 8); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if fake.types["/profzom/attachments/app/file id"] != "application/pdf" {
+		t.Fatalf("expected object stored under bucket path with content type, got %v", fake.types)
+	}
+	body, err := store.Open(ctx, "attachments/app/file id")
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	data, _ := io.ReadAll(body)
+	body.Close()
+	if string(data) != "%PDF-1.4" {
+		t.Fatalf("expected stored content, got %q", data)
+	}
+	if err := store.Delete(ctx, "attachments/app/file id"); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if _, err := store.Open(ctx, "attachments/app/file id"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestS3_RejectsWrongSecret(t *testing.T) {
+	store, _ := newFakeS3(t)
+	store.cfg.SecretKey = "other"
+	err := store.Put(context.Background(), "a/b", "text/plain", strings.NewReader("x"), 1)
+	if err == nil || !strings.Contains(err.Error(), "status=403") {
+		t.Fatalf("expected 403 from stand-in, got %v", err)
+	}
+}
@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+)
+
+// ErrNotFound возвращается, если объекта с таким ключом нет в хранилище.
+var ErrNotFound = errors.New("storage: object not found")
+
+// Storage хранит файлы вложений по ключу. Ключ — относительный путь из сегментов через "/", без "..".
+type Storage interface {
+	Put(ctx context.Context, key, contentType string, body io.Reader, size int64) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+func validKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return false
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,37 @@
+-- +goose Up
+ALTER TABLE vacancies
+    ADD COLUMN search_vector TSVECTOR;
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION vacancies_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('russian', coalesce(NEW.title, '')), 'A') ||
+        setweight(to_tsvector('russian', coalesce(array_to_string(NEW.requirements, ' '), '')), 'B') ||
+        setweight(to_tsvector('russian', coalesce(NEW.description, '')), 'C');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER vacancies_search_vector_trigger
+    BEFORE INSERT OR UPDATE OF title, description, requirements ON vacancies
+    FOR EACH ROW EXECUTE FUNCTION vacancies_search_vector_update();
+
+UPDATE vacancies
+SET search_vector =
+    setweight(to_tsvector('russian', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('russian', coalesce(array_to_string(requirements, ' '), '')), 'B') ||
+    setweight(to_tsvector('russian', coalesce(description, '')), 'C');
+
+CREATE INDEX IF NOT EXISTS idx_vacancies_search ON vacancies USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_vacancies_type ON vacancies(vacancy_type);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_vacancies_type;
+DROP INDEX IF EXISTS idx_vacancies_search;
+DROP TRIGGER IF EXISTS vacancies_search_vector_trigger ON vacancies;
+DROP FUNCTION IF EXISTS vacancies_search_vector_update();
+
+ALTER TABLE vacancies
+    DROP COLUMN search_vector;
@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE INDEX IF NOT EXISTS idx_vacancies_status_created ON vacancies(status, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_vacancies_company_created ON vacancies(company_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_applications_student_created ON applications(student_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_applications_vacancy_created ON applications(vacancy_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_messages_application_created ON messages(application_id, created_at, id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_messages_application_created;
+DROP INDEX IF EXISTS idx_applications_vacancy_created;
+DROP INDEX IF EXISTS idx_applications_student_created;
+DROP INDEX IF EXISTS idx_vacancies_company_created;
+DROP INDEX IF EXISTS idx_vacancies_status_created;
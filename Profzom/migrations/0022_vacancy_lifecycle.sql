@@ -0,0 +1,14 @@
+-- +goose Up
+ALTER TABLE vacancies
+    ADD COLUMN expires_at TIMESTAMP NULL;
+
+CREATE INDEX IF NOT EXISTS idx_vacancies_expires_published ON vacancies(expires_at)
+    WHERE status = 'published' AND expires_at IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_vacancies_expires_published;
+
+UPDATE vacancies SET status = 'draft' WHERE status IN ('closed', 'archived');
+
+ALTER TABLE vacancies
+    DROP COLUMN expires_at;
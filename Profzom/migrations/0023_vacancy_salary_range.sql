@@ -0,0 +1,56 @@
+-- +goose Up
+ALTER TABLE vacancies
+    ADD COLUMN salary_min INT NULL,
+    ADD COLUMN salary_max INT NULL,
+    ADD COLUMN salary_currency TEXT NOT NULL DEFAULT '',
+    ADD COLUMN salary_period TEXT NOT NULL DEFAULT 'month',
+    ADD COLUMN salary_negotiable BOOLEAN NOT NULL DEFAULT false;
+
+-- Разбор старого текстового поля salary по возможности:
+-- "50 000–70 000 ₽" -> 50000..70000 RUB, "до 70 000" -> max, "от 500 ₽/час" -> min, period = hour.
+-- Исходная колонка salary сохраняется, чтобы неразобранные значения можно было поправить вручную.
+WITH parsed AS (
+    SELECT id,
+           lower(salary) AS raw,
+           ARRAY(
+               SELECT m[1]::INT
+               FROM regexp_matches(regexp_replace(salary, '[\s\u00a0\u202f]', '', 'g'), '(\d{1,9})', 'g') AS m
+           ) AS nums
+    FROM vacancies
+    WHERE salary <> ''
+)
+UPDATE vacancies v
+SET salary_min = CASE
+        WHEN cardinality(p.nums) >= 2 THEN p.nums[1]
+        WHEN cardinality(p.nums) = 1 AND p.raw !~ '^\s*до' THEN p.nums[1]
+    END,
+    salary_max = CASE
+        WHEN cardinality(p.nums) >= 2 THEN p.nums[2]
+        WHEN cardinality(p.nums) = 1 AND p.raw ~ '^\s*до' THEN p.nums[1]
+    END,
+    salary_currency = CASE
+        WHEN cardinality(p.nums) = 0 THEN ''
+        WHEN p.raw ~ '(\$|usd|долл)' THEN 'USD'
+        WHEN p.raw ~ '(€|eur|евро)' THEN 'EUR'
+        ELSE 'RUB'
+    END,
+    salary_period = CASE WHEN p.raw ~ '(час|/ч|hour)' THEN 'hour' ELSE 'month' END,
+    salary_negotiable = p.raw ~ '(договор|negotiable|по итогам)' OR cardinality(p.nums) = 0
+FROM parsed p
+WHERE v.id = p.id;
+
+UPDATE vacancies
+SET salary_min = salary_max, salary_max = salary_min
+WHERE salary_min IS NOT NULL AND salary_max IS NOT NULL AND salary_min > salary_max;
+
+CREATE INDEX IF NOT EXISTS idx_vacancies_salary ON vacancies(salary_period, salary_min, salary_max);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_vacancies_salary;
+
+ALTER TABLE vacancies
+    DROP COLUMN salary_min,
+    DROP COLUMN salary_max,
+    DROP COLUMN salary_currency,
+    DROP COLUMN salary_period,
+    DROP COLUMN salary_negotiable;
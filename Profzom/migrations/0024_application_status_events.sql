@@ -0,0 +1,27 @@
+-- +goose Up
+CREATE TABLE application_status_events (
+    id UUID PRIMARY KEY,
+    application_id UUID NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    from_status TEXT NOT NULL DEFAULT '',
+    to_status TEXT NOT NULL,
+    changed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    feedback TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_application_status_events_application ON application_status_events(application_id, created_at);
+
+INSERT INTO application_status_events (id, application_id, from_status, to_status, changed_by, created_at)
+SELECT gen_random_uuid(), a.id, '', 'applied', a.student_id, a.created_at
+FROM applications a;
+
+INSERT INTO application_status_events (id, application_id, from_status, to_status, changed_by, feedback, created_at)
+SELECT gen_random_uuid(), a.id, 'applied',
+       CASE WHEN lower(trim(a.status)) = 'interview' THEN 'invited' ELSE lower(trim(a.status)) END,
+       v.company_id, a.feedback, a.updated_at
+FROM applications a
+JOIN vacancies v ON v.id = a.vacancy_id
+WHERE lower(trim(a.status)) <> 'applied';
+
+-- +goose Down
+DROP TABLE application_status_events;
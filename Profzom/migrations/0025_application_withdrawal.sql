@@ -0,0 +1,15 @@
+-- +goose Up
+ALTER TABLE messages
+    ADD COLUMN kind TEXT NOT NULL DEFAULT 'user';
+
+ALTER TABLE messages
+    ALTER COLUMN sender_id DROP NOT NULL;
+
+-- +goose Down
+DELETE FROM messages WHERE sender_id IS NULL;
+
+ALTER TABLE messages
+    ALTER COLUMN sender_id SET NOT NULL;
+
+ALTER TABLE messages
+    DROP COLUMN kind;
@@ -0,0 +1,30 @@
+-- +goose Up
+CREATE TABLE interviews (
+    id UUID PRIMARY KEY,
+    application_id UUID NOT NULL UNIQUE REFERENCES applications(id) ON DELETE CASCADE,
+    status TEXT NOT NULL,
+    format TEXT NOT NULL,
+    location TEXT NOT NULL DEFAULT '',
+    timezone TEXT NOT NULL DEFAULT 'UTC',
+    proposed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    selected_slot_id UUID,
+    cancelled_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now(),
+    CONSTRAINT interviews_status_check CHECK (status IN ('proposed', 'scheduled', 'cancelled')),
+    CONSTRAINT interviews_format_check CHECK (format IN ('online', 'offline'))
+);
+
+CREATE TABLE interview_slots (
+    id UUID PRIMARY KEY,
+    interview_id UUID NOT NULL REFERENCES interviews(id) ON DELETE CASCADE,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    CONSTRAINT interview_slots_range_check CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX idx_interview_slots_interview ON interview_slots(interview_id, starts_at);
+
+-- +goose Down
+DROP TABLE interview_slots;
+DROP TABLE interviews;
@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE outbox_messages (
+    id UUID PRIMARY KEY,
+    topic TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    available_at TIMESTAMP NOT NULL DEFAULT now(),
+    delivered_at TIMESTAMP,
+    failed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_outbox_messages_pending ON outbox_messages(available_at, created_at)
+    WHERE delivered_at IS NULL AND failed_at IS NULL;
+CREATE INDEX idx_outbox_messages_delivered ON outbox_messages(delivered_at)
+    WHERE delivered_at IS NOT NULL;
+
+-- +goose Down
+DROP TABLE outbox_messages;
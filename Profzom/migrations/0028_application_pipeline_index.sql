@@ -0,0 +1,5 @@
+-- +goose Up
+CREATE INDEX IF NOT EXISTS idx_applications_vacancy_status ON applications(vacancy_id, status);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_applications_vacancy_status;
@@ -0,0 +1,39 @@
+-- +goose Up
+ALTER TABLE student_profiles
+    ADD COLUMN visible_to_companies BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN skill_tags TEXT[] NOT NULL DEFAULT ARRAY[]::TEXT[],
+    ADD COLUMN search_vector TSVECTOR;
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION student_profiles_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector := to_tsvector('russian', coalesce(NEW.bio, ''));
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER student_profiles_search_vector_trigger
+    BEFORE INSERT OR UPDATE OF bio ON student_profiles
+    FOR EACH ROW EXECUTE FUNCTION student_profiles_search_vector_update();
+
+-- синонимы навыков применяет приложение при следующем сохранении профиля; здесь только нижний регистр
+UPDATE student_profiles
+SET search_vector = to_tsvector('russian', coalesce(bio, '')),
+    skill_tags = ARRAY(SELECT DISTINCT lower(btrim(s)) FROM unnest(skills) AS s WHERE btrim(s) <> '');
+
+CREATE INDEX IF NOT EXISTS idx_student_profiles_search ON student_profiles USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_student_profiles_skill_tags ON student_profiles USING GIN (skill_tags);
+CREATE INDEX IF NOT EXISTS idx_student_profiles_visible_created ON student_profiles(created_at DESC, user_id DESC) WHERE visible_to_companies;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_student_profiles_visible_created;
+DROP INDEX IF EXISTS idx_student_profiles_skill_tags;
+DROP INDEX IF EXISTS idx_student_profiles_search;
+DROP TRIGGER IF EXISTS student_profiles_search_vector_trigger ON student_profiles;
+DROP FUNCTION IF EXISTS student_profiles_search_vector_update();
+
+ALTER TABLE student_profiles
+    DROP COLUMN search_vector,
+    DROP COLUMN skill_tags,
+    DROP COLUMN visible_to_companies;
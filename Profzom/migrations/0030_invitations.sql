@@ -0,0 +1,21 @@
+-- +goose Up
+CREATE TABLE invitations (
+    id UUID PRIMARY KEY,
+    vacancy_id UUID NOT NULL REFERENCES vacancies(id) ON DELETE CASCADE,
+    company_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    student_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    message TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'pending',
+    application_id UUID REFERENCES applications(id) ON DELETE SET NULL,
+    responded_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now(),
+    CONSTRAINT invitations_status_check CHECK (status IN ('pending', 'accepted', 'declined', 'cancelled'))
+);
+
+CREATE UNIQUE INDEX idx_invitations_pending ON invitations(vacancy_id, student_id) WHERE status = 'pending';
+CREATE INDEX idx_invitations_student_created ON invitations(student_id, created_at DESC, id DESC);
+CREATE INDEX idx_invitations_company_created ON invitations(company_id, created_at DESC, id DESC);
+
+-- +goose Down
+DROP TABLE invitations;
@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE TABLE saved_vacancies (
+    student_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    vacancy_id UUID NOT NULL REFERENCES vacancies(id) ON DELETE CASCADE,
+    vacancy_updated_at TIMESTAMP NOT NULL,
+    saved_at TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (student_id, vacancy_id)
+);
+
+CREATE INDEX idx_saved_vacancies_student_saved ON saved_vacancies(student_id, saved_at DESC, vacancy_id DESC);
+
+-- +goose Down
+DROP TABLE saved_vacancies;
@@ -0,0 +1,32 @@
+-- +goose Up
+CREATE TABLE saved_searches (
+    id UUID PRIMARY KEY,
+    student_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    query_text TEXT NOT NULL DEFAULT '',
+    vacancy_type TEXT NOT NULL DEFAULT '',
+    location TEXT NOT NULL DEFAULT '',
+    salary_from INTEGER,
+    salary_to INTEGER,
+    salary_period TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_saved_searches_student ON saved_searches(student_id, created_at DESC);
+
+CREATE TABLE saved_search_matches (
+    search_id UUID NOT NULL REFERENCES saved_searches(id) ON DELETE CASCADE,
+    student_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    vacancy_id UUID NOT NULL REFERENCES vacancies(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    notified_at TIMESTAMP,
+    PRIMARY KEY (search_id, vacancy_id)
+);
+
+CREATE INDEX idx_saved_search_matches_pending ON saved_search_matches(student_id, created_at)
+    WHERE notified_at IS NULL;
+
+-- +goose Down
+DROP TABLE saved_search_matches;
+DROP TABLE saved_searches;
@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE message_reads (
+    application_id UUID NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    last_read_message_id UUID NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    last_read_created_at TIMESTAMP NOT NULL,
+    read_at TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (application_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_messages_application_created ON messages(application_id, created_at, id);
+
+-- +goose Down
+DROP TABLE message_reads;
@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE message_attachments (
+    id UUID PRIMARY KEY,
+    application_id UUID NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    message_id UUID REFERENCES messages(id) ON DELETE CASCADE,
+    uploader_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    file_name TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size BIGINT NOT NULL CHECK (size > 0),
+    storage_key TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_message_attachments_message ON message_attachments(message_id) WHERE message_id IS NOT NULL;
+CREATE INDEX idx_message_attachments_application ON message_attachments(application_id);
+
+-- +goose Down
+DROP TABLE message_attachments;
@@ -0,0 +1,57 @@
+-- +goose Up
+CREATE TABLE student_education (
+    student_id UUID NOT NULL REFERENCES student_profiles(user_id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    institution TEXT NOT NULL,
+    degree TEXT NOT NULL DEFAULT '',
+    field TEXT NOT NULL DEFAULT '',
+    start_year INT NOT NULL,
+    end_year INT,
+    PRIMARY KEY (student_id, position)
+);
+
+CREATE TABLE student_experience (
+    student_id UUID NOT NULL REFERENCES student_profiles(user_id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('work', 'project')),
+    title TEXT NOT NULL,
+    organization TEXT NOT NULL DEFAULT '',
+    start_month TEXT NOT NULL,
+    end_month TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    url TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (student_id, position)
+);
+
+CREATE TABLE student_links (
+    student_id UUID NOT NULL REFERENCES student_profiles(user_id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    kind TEXT NOT NULL,
+    url TEXT NOT NULL,
+    PRIMARY KEY (student_id, position)
+);
+
+CREATE TABLE student_languages (
+    student_id UUID NOT NULL REFERENCES student_profiles(user_id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    name TEXT NOT NULL,
+    level TEXT NOT NULL,
+    PRIMARY KEY (student_id, position)
+);
+
+CREATE TABLE student_certificates (
+    student_id UUID NOT NULL REFERENCES student_profiles(user_id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    name TEXT NOT NULL,
+    issuer TEXT NOT NULL DEFAULT '',
+    issued_month TEXT NOT NULL DEFAULT '',
+    url TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (student_id, position)
+);
+
+-- +goose Down
+DROP TABLE student_certificates;
+DROP TABLE student_languages;
+DROP TABLE student_links;
+DROP TABLE student_experience;
+DROP TABLE student_education;
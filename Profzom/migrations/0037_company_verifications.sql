@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE company_verifications (
+    company_id UUID PRIMARY KEY REFERENCES company_profiles(user_id) ON DELETE CASCADE,
+    status TEXT NOT NULL,
+    legal_name TEXT NOT NULL,
+    inn TEXT NOT NULL,
+    ogrn TEXT NOT NULL,
+    rejection_reason TEXT NOT NULL DEFAULT '',
+    submitted_at TIMESTAMP NOT NULL,
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT now(),
+    CONSTRAINT company_verifications_status_check CHECK (status IN ('pending', 'verified', 'rejected'))
+);
+
+CREATE INDEX idx_company_verifications_queue ON company_verifications(status, submitted_at, company_id);
+
+-- +goose Down
+DROP TABLE company_verifications;
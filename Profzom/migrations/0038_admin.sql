@@ -0,0 +1,24 @@
+-- +goose Up
+ALTER TABLE users
+    ADD COLUMN blocked_at TIMESTAMP,
+    ADD COLUMN block_reason TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE admin_audit_log (
+    id UUID PRIMARY KEY,
+    admin_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    action TEXT NOT NULL,
+    target_type TEXT NOT NULL DEFAULT '',
+    target_id TEXT NOT NULL DEFAULT '',
+    details JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_admin_audit_log_created ON admin_audit_log(created_at DESC, id DESC);
+CREATE INDEX idx_admin_audit_log_target ON admin_audit_log(target_id, created_at DESC, id DESC);
+
+-- +goose Down
+DROP TABLE admin_audit_log;
+
+ALTER TABLE users
+    DROP COLUMN block_reason,
+    DROP COLUMN blocked_at;
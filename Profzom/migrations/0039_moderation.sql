@@ -0,0 +1,29 @@
+-- +goose Up
+ALTER TABLE messages
+    ADD COLUMN moderation_status TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE moderation_items (
+    id UUID PRIMARY KEY,
+    content_type TEXT NOT NULL,
+    content_id UUID NOT NULL,
+    author_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    text TEXT NOT NULL DEFAULT '',
+    reasons TEXT[] NOT NULL DEFAULT '{}',
+    status TEXT NOT NULL DEFAULT 'pending',
+    review_reason TEXT NOT NULL DEFAULT '',
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now(),
+    CONSTRAINT moderation_items_type_check CHECK (content_type IN ('vacancy', 'message')),
+    CONSTRAINT moderation_items_status_check CHECK (status IN ('pending', 'approved', 'rejected'))
+);
+
+CREATE UNIQUE INDEX idx_moderation_items_pending ON moderation_items(content_type, content_id) WHERE status = 'pending';
+CREATE INDEX idx_moderation_items_queue ON moderation_items(status, created_at, id);
+
+-- +goose Down
+DROP TABLE moderation_items;
+
+ALTER TABLE messages
+    DROP COLUMN moderation_status;
@@ -0,0 +1,38 @@
+-- +goose Up
+CREATE TABLE reports (
+    id UUID PRIMARY KEY,
+    reporter_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    target_type TEXT NOT NULL,
+    target_id UUID NOT NULL,
+    target_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    reason TEXT NOT NULL,
+    comment TEXT NOT NULL DEFAULT '',
+    text TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'open',
+    resolution TEXT NOT NULL DEFAULT '',
+    resolved_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    resolved_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now(),
+    CONSTRAINT reports_target_type_check CHECK (target_type IN ('vacancy', 'company', 'message')),
+    CONSTRAINT reports_reason_check CHECK (reason IN ('spam', 'fraud', 'harassment', 'discrimination', 'inappropriate', 'other')),
+    CONSTRAINT reports_status_check CHECK (status IN ('open', 'resolved', 'dismissed'))
+);
+
+CREATE UNIQUE INDEX idx_reports_open ON reports(reporter_id, target_type, target_id) WHERE status = 'open';
+CREATE INDEX idx_reports_queue ON reports(status, created_at, id);
+CREATE INDEX idx_reports_target_user ON reports(target_user_id, created_at);
+
+CREATE TABLE user_blocks (
+    blocker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    blocked_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (blocker_id, blocked_id),
+    CONSTRAINT user_blocks_self_check CHECK (blocker_id <> blocked_id)
+);
+
+CREATE INDEX idx_user_blocks_blocked ON user_blocks(blocked_id, blocker_id);
+
+-- +goose Down
+DROP TABLE user_blocks;
+DROP TABLE reports;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- Поиск сравнивает тип без учёта регистра, поэтому индекс строится по lower(vacancy_type).
+DROP INDEX IF EXISTS idx_vacancies_type;
+CREATE INDEX IF NOT EXISTS idx_vacancies_type_lower ON vacancies(lower(vacancy_type));
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_vacancies_type_lower;
+CREATE INDEX IF NOT EXISTS idx_vacancies_type ON vacancies(vacancy_type);
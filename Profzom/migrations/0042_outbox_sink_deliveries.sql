@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE outbox_messages
+    ADD COLUMN delivered_sinks TEXT[] NOT NULL DEFAULT '{}';
+
+-- +goose Down
+ALTER TABLE outbox_messages
+    DROP COLUMN delivered_sinks;
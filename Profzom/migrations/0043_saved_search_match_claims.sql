@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE saved_search_matches
+    ADD COLUMN claimed_until TIMESTAMP;
+
+-- +goose Down
+ALTER TABLE saved_search_matches
+    DROP COLUMN claimed_until;